@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/infra/api/grpc/interceptor"
+	"github.com/felipemagrassi/pix-api/internal/infra/api/grpc/receiver_service"
+	"github.com/felipemagrassi/pix-api/internal/infra/health"
+	"github.com/felipemagrassi/pix-api/internal/infra/rate_limiter"
+	"github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase"
+	receiverv1 "github.com/felipemagrassi/pix-api/pkg/pb/receiver/v1"
+	"google.golang.org/grpc"
+	grpc_health "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// grpcHealthInterval is how often the gRPC health service is refreshed from the
+// readiness checks.
+const grpcHealthInterval = 10 * time.Second
+
+// newGRPCServer serves the receiver service. The limiters, when set, run before
+// and after authentication as in the http routes.
+func newGRPCServer(receiverUseCase receiver_usecase.ReceiverUseCaseInterface, authenticators []auth.Authenticator, ipLimiter, principalLimiter *rate_limiter.RateLimiter, healthServer *grpc_health.Server, enableReflection bool) *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{interceptor.RequestLogger()}
+	if ipLimiter != nil {
+		interceptors = append(interceptors, interceptor.RateLimit(ipLimiter))
+	}
+	interceptors = append(interceptors, interceptor.Authenticate(authenticators...))
+	if principalLimiter != nil {
+		interceptors = append(interceptors, interceptor.RateLimit(principalLimiter))
+	}
+	interceptors = append(interceptors, interceptor.RequireScopes(receiver_service.MethodScopes))
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(interceptor.AuthenticateStream(authenticators...)),
+	)
+
+	receiverv1.RegisterReceiverServiceServer(grpcServer, receiver_service.NewReceiverService(receiverUseCase))
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	if enableReflection {
+		reflection.Register(grpcServer)
+	}
+
+	return grpcServer
+}
+
+// watchHealth reports the service as serving while the critical readiness checks
+// pass, until ctx is canceled.
+func watchHealth(ctx context.Context, healthServer *grpc_health.Server, registry *health.Registry) {
+	update := func() {
+		servingStatus := grpc_health_v1.HealthCheckResponse_SERVING
+		if registry.Check(ctx).Status == health.StatusDown {
+			servingStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+
+		healthServer.SetServingStatus("", servingStatus)
+		healthServer.SetServingStatus(receiverv1.ReceiverService_ServiceDesc.ServiceName, servingStatus)
+	}
+
+	ticker := time.NewTicker(grpcHealthInterval)
+	defer ticker.Stop()
+
+	for {
+		update()
+
+		select {
+		case <-ctx.Done():
+			healthServer.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,21 +2,46 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/felipemagrassi/pix-api/configuration/database/postgres"
 	"github.com/felipemagrassi/pix-api/configuration/env"
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/server"
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
 	"github.com/felipemagrassi/pix-api/docs"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/api/web/controller/claim_controller"
+	"github.com/felipemagrassi/pix-api/internal/infra/api/web/controller/health_controller"
 	"github.com/felipemagrassi/pix-api/internal/infra/api/web/controller/receiver_controller"
-	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/api/web/middleware"
+	"github.com/felipemagrassi/pix-api/internal/infra/auth/jwt_authenticator"
+	"github.com/felipemagrassi/pix-api/internal/infra/cli"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/storage"
+	"github.com/felipemagrassi/pix-api/internal/infra/health"
+	"github.com/felipemagrassi/pix-api/internal/infra/metrics"
+	"github.com/felipemagrassi/pix-api/internal/infra/rate_limiter"
+	"github.com/felipemagrassi/pix-api/internal/infra/scheduler"
+	"github.com/felipemagrassi/pix-api/internal/usecase/api_key_usecase"
+	"github.com/felipemagrassi/pix-api/internal/usecase/claim_usecase"
 	"github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	grpc_health "google.golang.org/grpc/health"
 )
 
+const serviceName = "pix-api"
+
 // @title           Pix Receiver API
 // @version         1.0
 // @description     API to validate receiver PIX information
@@ -27,45 +52,298 @@ import (
 
 // @host      localhost:8080
 // @BasePath  /receiver
+
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
 func main() {
-	ctx := context.Background()
+	if err := run(); err != nil {
+		slog.Error("api stopped with error", "error", err)
+		os.Exit(1)
+	}
+}
 
-	config, err := env.LoadConfig("cmd/api/.env")
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	config, args, err := env.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
 	if err != nil {
-		log.Fatal(err)
-		os.Exit(1)
+		return err
+	}
+
+	command := ""
+	if len(args) > 0 {
+		command = args[0]
 	}
 
-	db, err := postgres.InitializeDatabase(ctx, config.DBUrl, "../../../..")
+	switch command {
+	case "", "apikey", "migrate", "reencrypt":
+	case "config":
+		return config.Print(os.Stdout)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	if _, err := logger.Setup(config.LogLevel, config.LogFormat); err != nil {
+		return err
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    config.TracingExporter,
+		FilePath:    config.TracingFile,
+		ServiceName: serviceName,
+		SampleRatio: config.TracingSampleRatio,
+	})
 	if err != nil {
-		log.Fatal(err)
-		os.Exit(1)
+		return err
 	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			slog.Error("error flushing traces", "error", err)
+		}
+	}()
 
-	defer db.Close()
+	// The migrate command decides itself which migrations to run.
+	store, err := storage.Open(ctx, config, config.DBAutoMigrate && command != "migrate")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			slog.Error("error closing storage", "error", err)
+		}
+	}()
 
-	receiverController := initDependencies(db)
+	if command == "migrate" {
+		if store.Migrator == nil {
+			return fmt.Errorf("migrate is not available with DB_DRIVER %s", config.DBDriver)
+		}
+		return cli.RunMigrate(ctx, os.Stdout, "api", store.Migrator, args[1:])
+	}
+
+	if command == "reencrypt" {
+		if store.Reencrypter == nil {
+			return fmt.Errorf("reencrypt is not available with DB_DRIVER %s", config.DBDriver)
+		}
+		return cli.RunReencrypt(ctx, os.Stdout, "api", store.Reencrypter, args[1:])
+	}
 
-	router := gin.Default()
+	apiKeyUseCase := api_key_usecase.NewApiKeyUseCase(store.ApiKeys, []byte(config.ApiKeyPepper))
+
+	if command == "apikey" {
+		return cli.RunApiKey(ctx, os.Stdout, "api", cli.OutputJSON, apiKeyUseCase, args[1:])
+	}
+
+	appMetrics := metrics.NewMetrics()
+	if store.DB != nil {
+		if err := appMetrics.RegisterDB(store.DB.DB, config.DatabaseName()); err != nil {
+			return err
+		}
+	}
+
+	receiverUseCase, claimUseCase := initDependencies(store, config, appMetrics)
+	receiverController := receiver_controller.NewReceiverController(receiverUseCase)
+	claimController := claim_controller.NewClaimController(claimUseCase)
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(config.TrustedProxyList()); err != nil {
+		return fmt.Errorf("error setting trusted proxies: %w", err)
+	}
+	router.Use(otelgin.Middleware(serviceName), middleware.RequestID(), middleware.RequestLogger(), middleware.Recovery(), middleware.Metrics(appMetrics))
+	router.GET("/metrics", gin.WrapH(appMetrics.Handler()))
+
+	healthRegistry := health.NewRegistry(config.HealthCheckTimeout)
+	if store.DB != nil {
+		healthRegistry.Register("database", health.NewDatabaseChecker(store.DB, store.SchemaVersion), true)
+	}
+
+	healthController := health_controller.NewHealthController(healthRegistry)
+	router.GET("/healthz", healthController.Liveness)
+	router.GET("/readyz", healthController.Readiness)
+	router.GET("/health", healthController.Health)
+
+	authenticators := []auth.Authenticator{apiKeyUseCase}
+	if config.JwtJwksSource != "" {
+		keySet := jwt_authenticator.NewKeySet(config.JwtJwksSource, config.JwtJwksCacheTTL)
+		if err := keySet.Refresh(ctx); err != nil {
+			return err
+		}
+
+		authenticators = append(authenticators, jwt_authenticator.NewJwtAuthenticator(keySet, jwt_authenticator.Config{
+			Issuer:     config.JwtIssuer,
+			Audience:   config.JwtAudience,
+			RoleScopes: jwt_authenticator.ParseRoleScopes(config.JwtRoleScopes),
+		}))
+	}
+
+	// Background workers stop when ctx is canceled; shutdown waits for them after
+	// draining the http server.
+	var workers server.Workers
+
+	// The IP limiter runs before authentication so requests with invalid credentials
+	// are limited too; the limiter keyed by api key or tenant runs after it.
+	var ipLimiter, principalLimiter *rate_limiter.RateLimiter
+	if config.RateLimitEnabled {
+		ipLimiter, principalLimiter, err = initRateLimiters(ctx, &workers, store.DB, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	receivers := router.Group("/receiver")
+	if ipLimiter != nil {
+		receivers.Use(middleware.RateLimit(ipLimiter))
+	}
+	receivers.Use(middleware.Authenticate(authenticators...))
+	if principalLimiter != nil {
+		receivers.Use(middleware.RateLimit(principalLimiter))
+	}
+	receivers.GET("", middleware.RequireScope(auth.ScopeReceiversRead), receiverController.FindReceivers)
+	receivers.GET("/export", middleware.RequireScope(auth.ScopeReceiversRead), receiverController.ExportReceivers)
+	receivers.GET("/:receiverId", middleware.RequireScope(auth.ScopeReceiversRead), receiverController.FindReceiverById)
+	receivers.POST("", middleware.RequireScope(auth.ScopeReceiversWrite), receiverController.CreateReceiver)
+	receivers.PUT("/:receiverId", middleware.RequireScope(auth.ScopeReceiversWrite), receiverController.UpdateReceiver)
+	receivers.POST("/:receiverId/pix-keys/random", middleware.RequireScope(auth.ScopeReceiversWrite), receiverController.AddRandomPixKey)
+	receivers.DELETE("", middleware.RequireScope(auth.ScopeReceiversDelete), receiverController.DeleteReceivers)
+	receivers.GET("/claims", middleware.RequireScope(auth.ScopeClaimsRead), claimController.FindClaims)
+	receivers.GET("/claims/:claimId", middleware.RequireScope(auth.ScopeClaimsRead), claimController.FindClaimById)
+	receivers.POST("/claims", middleware.RequireScope(auth.ScopeClaimsWrite), claimController.OpenClaim)
+	receivers.POST("/claims/:claimId/confirm", middleware.RequireScope(auth.ScopeClaimsWrite), claimController.ConfirmClaim)
+	receivers.POST("/claims/:claimId/cancel", middleware.RequireScope(auth.ScopeClaimsWrite), claimController.CancelClaim)
+	receivers.POST("/claims/:claimId/complete", middleware.RequireScope(auth.ScopeClaimsWrite), claimController.CompleteClaim)
 
-	router.GET("/receiver", receiverController.FindReceivers)
-	router.GET("/receiver/:receiverId", receiverController.FindReceiverById)
-	router.POST("/receiver", receiverController.CreateReceiver)
-	router.PUT("/receiver/:receiverId", receiverController.UpdateReceiver)
-	router.DELETE("/receiver", receiverController.DeleteReceivers)
+	workers.Go(func() { scheduler.RunClaims(ctx, claimUseCase, config.ClaimSchedulerInterval) })
 
 	// TODO: Move to a separated file and adjust localhost to the correct host
 
 	docs.SwaggerInfo.BasePath = "/receiver"
 	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
-	router.Run(config.WebServerPort)
+	httpServer := server.New(router, server.Config{
+		Addr:              config.WebServerPort,
+		ReadTimeout:       config.HttpReadTimeout,
+		ReadHeaderTimeout: config.HttpReadHeaderTimeout,
+		WriteTimeout:      config.HttpWriteTimeout,
+		IdleTimeout:       config.HttpIdleTimeout,
+		MaxHeaderBytes:    config.HttpMaxHeaderBytes,
+	})
+
+	// Both servers stop when ctx is canceled or as soon as either of them fails.
+	serveErrs := make(chan error, 2)
+	servers := 1
+	go func() { serveErrs <- server.Run(ctx, httpServer, config.ShutdownTimeout) }()
+
+	if config.GrpcServerPort != "" {
+		healthServer := grpc_health.NewServer()
+		workers.Go(func() { watchHealth(ctx, healthServer, healthRegistry) })
+
+		grpcServer := newGRPCServer(receiverUseCase, authenticators, ipLimiter, principalLimiter, healthServer, config.GrpcReflection)
+		servers++
+		go func() { serveErrs <- server.RunGRPC(ctx, grpcServer, config.GrpcServerPort, config.ShutdownTimeout) }()
+	}
+
+	serveErr := <-serveErrs
+	// Stop the other server and the workers even when a server failed to start.
+	stop()
+	for i := 1; i < servers; i++ {
+		serveErr = errors.Join(serveErr, <-serveErrs)
+	}
+
+	if err := workers.Wait(config.ShutdownTimeout); err != nil {
+		slog.Error("error stopping background workers", "error", err)
+	}
+
+	if serveErr != nil {
+		return serveErr
+	}
+
+	slog.Info("api stopped")
+	return nil
 }
 
-func initDependencies(database *sqlx.DB) *receiver_controller.ReceiverController {
-	receiverRepo := receiver_repository.NewReceiverRepository(database)
+// initRateLimiters returns the limiter keyed by client IP, applied before
+// authentication, and the one keyed by RATE_LIMIT_KEY, applied after it. With
+// RATE_LIMIT_KEY=ip the configured limits are enforced by the IP limiter alone and
+// the second limiter is nil; otherwise the IP limiter enforces RATE_LIMIT_IP.
+func initRateLimiters(ctx context.Context, workers *server.Workers, db *sqlx.DB, config *env.Config) (*rate_limiter.RateLimiter, *rate_limiter.RateLimiter, error) {
+	limit, err := rate_limiter.ParseLimit(config.RateLimitDefault)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	routes, err := rate_limiter.ParseRouteLimits(config.RateLimitRoutes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	store, err := initRateLimitStore(ctx, workers, db, config.RateLimitStore)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if config.RateLimitKey == rate_limiter.KeyByIP {
+		ipLimiter, err := rate_limiter.NewRateLimiter(store, limit, routes, rate_limiter.KeyByIP)
+		return ipLimiter, nil, err
+	}
+
+	ipLimit, err := rate_limiter.ParseLimit(config.RateLimitIP)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ipLimiter, err := rate_limiter.NewRateLimiter(store, ipLimit, nil, rate_limiter.KeyByIP)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	principalLimiter, err := rate_limiter.NewRateLimiter(store, limit, routes, config.RateLimitKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ipLimiter, principalLimiter, nil
+}
+
+func initRateLimitStore(ctx context.Context, workers *server.Workers, db *sqlx.DB, storeName string) (rate_limiter.Store, error) {
+	var store rate_limiter.Store
+	switch storeName {
+	case "memory":
+		memoryStore := rate_limiter.NewMemoryStore()
+		workers.Go(func() { memoryStore.Run(ctx, time.Minute, 24*time.Hour) })
+		store = memoryStore
+	case "postgres":
+		postgresStore := rate_limiter.NewPostgresStore(db)
+		workers.Go(func() { postgresStore.Run(ctx, time.Hour, 24*time.Hour) })
+		store = postgresStore
+	default:
+		return nil, fmt.Errorf("invalid rate limit store %q: must be memory or postgres", storeName)
+	}
+
+	return store, nil
+}
+
+func initDependencies(store *storage.Storage, config *env.Config, appMetrics *metrics.Metrics) (*receiver_usecase.ReceiverUseCase, *claim_usecase.ClaimUseCase) {
+	receiverRepo := metrics.NewInstrumentedReceiverRepository(store.Receivers, appMetrics)
+
+	pixKeyLimits := entity.PixKeyLimits{Cpf: config.PixKeyLimitCpf, Cnpj: config.PixKeyLimitCnpj}
+
 	receiverUseCase := receiver_usecase.NewReceiverUseCase(receiverRepo)
-	receiverController := receiver_controller.NewReceiverController(receiverUseCase)
+	receiverUseCase.Events = appMetrics
+	receiverUseCase.PixKeyLimits = pixKeyLimits
+	receiverUseCase.Transactions = store.Transactions
+
+	claimUseCase := claim_usecase.NewClaimUseCase(store.Claims, receiverRepo, store.Transactions, config.ClaimResolutionPeriod, config.ClaimCompletionPeriod)
+	claimUseCase.PixKeyLimits = pixKeyLimits
 
-	return receiverController
+	return receiverUseCase, claimUseCase
 }
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/felipemagrassi/pix-api/configuration/env"
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/cli"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/storage"
+	"github.com/felipemagrassi/pix-api/internal/usecase/api_key_usecase"
+	"github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase"
+)
+
+const program = "pixctl"
+
+const usage = `usage: pixctl [settings flags] <command> [arguments]
+
+commands:
+  receivers   create, list, show, update, validate, delete, import and export receivers
+  migrate     run the database migrations
+  reencrypt   encrypt the receivers with the current encryption keys
+  apikey      create, list and revoke api keys
+
+Settings are loaded as by the api, from defaults, the config file, the environment
+and flags such as --db-driver, so pixctl works on the same database.`
+
+// pixctl is the operators' command line: it runs the receiver and api key use
+// cases and the migrations directly against the configured storage.
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	config, args, err := env.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	command := args[0]
+	switch command {
+	case "receivers", "migrate", "reencrypt", "apikey":
+	default:
+		return errors.New(usage)
+	}
+
+	// Results go to stdout, so logs are kept apart on stderr.
+	commandLogger, err := logger.New(os.Stderr, config.LogLevel, config.LogFormat)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(commandLogger)
+
+	store, err := storage.Open(ctx, config, config.DBAutoMigrate && command != "migrate")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			slog.Error("error closing storage", "error", err)
+		}
+	}()
+
+	switch command {
+	case "migrate":
+		if store.Migrator == nil {
+			return fmt.Errorf("migrate is not available with DB_DRIVER %s", config.DBDriver)
+		}
+		return cli.RunMigrate(ctx, os.Stdout, program, store.Migrator, args[1:])
+	case "reencrypt":
+		if store.Reencrypter == nil {
+			return fmt.Errorf("reencrypt is not available with DB_DRIVER %s", config.DBDriver)
+		}
+		return cli.RunReencrypt(ctx, os.Stdout, program, store.Reencrypter, args[1:])
+	case "apikey":
+		apiKeyUseCase := api_key_usecase.NewApiKeyUseCase(store.ApiKeys, []byte(config.ApiKeyPepper))
+		return cli.RunApiKey(ctx, os.Stdout, program, cli.OutputTable, apiKeyUseCase, args[1:])
+	default:
+		receiverUseCase := receiver_usecase.NewReceiverUseCase(store.Receivers)
+		receiverUseCase.PixKeyLimits = entity.PixKeyLimits{Cpf: config.PixKeyLimitCpf, Cnpj: config.PixKeyLimitCnpj}
+		receiverUseCase.Transactions = store.Transactions
+		return runReceivers(ctx, os.Stdout, receiverUseCase, args[1:])
+	}
+}
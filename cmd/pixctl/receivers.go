@@ -0,0 +1,392 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/cli"
+	"github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+)
+
+const receiversUsage = `usage: pixctl receivers <command> [flags] [arguments]
+
+commands:
+  list [--status valid|draft] [--name NAME] [--pix-key KEY] [--pix-key-type TYPE] [--page N]
+  show ID
+  create --name NAME --document DOCUMENT [--email EMAIL] --pix-key-type TYPE --pix-key KEY
+  update [--name NAME] [--document DOCUMENT] [--email EMAIL] [--pix-key-type TYPE --pix-key KEY] ID
+  validate ID
+  delete ID [ID...]
+  import [--format csv|ndjson] FILE|-
+  export [--format csv|ndjson] [--columns COLUMNS] [--mask=false] [--file FILE] [list filters]
+
+every command accepts --tenant TENANT (default "default") and --output table|json
+(default table); flags go before the arguments.`
+
+// The list only loads the columns below, so email and times are left to show.
+var (
+	listHeader = []string{"RECEIVER ID", "NAME", "DOCUMENT", "STATUS", "PIX KEY TYPE", "PIX KEY"}
+	showHeader = append(append([]string(nil), listHeader...), "EMAIL", "CREATED AT", "UPDATED AT")
+)
+
+// receiversCommand holds the flags shared by every receivers command.
+type receiversCommand struct {
+	flags  *flag.FlagSet
+	tenant *string
+	output *string
+}
+
+func newReceiversCommand(name string) *receiversCommand {
+	flags := flag.NewFlagSet("receivers "+name, flag.ContinueOnError)
+
+	return &receiversCommand{
+		flags:  flags,
+		tenant: flags.String("tenant", auth.DefaultTenant, "tenant of the receivers"),
+		output: flags.String("output", cli.OutputTable, "output format, table or json"),
+	}
+}
+
+// parse parses args and returns the tenant scoped context and the output.
+func (c *receiversCommand) parse(ctx context.Context, w io.Writer, args []string) (context.Context, *cli.Output, error) {
+	out, err := cli.ParseFlags(c.flags, args, w, c.output)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return auth.WithTenant(ctx, *c.tenant), out, nil
+}
+
+// filters adds the list filters to the command.
+func (c *receiversCommand) filters() func() (entity.ReceiverStatus, string, string, entity.PixKeyType, error) {
+	status := c.flags.String("status", "", "filter by status, valid or draft")
+	name := c.flags.String("name", "", "filter by name, accepts the % and _ wildcards")
+	pixKey := c.flags.String("pix-key", "", "filter by pix key")
+	pixKeyType := c.flags.String("pix-key-type", "", "filter by pix key type")
+
+	return func() (entity.ReceiverStatus, string, string, entity.PixKeyType, error) {
+		parsedStatus, err := parseStatus(*status)
+		if err != nil {
+			return 0, "", "", 0, err
+		}
+
+		parsedKeyType := entity.PixKeyType(-1)
+		if *pixKeyType != "" {
+			keyType, ok := entity.ParsePixKeyType(*pixKeyType)
+			if !ok {
+				return 0, "", "", 0, fmt.Errorf("invalid pix key type %q", *pixKeyType)
+			}
+			parsedKeyType = keyType
+		}
+
+		return parsedStatus, *name, *pixKey, parsedKeyType, nil
+	}
+}
+
+func runReceivers(ctx context.Context, w io.Writer, receiverUseCase receiver_usecase.ReceiverUseCaseInterface, args []string) error {
+	if len(args) == 0 {
+		return errors.New(receiversUsage)
+	}
+
+	command := newReceiversCommand(args[0])
+
+	switch args[0] {
+	case "list":
+		filters := command.filters()
+		page := command.flags.Int("page", 1, "page, 10 receivers each")
+		ctx, out, err := command.parse(ctx, w, args[1:])
+		if err != nil {
+			return err
+		}
+
+		status, name, pixKey, pixKeyType, err := filters()
+		if err != nil {
+			return err
+		}
+
+		receivers, findErr := receiverUseCase.FindReceivers(ctx, receiver_usecase.FindReceiversInput{
+			Status:      status,
+			Name:        name,
+			PixKeyValue: pixKey,
+			PixKeyType:  pixKeyType,
+			Page:        *page,
+		})
+		if findErr != nil {
+			return cli.Error(findErr)
+		}
+
+		rows := make([][]string, 0, len(receivers.Receivers))
+		for _, receiver := range receivers.Receivers {
+			rows = append(rows, receiverRow(receiver))
+		}
+
+		return out.Print(receivers, listHeader, rows)
+	case "show":
+		ctx, out, err := command.parse(ctx, w, args[1:])
+		if err != nil {
+			return err
+		}
+
+		receiverId, err := receiverIdArgument(command.flags.Args())
+		if err != nil {
+			return err
+		}
+
+		receiver, findErr := receiverUseCase.FindReceiverById(ctx, receiverId)
+		if findErr != nil {
+			return cli.Error(findErr)
+		}
+
+		row := append(receiverRow(*receiver), receiver.Email, receiver.CreatedAt, receiver.UpdatedAt)
+		return out.Print(receiver, showHeader, [][]string{row})
+	case "create":
+		name := command.flags.String("name", "", "receiver name")
+		document := command.flags.String("document", "", "CPF or CNPJ")
+		email := command.flags.String("email", "", "contact email")
+		pixKeyType := command.flags.String("pix-key-type", "", "cnpj, cpf, email, phone or random")
+		pixKey := command.flags.String("pix-key", "", "pix key value, empty to generate a random key")
+		ctx, out, err := command.parse(ctx, w, args[1:])
+		if err != nil {
+			return err
+		}
+
+		receiver, createErr := receiverUseCase.CreateReceiver(ctx, receiver_usecase.CreateReceiverInput{
+			Name:        *name,
+			Document:    *document,
+			Email:       *email,
+			PixKeyValue: *pixKey,
+			PixKeyType:  *pixKeyType,
+		})
+		if createErr != nil {
+			return cli.Error(createErr)
+		}
+
+		return out.Message("receiver %s created with pix key %s", receiver.ReceiverId, receiver.PixKey.KeyValue)
+	case "update":
+		name := command.flags.String("name", "", "receiver name")
+		document := command.flags.String("document", "", "CPF or CNPJ")
+		email := command.flags.String("email", "", "contact email")
+		pixKeyType := command.flags.String("pix-key-type", "", "cnpj, cpf, email, phone or random")
+		pixKey := command.flags.String("pix-key", "", "pix key value")
+		ctx, out, err := command.parse(ctx, w, args[1:])
+		if err != nil {
+			return err
+		}
+
+		receiverId, err := receiverIdArgument(command.flags.Args())
+		if err != nil {
+			return err
+		}
+
+		if updateErr := receiverUseCase.UpdateReceiver(ctx, receiverId, receiver_usecase.UpdateReceiverInput{
+			Name:        *name,
+			Document:    *document,
+			Email:       *email,
+			PixKeyValue: *pixKey,
+			PixKeyType:  *pixKeyType,
+		}); updateErr != nil {
+			return cli.Error(updateErr)
+		}
+
+		return out.Message("receiver %s updated", receiverId)
+	case "validate":
+		ctx, out, err := command.parse(ctx, w, args[1:])
+		if err != nil {
+			return err
+		}
+
+		receiverId, err := receiverIdArgument(command.flags.Args())
+		if err != nil {
+			return err
+		}
+
+		if validateErr := receiverUseCase.ValidateReceiver(ctx, receiverId); validateErr != nil {
+			return cli.Error(validateErr)
+		}
+
+		return out.Message("receiver %s validated", receiverId)
+	case "delete":
+		ctx, out, err := command.parse(ctx, w, args[1:])
+		if err != nil {
+			return err
+		}
+
+		if command.flags.NArg() == 0 {
+			return errors.New(receiversUsage)
+		}
+
+		receiverIds := make([]pkg_entity.ID, 0, command.flags.NArg())
+		for _, id := range command.flags.Args() {
+			receiverId, parseErr := pkg_entity.ParseID(id)
+			if parseErr != nil {
+				return fmt.Errorf("invalid receiver id %q: %w", id, parseErr)
+			}
+			receiverIds = append(receiverIds, receiverId)
+		}
+
+		if deleteErr := receiverUseCase.DeleteReceivers(ctx, receiver_usecase.DeleteReceiversInput{ReceiverIds: receiverIds}); deleteErr != nil {
+			return cli.Error(deleteErr)
+		}
+
+		return out.Message("%d receivers deleted", len(receiverIds))
+	case "import":
+		format := command.flags.String("format", "", "csv or ndjson, from the file extension by default")
+		ctx, out, err := command.parse(ctx, w, args[1:])
+		if err != nil {
+			return err
+		}
+
+		if command.flags.NArg() != 1 {
+			return errors.New(receiversUsage)
+		}
+
+		path := command.flags.Arg(0)
+		if *format == "" {
+			*format = formatFromPath(path)
+		}
+
+		var file io.Reader = os.Stdin
+		if path != "-" {
+			opened, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer opened.Close()
+			file = opened
+		}
+
+		imported, importErr := receiverUseCase.ImportReceivers(ctx, receiver_usecase.ImportReceiversInput{Format: *format}, file)
+		if importErr != nil {
+			return cli.Error(importErr)
+		}
+
+		rows := make([][]string, 0, len(imported.Failed))
+		for _, failed := range imported.Failed {
+			causes := make([]string, 0, len(failed.Causes))
+			for _, cause := range failed.Causes {
+				causes = append(causes, cause.Field+": "+cause.Message)
+			}
+			rows = append(rows, []string{strconv.Itoa(failed.Line), failed.Message, strings.Join(causes, ", ")})
+		}
+
+		if err := out.Print(imported, []string{"LINE", "ERROR", "CAUSES"}, rows); err != nil {
+			return err
+		}
+
+		if len(imported.Failed) > 0 {
+			return fmt.Errorf("%d receivers created, %d rows failed", imported.Created, len(imported.Failed))
+		}
+
+		return nil
+	case "export":
+		filters := command.filters()
+		format := command.flags.String("format", "", "csv or ndjson, from the file extension by default")
+		columns := command.flags.String("columns", "", "comma separated list of columns")
+		mask := command.flags.Bool("mask", true, "mask documents, emails, account numbers and pix keys")
+		path := command.flags.String("file", "", "file to write, stdout by default")
+		ctx, _, err := command.parse(ctx, w, args[1:])
+		if err != nil {
+			return err
+		}
+
+		status, name, pixKey, pixKeyType, err := filters()
+		if err != nil {
+			return err
+		}
+
+		if *format == "" {
+			*format = formatFromPath(*path)
+		}
+
+		var file io.Writer = w
+		if *path != "" {
+			created, err := os.Create(*path)
+			if err != nil {
+				return err
+			}
+			defer created.Close()
+			file = created
+		}
+
+		if _, exportErr := receiverUseCase.ExportReceivers(ctx, receiver_usecase.ExportReceiversInput{
+			Status:      status,
+			Name:        name,
+			PixKeyValue: pixKey,
+			PixKeyType:  pixKeyType,
+			Format:      *format,
+			Columns:     cli.SplitList(*columns),
+			Mask:        *mask,
+		}, file); exportErr != nil {
+			return cli.Error(exportErr)
+		}
+
+		return nil
+	default:
+		return errors.New(receiversUsage)
+	}
+}
+
+func receiverRow(receiver receiver_usecase.FindReceiverOutput) []string {
+	pixKeyType, pixKey := "", ""
+	if receiver.PixKey != nil {
+		pixKeyType, pixKey = receiver.PixKey.KeyType, receiver.PixKey.KeyValue
+	}
+
+	return []string{receiver.ReceiverId, receiver.Name, receiver.Document, statusName(receiver.Status), pixKeyType, pixKey}
+}
+
+func receiverIdArgument(args []string) (pkg_entity.ID, error) {
+	if len(args) != 1 {
+		return pkg_entity.ID{}, errors.New(receiversUsage)
+	}
+
+	receiverId, err := pkg_entity.ParseID(args[0])
+	if err != nil {
+		return pkg_entity.ID{}, fmt.Errorf("invalid receiver id %q: %w", args[0], err)
+	}
+
+	return receiverId, nil
+}
+
+func parseStatus(status string) (entity.ReceiverStatus, error) {
+	switch strings.ToLower(status) {
+	case "":
+		return -1, nil
+	case "valid":
+		return entity.Valid, nil
+	case "draft":
+		return entity.Draft, nil
+	default:
+		return 0, fmt.Errorf("invalid status %q: must be valid or draft", status)
+	}
+}
+
+func statusName(status entity.ReceiverStatus) string {
+	switch status {
+	case entity.Valid:
+		return "valid"
+	case entity.Draft:
+		return "draft"
+	default:
+		return strconv.Itoa(int(status))
+	}
+}
+
+// formatFromPath picks ndjson for .ndjson and .jsonl files and csv otherwise.
+func formatFromPath(path string) string {
+	switch filepath.Ext(path) {
+	case ".ndjson", ".jsonl":
+		return receiver_usecase.ExportFormatNDJSON
+	default:
+		return receiver_usecase.ExportFormatCSV
+	}
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/db/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// migrationLockId is the key of the session advisory lock held while migrating,
+// so replicas starting together apply the migrations one at a time.
+const migrationLockId = 7_402_019_114
+
+const lockPollInterval = 500 * time.Millisecond
+
+type MigrateConfig struct {
+	// AutoMigrate applies the pending migrations when the database is initialized.
+	AutoMigrate bool
+	// LockTimeout bounds the wait for another replica to finish migrating; zero
+	// waits until ctx is done.
+	LockTimeout time.Duration
+}
+
+// Migrator runs the embedded migrations on a dedicated connection that holds the
+// migration advisory lock for the whole operation.
+type Migrator struct {
+	db          *sql.DB
+	lockTimeout time.Duration
+}
+
+func NewMigrator(db *sql.DB, lockTimeout time.Duration) *Migrator {
+	return &Migrator{db: db, lockTimeout: lockTimeout}
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.run(ctx, func(mig *migrate.Migrate) error {
+		return ignoreNoChange(mig.Up())
+	})
+}
+
+// Down reverts the last steps migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return errors.New("down steps must be greater than zero")
+	}
+
+	return m.run(ctx, func(mig *migrate.Migrate) error {
+		return ignoreNoChange(mig.Steps(-steps))
+	})
+}
+
+// Goto migrates up or down to version.
+func (m *Migrator) Goto(ctx context.Context, version uint) error {
+	return m.run(ctx, func(mig *migrate.Migrate) error {
+		return ignoreNoChange(mig.Migrate(version))
+	})
+}
+
+// Force sets the version without running any migration and clears the dirty flag,
+// to recover from a failed migration that was fixed by hand.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	return m.run(ctx, func(mig *migrate.Migrate) error {
+		return mig.Force(version)
+	})
+}
+
+// Version returns the current version, or migrate.ErrNilVersion when none was
+// applied.
+func (m *Migrator) Version(ctx context.Context) (version uint, dirty bool, err error) {
+	err = m.run(ctx, func(mig *migrate.Migrate) error {
+		var versionErr error
+		version, dirty, versionErr = mig.Version()
+		return versionErr
+	})
+
+	return version, dirty, err
+}
+
+func (m *Migrator) run(ctx context.Context, fn func(mig *migrate.Migrate) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := m.lock(ctx, conn); err != nil {
+		return err
+	}
+	defer func() {
+		// The lock is released with the session if the unlock itself fails.
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockId); err != nil {
+			slog.Error("error releasing migration lock", "error", err)
+		}
+	}()
+
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return fmt.Errorf("reading embedded migrations: %w", err)
+	}
+
+	// The driver takes its own advisory lock on the same session, which
+	// postgres grants at once since this session already serializes migrations.
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		return fmt.Errorf("creating migration driver: %w", err)
+	}
+
+	mig, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		return fmt.Errorf("creating migration: %w", err)
+	}
+	mig.Log = migrateLogger{}
+
+	return fn(mig)
+}
+
+// lock waits for the migration advisory lock, polling so the wait honors ctx and
+// the lock timeout.
+func (m *Migrator) lock(ctx context.Context, conn *sql.Conn) error {
+	if m.lockTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.lockTimeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+
+	for waiting := false; ; waiting = true {
+		var locked bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", migrationLockId).Scan(&locked); err != nil {
+			return fmt.Errorf("acquiring migration lock: %w", err)
+		}
+		if locked {
+			return nil
+		}
+
+		if !waiting {
+			slog.Info("waiting for migration lock held by another instance")
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("acquiring migration lock: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+
+	return err
+}
+
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...interface{}) {
+	slog.Info("migration", "message", fmt.Sprintf(format, v...))
+}
+
+func (migrateLogger) Verbose() bool {
+	return false
+}
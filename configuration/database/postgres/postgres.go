@@ -3,56 +3,56 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"log/slog"
-	"path/filepath"
-	"runtime"
+	"time"
 
-	"github.com/golang-migrate/migrate/v4"
 	"github.com/jmoiron/sqlx"
-
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/lib/pq"
 )
 
-func InitializeDatabase(ctx context.Context, databaseURL string, migrationPath string) (*sqlx.DB, error) {
-	db, err := sql.Open("postgres", databaseURL)
-	if err != nil {
-		slog.Error("error connecting to database", err)
-		return nil, err
-	}
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
 
-	if err := db.PingContext(ctx); err != nil {
-		slog.Error("error connecting to database", err)
-		return nil, err
+// ConfigurePool applies the connection pool limits; zero values keep the
+// database/sql defaults.
+func ConfigurePool(db *sqlx.DB, config PoolConfig) {
+	if config.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(config.MaxOpenConns)
 	}
-
-	_, path, _, ok := runtime.Caller(0)
-	if !ok {
-		slog.Error("error getting current path")
-		return nil, err
+	if config.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(config.MaxIdleConns)
 	}
+	if config.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	}
+	if config.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+	}
+}
 
-	migrationsSource := fmt.Sprintf("file://%s", filepath.Join(path, migrationPath))
-	migrationFolder := "db/migrations"
-
-	migrationsSource = fmt.Sprintf("%s/%s", migrationsSource, migrationFolder)
-
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
+func InitializeDatabase(ctx context.Context, databaseURL string, migrateConfig MigrateConfig) (*sqlx.DB, error) {
+	db, err := sql.Open("postgres", databaseURL)
 	if err != nil {
-		slog.Error("error creating migration driver", err)
+		slog.Error("error connecting to database", "error", err)
 		return nil, err
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(migrationsSource, "postgres", driver)
-	if err != nil {
-		slog.Error("error creating migration", err)
+	if err := db.PingContext(ctx); err != nil {
+		slog.Error("error connecting to database", "error", err)
+		db.Close()
 		return nil, err
 	}
 
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		slog.Error("error running migration", err)
-		return nil, err
+	if migrateConfig.AutoMigrate {
+		if err := NewMigrator(db, migrateConfig.LockTimeout).Up(ctx); err != nil {
+			slog.Error("error running migration", "error", err)
+			db.Close()
+			return nil, err
+		}
 	}
 
 	return sqlx.NewDb(db, "postgres"), nil
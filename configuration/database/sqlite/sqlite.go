@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/felipemagrassi/pix-api/db/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// DSN opens path with foreign keys, write-ahead logging so readers do not block
+// the writer, a busy timeout instead of immediate SQLITE_BUSY errors, and a case
+// sensitive LIKE to filter names the same way as postgres.
+func DSN(path string) string {
+	query := url.Values{}
+	query.Set("_foreign_keys", "on")
+	query.Set("_journal_mode", "WAL")
+	query.Set("_busy_timeout", "5000")
+	query.Set("_cslike", "true")
+
+	return "file:" + path + "?" + query.Encode()
+}
+
+func InitializeDatabase(ctx context.Context, path string, autoMigrate bool) (*sqlx.DB, error) {
+	db, err := sql.Open("sqlite3", DSN(path))
+	if err != nil {
+		slog.Error("error opening database", "error", err)
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		slog.Error("error opening database", "error", err)
+		db.Close()
+		return nil, err
+	}
+
+	if autoMigrate {
+		if err := NewMigrator(db).Up(ctx); err != nil {
+			slog.Error("error running migration", "error", err)
+			db.Close()
+			return nil, err
+		}
+	}
+
+	return sqlx.NewDb(db, "sqlite3"), nil
+}
+
+// Migrator runs the embedded sqlite migrations. Unlike postgres no lock is taken
+// across processes, since a sqlite database is served by a single node.
+type Migrator struct {
+	db *sql.DB
+}
+
+func NewMigrator(db *sql.DB) *Migrator {
+	return &Migrator{db: db}
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.run(func(mig *migrate.Migrate) error {
+		return ignoreNoChange(mig.Up())
+	})
+}
+
+// Down reverts the last steps migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return errors.New("down steps must be greater than zero")
+	}
+
+	return m.run(func(mig *migrate.Migrate) error {
+		return ignoreNoChange(mig.Steps(-steps))
+	})
+}
+
+// Goto migrates up or down to version.
+func (m *Migrator) Goto(ctx context.Context, version uint) error {
+	return m.run(func(mig *migrate.Migrate) error {
+		return ignoreNoChange(mig.Migrate(version))
+	})
+}
+
+// Force sets the version without running any migration and clears the dirty flag.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	return m.run(func(mig *migrate.Migrate) error {
+		return mig.Force(version)
+	})
+}
+
+// Version returns the current version, or migrate.ErrNilVersion when none was
+// applied.
+func (m *Migrator) Version(ctx context.Context) (version uint, dirty bool, err error) {
+	err = m.run(func(mig *migrate.Migrate) error {
+		var versionErr error
+		version, dirty, versionErr = mig.Version()
+		return versionErr
+	})
+
+	return version, dirty, err
+}
+
+func (m *Migrator) run(fn func(mig *migrate.Migrate) error) error {
+	source, err := iofs.New(migrations.SQLiteFS, "sqlite")
+	if err != nil {
+		return fmt.Errorf("reading embedded migrations: %w", err)
+	}
+
+	driver, err := sqlite3.WithInstance(m.db, &sqlite3.Config{})
+	if err != nil {
+		return fmt.Errorf("creating migration driver: %w", err)
+	}
+
+	// The migrate instance is not closed, as closing the driver closes db.
+	mig, err := migrate.NewWithInstance("iofs", source, "sqlite3", driver)
+	if err != nil {
+		return fmt.Errorf("creating migration: %w", err)
+	}
+
+	return fn(mig)
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+
+	return err
+}
@@ -1,41 +1,335 @@
 package env
 
 import (
-	"os"
-
-	"github.com/joho/godotenv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
-type conf struct {
-	DBDriver      string `mapstructure:"DB_DRIVER"`
-	DBHost        string `mapstructure:"DB_HOST"`
-	DBPort        string `mapstructure:"DB_PORT"`
-	DBUser        string `mapstructure:"DB_USER"`
-	DBPassword    string `mapstructure:"DB_PASSWORD"`
-	DBName        string `mapstructure:"DB_NAME"`
-	WebServerPort string `mapstructure:"WEB_SERVER_PORT"`
-	DBUrl         string
+// DefaultConfigFile is read when present; an explicit --config or CONFIG_FILE must exist.
+const DefaultConfigFile = "cmd/api/.env"
+
+const redacted = "******"
+
+// Config is loaded from, in increasing priority: the default tag, the config file,
+// the environment and the command line flags. The flag of each setting is its env
+// key in lower case with dashes, e.g. DB_HOST is --db-host.
+type Config struct {
+	DBDriver             string        `env:"DB_DRIVER" default:"postgres" oneof:"postgres sqlite memory"`
+	DBPath               string        `env:"DB_PATH" default:"pix.db"`
+	DBSnapshotDir        string        `env:"DB_SNAPSHOT_DIR"`
+	DBSnapshotInterval   time.Duration `env:"DB_SNAPSHOT_INTERVAL" default:"1m"`
+	DatabaseURL          string        `env:"DATABASE_URL" secret:"true"`
+	DBHost               string        `env:"DB_HOST" default:"localhost"`
+	DBPort               int           `env:"DB_PORT" default:"5432"`
+	DBUser               string        `env:"DB_USER"`
+	DBPassword           string        `env:"DB_PASSWORD" secret:"true"`
+	DBName               string        `env:"DB_NAME"`
+	DBSSLMode            string        `env:"DB_SSLMODE" default:"disable" oneof:"disable allow prefer require verify-ca verify-full"`
+	DBSSLRootCert        string        `env:"DB_SSLROOTCERT"`
+	DBSSLCert            string        `env:"DB_SSLCERT"`
+	DBSSLKey             string        `env:"DB_SSLKEY"`
+	DBConnectTimeout     time.Duration `env:"DB_CONNECT_TIMEOUT" default:"5s"`
+	DBMaxOpenConns       int           `env:"DB_MAX_OPEN_CONNS" default:"25"`
+	DBMaxIdleConns       int           `env:"DB_MAX_IDLE_CONNS" default:"25"`
+	DBConnMaxLifetime    time.Duration `env:"DB_CONN_MAX_LIFETIME" default:"30m"`
+	DBConnMaxIdleTime    time.Duration `env:"DB_CONN_MAX_IDLE_TIME" default:"5m"`
+	DBRowLevelSecurity   bool          `env:"DB_ROW_LEVEL_SECURITY" default:"false"`
+	DBAutoMigrate        bool          `env:"DB_AUTO_MIGRATE" default:"true"`
+	DBMigrateLockTimeout time.Duration `env:"DB_MIGRATE_LOCK_TIMEOUT" default:"5m"`
+
+	WebServerPort         string        `env:"WEB_SERVER_PORT" default:":8080"`
+	HttpReadTimeout       time.Duration `env:"HTTP_READ_TIMEOUT" default:"15s"`
+	HttpReadHeaderTimeout time.Duration `env:"HTTP_READ_HEADER_TIMEOUT" default:"5s"`
+	HttpWriteTimeout      time.Duration `env:"HTTP_WRITE_TIMEOUT" default:"30s"`
+	HttpIdleTimeout       time.Duration `env:"HTTP_IDLE_TIMEOUT" default:"2m"`
+	HttpMaxHeaderBytes    int           `env:"HTTP_MAX_HEADER_BYTES" default:"1048576"`
+	ShutdownTimeout       time.Duration `env:"SHUTDOWN_TIMEOUT" default:"20s"`
+	HealthCheckTimeout    time.Duration `env:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+	TrustedProxies        string        `env:"TRUSTED_PROXIES"`
+
+	GrpcServerPort string `env:"GRPC_SERVER_PORT" default:":9090"`
+	GrpcReflection bool   `env:"GRPC_REFLECTION" default:"true"`
+
+	ApiKeyPepper    string        `env:"API_KEY_PEPPER" required:"true" secret:"true"`
+	JwtJwksSource   string        `env:"JWT_JWKS_SOURCE"`
+	JwtJwksCacheTTL time.Duration `env:"JWT_JWKS_CACHE_TTL" default:"5m"`
+	JwtIssuer       string        `env:"JWT_ISSUER"`
+	JwtAudience     string        `env:"JWT_AUDIENCE"`
+	JwtRoleScopes   string        `env:"JWT_ROLE_SCOPES"`
+
+	RateLimitEnabled bool   `env:"RATE_LIMIT_ENABLED" default:"false"`
+	RateLimitStore   string `env:"RATE_LIMIT_STORE" default:"memory" oneof:"memory postgres"`
+	RateLimitKey     string `env:"RATE_LIMIT_KEY" default:"api_key" oneof:"api_key ip tenant"`
+	RateLimitDefault string `env:"RATE_LIMIT_DEFAULT" default:"60/m"`
+	RateLimitRoutes  string `env:"RATE_LIMIT_ROUTES"`
+	RateLimitIP      string `env:"RATE_LIMIT_IP" default:"600/m"`
+
+	ClaimResolutionPeriod  time.Duration `env:"CLAIM_RESOLUTION_PERIOD" default:"168h"`
+	ClaimCompletionPeriod  time.Duration `env:"CLAIM_COMPLETION_PERIOD" default:"336h"`
+	ClaimSchedulerInterval time.Duration `env:"CLAIM_SCHEDULER_INTERVAL" default:"1m"`
+	PixKeyLimitCpf         int           `env:"PIX_KEY_LIMIT_CPF" default:"5"`
+	PixKeyLimitCnpj        int           `env:"PIX_KEY_LIMIT_CNPJ" default:"20"`
+
+	EncryptionMasterKey     string `env:"ENCRYPTION_MASTER_KEY" secret:"true"`
+	EncryptionMasterKeyFile string `env:"ENCRYPTION_MASTER_KEY_FILE"`
+	EncryptionIndexKey      string `env:"ENCRYPTION_INDEX_KEY" secret:"true"`
+
+	LogLevel  string `env:"LOG_LEVEL" default:"info" oneof:"debug info warn error"`
+	LogFormat string `env:"LOG_FORMAT" default:"json" oneof:"json text"`
+
+	TracingExporter    string  `env:"TRACING_EXPORTER" default:"none" oneof:"none stdout file otlp"`
+	TracingFile        string  `env:"TRACING_FILE"`
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" default:"1"`
+
+	// DBUrl is the connection string built from DATABASE_URL or the DB_* settings,
+	// the database file with sqlite, or empty with memory.
+	DBUrl string
 }
 
-func (c *conf) setDBUrl() {
-	c.DBUrl = "host=" + c.DBHost + " port=" + c.DBPort + " user=" + c.DBUser + " password=" + c.DBPassword + " dbname=" + c.DBName + " sslmode=disable"
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+
+	forEachSetting(c, func(key string, field reflect.StructField, value reflect.Value) {
+		if field.Tag.Get("required") == "true" && value.IsZero() {
+			errs = append(errs, fmt.Errorf("%s is required", key))
+		}
+
+		if oneof := field.Tag.Get("oneof"); oneof != "" {
+			allowed := strings.Fields(oneof)
+			if !contains(allowed, value.String()) {
+				errs = append(errs, fmt.Errorf("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value.String()))
+			}
+		}
+
+		switch v := value.Interface().(type) {
+		case int:
+			if v < 0 {
+				errs = append(errs, fmt.Errorf("%s must not be negative", key))
+			}
+		case time.Duration:
+			if v < 0 {
+				errs = append(errs, fmt.Errorf("%s must not be negative", key))
+			}
+		}
+	})
+
+	if c.DBDriver != "postgres" {
+		if c.DBDriver == "sqlite" && c.DBPath == "" {
+			errs = append(errs, errors.New("DB_PATH is required when DB_DRIVER is sqlite"))
+		}
+		if c.DBRowLevelSecurity {
+			errs = append(errs, errors.New("DB_ROW_LEVEL_SECURITY requires DB_DRIVER postgres"))
+		}
+		if c.RateLimitStore == "postgres" {
+			errs = append(errs, errors.New("RATE_LIMIT_STORE postgres requires DB_DRIVER postgres"))
+		}
+		if c.DBDriver == "memory" && (c.EncryptionMasterKey != "" || c.EncryptionMasterKeyFile != "") {
+			errs = append(errs, errors.New("ENCRYPTION_MASTER_KEY requires DB_DRIVER postgres or sqlite"))
+		}
+	} else if c.DatabaseURL == "" {
+		if c.DBHost == "" {
+			errs = append(errs, errors.New("DB_HOST is required when DATABASE_URL is not set"))
+		}
+		if c.DBUser == "" {
+			errs = append(errs, errors.New("DB_USER is required when DATABASE_URL is not set"))
+		}
+		if c.DBName == "" {
+			errs = append(errs, errors.New("DB_NAME is required when DATABASE_URL is not set"))
+		}
+	} else if parsedURL, err := url.Parse(c.DatabaseURL); err != nil || (parsedURL.Scheme != "postgres" && parsedURL.Scheme != "postgresql") {
+		errs = append(errs, errors.New("DATABASE_URL must be a postgres:// url"))
+	}
+
+	if c.DBMaxIdleConns > c.DBMaxOpenConns && c.DBMaxOpenConns > 0 {
+		errs = append(errs, errors.New("DB_MAX_IDLE_CONNS must not be greater than DB_MAX_OPEN_CONNS"))
+	}
+
+	if _, _, err := net.SplitHostPort(c.WebServerPort); err != nil {
+		errs = append(errs, fmt.Errorf("WEB_SERVER_PORT must be an address such as :8080, got %q", c.WebServerPort))
+	}
+
+	for _, proxy := range c.TrustedProxyList() {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errs = append(errs, fmt.Errorf("TRUSTED_PROXIES must be a list of IPs or CIDRs, got %q", proxy))
+			}
+		}
+	}
+
+	if c.GrpcServerPort != "" {
+		if _, _, err := net.SplitHostPort(c.GrpcServerPort); err != nil {
+			errs = append(errs, fmt.Errorf("GRPC_SERVER_PORT must be an address such as :9090, got %q", c.GrpcServerPort))
+		} else if c.GrpcServerPort == c.WebServerPort {
+			errs = append(errs, errors.New("GRPC_SERVER_PORT must differ from WEB_SERVER_PORT"))
+		}
+	}
+
+	if c.EncryptionMasterKey != "" && c.EncryptionMasterKeyFile != "" {
+		errs = append(errs, errors.New("ENCRYPTION_MASTER_KEY and ENCRYPTION_MASTER_KEY_FILE are mutually exclusive"))
+	}
+
+	if (c.EncryptionMasterKey != "" || c.EncryptionMasterKeyFile != "") != (c.EncryptionIndexKey != "") {
+		errs = append(errs, errors.New("ENCRYPTION_INDEX_KEY must be set together with ENCRYPTION_MASTER_KEY or ENCRYPTION_MASTER_KEY_FILE"))
+	}
+
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		errs = append(errs, errors.New("TRACING_SAMPLE_RATIO must be between 0 and 1"))
+	}
+
+	if c.TracingExporter == "file" && c.TracingFile == "" {
+		errs = append(errs, errors.New("TRACING_FILE is required when TRACING_EXPORTER is file"))
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+
+	return nil
+}
+
+// ValidationError lists every invalid setting.
+type ValidationError struct {
+	Errors []error
 }
 
-func LoadConfig(path string) (*conf, error) {
-	if err := godotenv.Load(path); err != nil {
-		return nil, err
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, "  - "+err.Error())
 	}
-	c := &conf{}
 
-	c.DBDriver = os.Getenv("DB_DRIVER")
-	c.DBHost = os.Getenv("DB_HOST")
-	c.DBPort = os.Getenv("DB_PORT")
-	c.DBUser = os.Getenv("DB_USER")
-	c.DBPassword = os.Getenv("DB_PASSWORD")
-	c.DBName = os.Getenv("DB_NAME")
-	c.WebServerPort = os.Getenv("WEB_SERVER_PORT")
+	return "invalid configuration:\n" + strings.Join(messages, "\n")
+}
 
-	c.setDBUrl()
+// BuildDatabaseURL returns DB_PATH for sqlite, nothing for memory, DATABASE_URL
+// when set, or a postgres url built from the DB_* settings with the TLS mode and
+// connect timeout as query parameters.
+func (c *Config) BuildDatabaseURL() string {
+	switch c.DBDriver {
+	case "sqlite":
+		return c.DBPath
+	case "memory":
+		return ""
+	}
+
+	if c.DatabaseURL != "" {
+		return c.DatabaseURL
+	}
+
+	query := url.Values{}
+	query.Set("sslmode", c.DBSSLMode)
+	if c.DBSSLRootCert != "" {
+		query.Set("sslrootcert", c.DBSSLRootCert)
+	}
+	if c.DBSSLCert != "" {
+		query.Set("sslcert", c.DBSSLCert)
+	}
+	if c.DBSSLKey != "" {
+		query.Set("sslkey", c.DBSSLKey)
+	}
+	if c.DBConnectTimeout > 0 {
+		query.Set("connect_timeout", strconv.Itoa(int(c.DBConnectTimeout.Seconds())))
+	}
+
+	databaseURL := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.DBUser, c.DBPassword),
+		Host:     net.JoinHostPort(c.DBHost, strconv.Itoa(c.DBPort)),
+		Path:     "/" + c.DBName,
+		RawQuery: query.Encode(),
+	}
+
+	return databaseURL.String()
+}
+
+// TrustedProxyList is the IPs and CIDRs in TRUSTED_PROXIES, or nil to trust no proxy
+// and take the client IP from the connection.
+func (c *Config) TrustedProxyList() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(c.TrustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
+}
+
+// DatabaseName is the name of the database in the connection url.
+func (c *Config) DatabaseName() string {
+	if c.DBDriver == "sqlite" {
+		return strings.TrimSuffix(filepath.Base(c.DBPath), filepath.Ext(c.DBPath))
+	}
+
+	parsedURL, err := url.Parse(c.DBUrl)
+	if err != nil {
+		return c.DBName
+	}
+
+	return strings.TrimPrefix(parsedURL.Path, "/")
+}
+
+// Print writes the effective configuration with secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	var lines []string
+
+	forEachSetting(c, func(key string, field reflect.StructField, value reflect.Value) {
+		formatted := formatValue(value)
+		if field.Tag.Get("secret") == "true" && formatted != "" {
+			formatted = redacted
+		}
+		lines = append(lines, key+"="+formatted)
+	})
+
+	if parsedURL, err := url.Parse(c.DBUrl); err == nil {
+		lines = append(lines, "# database url: "+parsedURL.Redacted())
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+func forEachSetting(c *Config, fn func(key string, field reflect.StructField, value reflect.Value)) {
+	configValue := reflect.ValueOf(c).Elem()
+	configType := configValue.Type()
+
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		key := field.Tag.Get("env")
+		if key == "" {
+			continue
+		}
+
+		fn(key, field, configValue.Field(i))
+	}
+}
+
+func formatValue(value reflect.Value) string {
+	switch v := value.Interface().(type) {
+	case time.Duration:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
 
-	return c, nil
+	return false
 }
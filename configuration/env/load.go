@@ -0,0 +1,181 @@
+package env
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+const configFileKey = "CONFIG_FILE"
+
+// Load layers defaults, the config file, the environment and the flags in args,
+// validates the result and returns the arguments left after the flags, such as a
+// subcommand.
+func Load(args []string) (*Config, []string, error) {
+	flagSet := flag.NewFlagSet("api", flag.ContinueOnError)
+
+	configFile := flagSet.String("config", "", "config file in .env format (env "+configFileKey+")")
+	flagValues := map[string]*settingFlag{}
+
+	forEachSetting(&Config{}, func(key string, field reflect.StructField, value reflect.Value) {
+		settingFlag := &settingFlag{isBool: value.Kind() == reflect.Bool}
+		flagValues[key] = settingFlag
+		flagSet.Var(settingFlag, flagName(key), "overrides "+key)
+	})
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	values := map[string]string{}
+	forEachSetting(&Config{}, func(key string, field reflect.StructField, value reflect.Value) {
+		if defaultValue, ok := field.Tag.Lookup("default"); ok {
+			values[key] = defaultValue
+		}
+	})
+
+	fileValues, err := readConfigFile(*configFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for key, value := range fileValues {
+		values[key] = value
+	}
+
+	for key := range flagValues {
+		if value, ok := os.LookupEnv(key); ok {
+			values[key] = value
+		}
+	}
+
+	for key, settingFlag := range flagValues {
+		if settingFlag.set {
+			values[key] = settingFlag.value
+		}
+	}
+
+	config := &Config{}
+	if err := decode(config, values); err != nil {
+		return nil, nil, err
+	}
+
+	config.DBUrl = config.BuildDatabaseURL()
+
+	if err := config.Validate(); err != nil {
+		return config, nil, err
+	}
+
+	return config, flagSet.Args(), nil
+}
+
+// readConfigFile reads the explicit config file, which must exist, or the default
+// one when present.
+func readConfigFile(path string) (map[string]string, error) {
+	explicit := true
+	if path == "" {
+		path, _ = os.LookupEnv(configFileKey)
+	}
+	if path == "" {
+		path = DefaultConfigFile
+		explicit = false
+	}
+
+	values, err := godotenv.Read(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading config file %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+func decode(config *Config, values map[string]string) error {
+	var errs []error
+
+	forEachSetting(config, func(key string, field reflect.StructField, value reflect.Value) {
+		raw, ok := values[key]
+		if !ok {
+			return
+		}
+		raw = strings.TrimSpace(raw)
+
+		switch value.Interface().(type) {
+		case string:
+			value.SetString(raw)
+		case bool:
+			parsed, err := strconv.ParseBool(raw)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s must be true or false, got %q", key, raw))
+				return
+			}
+			value.SetBool(parsed)
+		case int:
+			parsed, err := strconv.Atoi(raw)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s must be an integer, got %q", key, raw))
+				return
+			}
+			value.SetInt(int64(parsed))
+		case float64:
+			parsed, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s must be a number, got %q", key, raw))
+				return
+			}
+			value.SetFloat(parsed)
+		case time.Duration:
+			parsed, err := time.ParseDuration(raw)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s must be a duration such as 5s, got %q", key, raw))
+				return
+			}
+			value.SetInt(int64(parsed))
+		}
+	})
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+
+	return nil
+}
+
+func flagName(key string) string {
+	return strings.ToLower(strings.ReplaceAll(key, "_", "-"))
+}
+
+// settingFlag records whether a flag was given, so unset flags do not override
+// the other sources with an empty value.
+type settingFlag struct {
+	value  string
+	set    bool
+	isBool bool
+}
+
+func (f *settingFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *settingFlag) Set(value string) error {
+	f.value = value
+	f.set = true
+	return nil
+}
+
+func (f *settingFlag) IsBoolFlag() bool {
+	return f.isBool
+}
@@ -0,0 +1,71 @@
+package grpc_err
+
+import (
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ConvertError maps an InternalError to a gRPC status. Bad request causes are sent
+// as field violations in a BadRequest detail, and conflicts as AlreadyExists with
+// a ResourceInfo per conflicting field; internal errors only carry the message,
+// never the original error.
+func ConvertError(internalErr *internal_error.InternalError) error {
+	switch internalErr.Err {
+	case "bad_request":
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(internalErr.Causes))
+		for _, cause := range internalErr.Causes {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: cause.Field, Description: cause.Message})
+		}
+		return NewInvalidArgumentError(internalErr.Message, violations...)
+	case "conflict":
+		return newAlreadyExistsError(internalErr)
+	case "not_found":
+		return status.Error(codes.NotFound, internalErr.Message)
+	case "unauthorized":
+		return status.Error(codes.Unauthenticated, internalErr.Message)
+	case "forbidden":
+		return status.Error(codes.PermissionDenied, internalErr.Message)
+	case "too_many_requests":
+		return status.Error(codes.ResourceExhausted, internalErr.Message)
+	default:
+		return status.Error(codes.Internal, internalErr.Message)
+	}
+}
+
+// NewInvalidArgumentError returns an InvalidArgument status with the violations,
+// if any, attached as a BadRequest detail.
+func NewInvalidArgumentError(message string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(codes.InvalidArgument, message)
+	if len(violations) == 0 {
+		return st.Err()
+	}
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+func newAlreadyExistsError(internalErr *internal_error.InternalError) error {
+	st := status.New(codes.AlreadyExists, internalErr.Message)
+	if len(internalErr.Causes) == 0 {
+		return st.Err()
+	}
+
+	details := make([]protoadapt.MessageV1, 0, len(internalErr.Causes))
+	for _, cause := range internalErr.Causes {
+		details = append(details, &errdetails.ResourceInfo{ResourceName: cause.Field, Description: cause.Message})
+	}
+
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
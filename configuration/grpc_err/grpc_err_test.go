@@ -0,0 +1,69 @@
+package grpc_err
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConvertErrorCodes(t *testing.T) {
+	tests := []struct {
+		err  *internal_error.InternalError
+		code codes.Code
+	}{
+		{internal_error.NewNotFoundError("receiver not found"), codes.NotFound},
+		{internal_error.NewUnauthorizedError("Invalid api key"), codes.Unauthenticated},
+		{internal_error.NewForbiddenError("Missing scope"), codes.PermissionDenied},
+		{internal_error.NewInternalServerError("error finding receivers", errors.New("connection refused")), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		st, ok := status.FromError(ConvertError(tt.err))
+		assert.True(t, ok)
+		assert.Equal(t, tt.code, st.Code())
+		assert.Equal(t, tt.err.Message, st.Message())
+		assert.Empty(t, st.Details())
+	}
+}
+
+func TestConvertErrorFieldViolations(t *testing.T) {
+	internalErr := internal_error.NewBadRequestError("Invalid receiver",
+		internal_error.Causes{Field: "email", Message: "Invalid email"},
+		internal_error.Causes{Field: "key_type", Message: "Invalid Key Type"},
+	)
+
+	st, ok := status.FromError(ConvertError(internalErr))
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "Invalid receiver", st.Message())
+
+	assert.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	assert.True(t, ok)
+	assert.Len(t, badRequest.FieldViolations, 2)
+	assert.Equal(t, "email", badRequest.FieldViolations[0].Field)
+	assert.Equal(t, "Invalid email", badRequest.FieldViolations[0].Description)
+	assert.Equal(t, "key_type", badRequest.FieldViolations[1].Field)
+}
+
+func TestConvertErrorConflict(t *testing.T) {
+	internalErr := internal_error.NewConflictError("Pix key already registered",
+		internal_error.Causes{Field: "pix_key_value", Message: "Pix key is registered to another receiver"},
+	)
+
+	st, ok := status.FromError(ConvertError(internalErr))
+	assert.True(t, ok)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	assert.Equal(t, "Pix key already registered", st.Message())
+
+	assert.Len(t, st.Details(), 1)
+	resourceInfo, ok := st.Details()[0].(*errdetails.ResourceInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "pix_key_value", resourceInfo.ResourceName)
+	assert.Equal(t, "Pix key is registered to another receiver", resourceInfo.Description)
+}
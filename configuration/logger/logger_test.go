@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := New(&buf, "info", "json")
+	assert.Nil(t, err)
+
+	logger.Debug("hidden")
+	logger.Info("shown", "key", "value")
+
+	var line map[string]any
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "shown", line["msg"])
+	assert.Equal(t, "value", line["key"])
+
+	_, err = New(&buf, "verbose", "json")
+	assert.NotNil(t, err)
+
+	_, err = New(&buf, "info", "xml")
+	assert.NotNil(t, err)
+}
+
+func TestRequestIdContext(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, _ := New(&buf, "info", "json")
+	ctx := WithLogger(context.Background(), logger)
+
+	assert.Equal(t, "", RequestIdFromContext(ctx))
+
+	ctx = WithRequestId(ctx, "abc")
+	ctx = With(ctx, "route", "/receiver")
+	assert.Equal(t, "abc", RequestIdFromContext(ctx))
+
+	FromContext(ctx).Info("request")
+
+	var line map[string]any
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "abc", line["request_id"])
+	assert.Equal(t, "/receiver", line["route"])
+}
@@ -7,10 +7,11 @@ import (
 )
 
 type RestErr struct {
-	Message string   `json:"message"`
-	Err     string   `json:"error"`
-	Code    int      `json:"code"`
-	Causes  []Causes `json:"causes"`
+	Message   string   `json:"message"`
+	Err       string   `json:"error"`
+	Code      int      `json:"code"`
+	Causes    []Causes `json:"causes"`
+	RequestId string   `json:"request_id,omitempty"`
 }
 
 type Causes struct {
@@ -22,6 +23,13 @@ func (r *RestErr) Error() string {
 	return r.Message
 }
 
+// WithRequestId sets the id of the request that produced the error, so clients can
+// report it back and it can be found in the logs.
+func (r *RestErr) WithRequestId(requestId string) *RestErr {
+	r.RequestId = requestId
+	return r
+}
+
 func ConvertError(internalErr *internal_error.InternalError) *RestErr {
 	switch internalErr.Err {
 	case "bad_request":
@@ -30,8 +38,18 @@ func ConvertError(internalErr *internal_error.InternalError) *RestErr {
 			causes = append(causes, Causes{Field: cause.Field, Message: cause.Message})
 		}
 		return NewBadRequestError(internalErr.Message, causes...)
+	case "conflict":
+		causes := make([]Causes, 0)
+		for _, cause := range internalErr.Causes {
+			causes = append(causes, Causes{Field: cause.Field, Message: cause.Message})
+		}
+		return NewConflictError(internalErr.Message, causes...)
 	case "not_found":
 		return NewNotFoundError(internalErr.Message)
+	case "unauthorized":
+		return NewUnauthorizedError(internalErr.Message)
+	case "forbidden":
+		return NewForbiddenError(internalErr.Message)
 	default:
 		return NewInternalServerError(internalErr.Message, internalErr.OriginalError)
 	}
@@ -54,6 +72,39 @@ func NewNotFoundError(message string) *RestErr {
 	}
 }
 
+func NewUnauthorizedError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "unauthorized",
+		Code:    http.StatusUnauthorized,
+	}
+}
+
+func NewForbiddenError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "forbidden",
+		Code:    http.StatusForbidden,
+	}
+}
+
+func NewConflictError(message string, causes ...Causes) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "conflict",
+		Code:    http.StatusConflict,
+		Causes:  causes,
+	}
+}
+
+func NewTooManyRequestsError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "too_many_requests",
+		Code:    http.StatusTooManyRequests,
+	}
+}
+
 func NewInternalServerError(message string, err error) *RestErr {
 	result := &RestErr{
 		Message: message,
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// RunGRPC serves on addr until ctx is canceled, then stops accepting calls and
+// waits up to shutdownTimeout for in-flight calls to finish.
+func RunGRPC(ctx context.Context, server *grpc.Server, addr string, shutdownTimeout time.Duration) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("grpc server listening", "addr", listener.Addr().String())
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down grpc server", "timeout", shutdownTimeout.String())
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return <-serveErr
+	case <-time.After(shutdownTimeout):
+		// Drop the calls that did not finish in time.
+		server.Stop()
+		<-stopped
+		return context.DeadlineExceeded
+	}
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func freeAddr(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func waitListening(t *testing.T, addr string) {
+	for i := 0; i < 100; i++ {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("server did not start")
+}
+
+func TestRunDrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	addr := freeAddr(t)
+	srv := New(handler, Config{Addr: addr})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- Run(ctx, srv, time.Second) }()
+	waitListening(t, addr)
+
+	status := make(chan int, 1)
+	go func() {
+		res, err := http.Get("http://" + addr)
+		if err != nil {
+			status <- 0
+			return
+		}
+		res.Body.Close()
+		status <- res.StatusCode
+	}()
+
+	<-started
+	cancel()
+
+	assert.Equal(t, http.StatusOK, <-status)
+	assert.Nil(t, <-runErr)
+
+	_, err := http.Get("http://" + addr)
+	assert.NotNil(t, err)
+}
+
+func TestRunShutdownTimeout(t *testing.T) {
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(time.Second)
+	})
+
+	addr := freeAddr(t)
+	srv := New(handler, Config{Addr: addr})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- Run(ctx, srv, 50*time.Millisecond) }()
+	waitListening(t, addr)
+
+	go http.Get("http://" + addr)
+
+	<-started
+	cancel()
+
+	assert.ErrorIs(t, <-runErr, context.DeadlineExceeded)
+}
+
+func TestWorkersWait(t *testing.T) {
+	var workers Workers
+
+	ctx, cancel := context.WithCancel(context.Background())
+	workers.Go(func() { <-ctx.Done() })
+
+	assert.NotNil(t, workers.Wait(10*time.Millisecond))
+
+	cancel()
+	assert.Nil(t, workers.Wait(time.Second))
+}
+
+func TestRunGRPCDrainsInFlightCalls(t *testing.T) {
+	started := make(chan struct{})
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(func(srv any, stream grpc.ServerStream) error {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		return status.Error(codes.Unimplemented, "unknown")
+	}))
+
+	addr := freeAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- RunGRPC(ctx, srv, addr, time.Second) }()
+	waitListening(t, addr)
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	code := make(chan codes.Code, 1)
+	go func() {
+		err := conn.Invoke(context.Background(), "/test.Service/Method", &emptypb.Empty{}, &emptypb.Empty{})
+		code <- status.Code(err)
+	}()
+
+	<-started
+	cancel()
+
+	assert.Equal(t, codes.Unimplemented, <-code)
+	assert.Nil(t, <-runErr)
+}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+	api_key_id uuid DEFAULT gen_random_uuid(),
+	name varchar NOT NULL,
+	prefix varchar NOT NULL,
+	key_hash varchar NOT NULL,
+	scopes varchar NOT NULL,
+	created_at timestamp DEFAULT now(),
+	revoked_at timestamp,
+	PRIMARY KEY (api_key_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS api_keys_key_hash_idx ON api_keys (key_hash);
@@ -0,0 +1,8 @@
+DROP POLICY IF EXISTS receivers_tenant_isolation ON receivers;
+ALTER TABLE receivers DISABLE ROW LEVEL SECURITY;
+
+DROP INDEX IF EXISTS api_keys_tenant_id_idx;
+DROP INDEX IF EXISTS receivers_tenant_id_created_at_idx;
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE receivers DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,13 @@
+ALTER TABLE receivers ADD COLUMN IF NOT EXISTS tenant_id varchar NOT NULL DEFAULT 'default';
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS tenant_id varchar NOT NULL DEFAULT 'default';
+
+CREATE INDEX IF NOT EXISTS receivers_tenant_id_created_at_idx ON receivers (tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS api_keys_tenant_id_idx ON api_keys (tenant_id);
+
+-- Policies only apply to roles that do not own the table (and are not superusers),
+-- and require the repository to set app.tenant_id (DB_ROW_LEVEL_SECURITY=true).
+ALTER TABLE receivers ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY receivers_tenant_isolation ON receivers
+	USING (tenant_id = current_setting('app.tenant_id', true))
+	WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
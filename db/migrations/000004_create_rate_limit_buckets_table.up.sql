@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+	bucket_key varchar NOT NULL,
+	tenant_id varchar NOT NULL DEFAULT '',
+	tokens double precision NOT NULL,
+	updated_at timestamptz NOT NULL,
+	PRIMARY KEY (bucket_key)
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_buckets_updated_at_idx ON rate_limit_buckets (updated_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS receivers_tenant_id_pix_key_canonical_idx;
+
+ALTER TABLE receivers DROP COLUMN IF EXISTS pix_key_canonical;
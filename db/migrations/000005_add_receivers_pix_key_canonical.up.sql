@@ -0,0 +1,15 @@
+-- The canonical key mirrors entity.PixKey.Canonical: CPF and CNPJ digits only,
+-- phones as +55 followed by the area code and number, emails and random keys in
+-- lower case. Duplicated keys of a tenant must be resolved before migrating.
+ALTER TABLE receivers ADD COLUMN IF NOT EXISTS pix_key_canonical varchar;
+
+UPDATE receivers SET pix_key_canonical = CASE pix_key_type
+	WHEN 1 THEN regexp_replace(pix_key, '\D', '', 'g')
+	WHEN 2 THEN regexp_replace(pix_key, '\D', '', 'g')
+	WHEN 4 THEN '+55' || right(regexp_replace(pix_key, '\D', '', 'g'), 11)
+	ELSE lower(pix_key)
+END;
+
+ALTER TABLE receivers ALTER COLUMN pix_key_canonical SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS receivers_tenant_id_pix_key_canonical_idx ON receivers (tenant_id, pix_key_canonical);
@@ -0,0 +1,24 @@
+-- Row level security is enabled on claims by 000010, which gives the claim
+-- scheduler a function to read them across tenants. A key has at most one active
+-- (open, waiting resolution or confirmed) claim.
+CREATE TABLE IF NOT EXISTS claims (
+	claim_id uuid NOT NULL,
+	tenant_id varchar NOT NULL,
+	type integer NOT NULL,
+	status integer NOT NULL,
+	pix_key varchar NOT NULL,
+	pix_key_type integer NOT NULL,
+	pix_key_canonical varchar NOT NULL,
+	claimer_receiver_id uuid NOT NULL,
+	donor_receiver_id uuid NOT NULL,
+	cancel_reason varchar NOT NULL DEFAULT '',
+	resolution_deadline timestamptz NOT NULL,
+	completion_deadline timestamptz,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	PRIMARY KEY (claim_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS claims_tenant_id_pix_key_canonical_active_idx ON claims (tenant_id, pix_key_canonical) WHERE status IN (1, 2, 3);
+CREATE INDEX IF NOT EXISTS claims_tenant_id_created_at_idx ON claims (tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS claims_status_resolution_deadline_idx ON claims (status, resolution_deadline);
@@ -0,0 +1,2 @@
+-- The phone keys are left in E.164, the format they were typed in is not kept.
+SELECT 1;
@@ -0,0 +1,5 @@
+-- Phone keys are stored in E.164 since entity.PhonePixKeyType normalizes them, which
+-- is their canonical form. Keys with an unknown area code or a landline number are
+-- kept, they are only rejected when created or updated.
+UPDATE receivers SET pix_key = pix_key_canonical WHERE pix_key_type = 4;
+UPDATE claims SET pix_key = pix_key_canonical WHERE pix_key_type = 4;
@@ -0,0 +1,9 @@
+-- Encrypted rows cannot be read back without their data key, so only revert while
+-- every receiver is in plaintext.
+DROP INDEX IF EXISTS receivers_tenant_id_document_index_idx;
+DROP INDEX IF EXISTS receivers_tenant_id_pix_key_index_idx;
+
+ALTER TABLE receivers DROP COLUMN IF EXISTS account_number_index;
+ALTER TABLE receivers DROP COLUMN IF EXISTS document_index;
+ALTER TABLE receivers DROP COLUMN IF EXISTS pix_key_index;
+ALTER TABLE receivers DROP COLUMN IF EXISTS data_key;
@@ -0,0 +1,11 @@
+-- With ENCRYPTION_MASTER_KEY set, document, pix_key and account_number hold AES-GCM
+-- ciphertext sealed with data_key, a data key wrapped by the master key, and
+-- pix_key_canonical holds a blind index. Existing rows keep an empty data_key and
+-- stay in plaintext until the reencrypt command rewrites them.
+ALTER TABLE receivers ADD COLUMN IF NOT EXISTS data_key varchar NOT NULL DEFAULT '';
+ALTER TABLE receivers ADD COLUMN IF NOT EXISTS pix_key_index varchar NOT NULL DEFAULT '';
+ALTER TABLE receivers ADD COLUMN IF NOT EXISTS document_index varchar NOT NULL DEFAULT '';
+ALTER TABLE receivers ADD COLUMN IF NOT EXISTS account_number_index varchar NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS receivers_tenant_id_pix_key_index_idx ON receivers (tenant_id, pix_key_index);
+CREATE INDEX IF NOT EXISTS receivers_tenant_id_document_index_idx ON receivers (tenant_id, document_index);
@@ -0,0 +1,4 @@
+-- With ENCRYPTION_MASTER_KEY set, pix_key holds AES-GCM ciphertext sealed with
+-- data_key and pix_key_canonical a blind index, as in receivers. Existing claims
+-- stay in plaintext until the reencrypt command rewrites them.
+ALTER TABLE claims ADD COLUMN IF NOT EXISTS data_key varchar NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+DROP FUNCTION IF EXISTS find_claims_to_resolve(timestamptz, integer);
+
+DROP POLICY IF EXISTS claims_tenant_isolation ON claims;
+ALTER TABLE claims DISABLE ROW LEVEL SECURITY;
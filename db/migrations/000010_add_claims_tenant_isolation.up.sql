@@ -0,0 +1,26 @@
+-- Isolates claims per tenant like receivers (see 000003), for roles that do not
+-- own the table when DB_ROW_LEVEL_SECURITY=true.
+ALTER TABLE claims ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY claims_tenant_isolation ON claims
+	USING (tenant_id = current_setting('app.tenant_id', true))
+	WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+-- The claim scheduler reads the claims to resolve across tenants through this
+-- function, which runs as its owner, the owner of the table, so the policy does
+-- not apply. Open claims are due at once, waiting resolution ones past their
+-- resolution deadline and confirmed ones past their completion deadline.
+CREATE OR REPLACE FUNCTION find_claims_to_resolve(resolve_at timestamptz, batch_size integer)
+RETURNS SETOF claims
+LANGUAGE sql
+STABLE
+SECURITY DEFINER
+SET search_path FROM CURRENT
+AS $$
+	SELECT * FROM claims
+	WHERE status = 1
+		OR (status = 2 AND resolution_deadline <= resolve_at)
+		OR (status = 3 AND completion_deadline <= resolve_at)
+	ORDER BY created_at
+	LIMIT batch_size
+$$;
@@ -0,0 +1,47 @@
+// Package migrations embeds the SQL migrations so the binary does not depend on
+// the source tree at runtime.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// FS holds the postgres migrations.
+//
+//go:embed *.sql
+var FS embed.FS
+
+// SQLiteFS holds the sqlite migrations under the sqlite directory.
+//
+//go:embed sqlite/*.sql
+var SQLiteFS embed.FS
+
+// LatestVersion returns the version of the newest up migration in dir, which is
+// the version a database is at once every migration of the binary was applied.
+func LatestVersion(fsys fs.FS, dir string) (uint, error) {
+	ups, err := fs.Glob(fsys, path.Join(dir, "*.up.sql"))
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, up := range ups {
+		prefix, _, _ := strings.Cut(path.Base(up), "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration file name %s: %w", up, err)
+		}
+		latest = max(latest, uint(version))
+	}
+
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations in %s", dir)
+	}
+
+	return latest, nil
+}
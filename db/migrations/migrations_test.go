@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEveryMigrationIsReversible(t *testing.T) {
+	for pattern, migrationsFS := range map[string]embed.FS{"*.up.sql": FS, "sqlite/*.up.sql": SQLiteFS} {
+		ups, err := fs.Glob(migrationsFS, pattern)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, ups)
+
+		for _, up := range ups {
+			down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+			_, err := fs.Stat(migrationsFS, down)
+			assert.Nil(t, err, "missing %s", down)
+		}
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	for dir, migrationsFS := range map[string]embed.FS{".": FS, "sqlite": SQLiteFS} {
+		ups, err := fs.Glob(migrationsFS, path.Join(dir, "*.up.sql"))
+		assert.Nil(t, err)
+
+		version, err := LatestVersion(migrationsFS, dir)
+		assert.Nil(t, err)
+		assert.Equal(t, uint(len(ups)), version)
+	}
+
+	_, err := LatestVersion(SQLiteFS, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS receivers (
+	receiver_id text NOT NULL,
+	tenant_id text NOT NULL DEFAULT 'default',
+	name text NOT NULL,
+	document text NOT NULL,
+	email text,
+	status integer NOT NULL,
+	pix_key text NOT NULL,
+	pix_key_type integer NOT NULL,
+	bank text,
+	office text,
+	account_number text,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (receiver_id)
+);
+
+CREATE INDEX IF NOT EXISTS receivers_tenant_id_created_at_idx ON receivers (tenant_id, created_at DESC);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+	api_key_id text NOT NULL,
+	tenant_id text NOT NULL DEFAULT 'default',
+	name text NOT NULL,
+	prefix text NOT NULL,
+	key_hash text NOT NULL,
+	scopes text NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	revoked_at timestamp,
+	PRIMARY KEY (api_key_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS api_keys_key_hash_idx ON api_keys (key_hash);
+CREATE INDEX IF NOT EXISTS api_keys_tenant_id_idx ON api_keys (tenant_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS receivers_tenant_id_pix_key_canonical_idx;
+
+ALTER TABLE receivers DROP COLUMN pix_key_canonical;
@@ -0,0 +1,13 @@
+-- See the postgres migration for the canonical form of each key type. Phone keys
+-- only hold digits and an optional +55, so the last 11 characters are the area
+-- code and number.
+ALTER TABLE receivers ADD COLUMN pix_key_canonical text NOT NULL DEFAULT '';
+
+UPDATE receivers SET pix_key_canonical = CASE pix_key_type
+	WHEN 1 THEN replace(replace(replace(pix_key, '.', ''), '/', ''), '-', '')
+	WHEN 2 THEN replace(replace(pix_key, '.', ''), '-', '')
+	WHEN 4 THEN '+55' || substr(pix_key, -11)
+	ELSE lower(pix_key)
+END;
+
+CREATE UNIQUE INDEX IF NOT EXISTS receivers_tenant_id_pix_key_canonical_idx ON receivers (tenant_id, pix_key_canonical);
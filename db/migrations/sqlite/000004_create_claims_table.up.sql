@@ -0,0 +1,22 @@
+-- See the postgres migration.
+CREATE TABLE IF NOT EXISTS claims (
+	claim_id text NOT NULL,
+	tenant_id text NOT NULL,
+	type integer NOT NULL,
+	status integer NOT NULL,
+	pix_key text NOT NULL,
+	pix_key_type integer NOT NULL,
+	pix_key_canonical text NOT NULL,
+	claimer_receiver_id text NOT NULL,
+	donor_receiver_id text NOT NULL,
+	cancel_reason text NOT NULL DEFAULT '',
+	resolution_deadline timestamp NOT NULL,
+	completion_deadline timestamp,
+	created_at timestamp NOT NULL,
+	updated_at timestamp NOT NULL,
+	PRIMARY KEY (claim_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS claims_tenant_id_pix_key_canonical_active_idx ON claims (tenant_id, pix_key_canonical) WHERE status IN (1, 2, 3);
+CREATE INDEX IF NOT EXISTS claims_tenant_id_created_at_idx ON claims (tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS claims_status_resolution_deadline_idx ON claims (status, resolution_deadline);
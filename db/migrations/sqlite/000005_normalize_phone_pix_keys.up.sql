@@ -0,0 +1,3 @@
+-- See the postgres migration.
+UPDATE receivers SET pix_key = pix_key_canonical WHERE pix_key_type = 4;
+UPDATE claims SET pix_key = pix_key_canonical WHERE pix_key_type = 4;
@@ -0,0 +1,8 @@
+-- See the postgres migration.
+DROP INDEX IF EXISTS receivers_tenant_id_document_index_idx;
+DROP INDEX IF EXISTS receivers_tenant_id_pix_key_index_idx;
+
+ALTER TABLE receivers DROP COLUMN account_number_index;
+ALTER TABLE receivers DROP COLUMN document_index;
+ALTER TABLE receivers DROP COLUMN pix_key_index;
+ALTER TABLE receivers DROP COLUMN data_key;
@@ -0,0 +1,8 @@
+-- See the postgres migration.
+ALTER TABLE receivers ADD COLUMN data_key text NOT NULL DEFAULT '';
+ALTER TABLE receivers ADD COLUMN pix_key_index text NOT NULL DEFAULT '';
+ALTER TABLE receivers ADD COLUMN document_index text NOT NULL DEFAULT '';
+ALTER TABLE receivers ADD COLUMN account_number_index text NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS receivers_tenant_id_pix_key_index_idx ON receivers (tenant_id, pix_key_index);
+CREATE INDEX IF NOT EXISTS receivers_tenant_id_document_index_idx ON receivers (tenant_id, document_index);
@@ -0,0 +1,2 @@
+-- See the postgres migration.
+ALTER TABLE claims DROP COLUMN data_key;
@@ -0,0 +1,2 @@
+-- See the postgres migration.
+ALTER TABLE claims ADD COLUMN data_key text NOT NULL DEFAULT '';
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+)
+
+const (
+	ScopeReceiversRead   = "receivers:read"
+	ScopeReceiversWrite  = "receivers:write"
+	ScopeReceiversDelete = "receivers:delete"
+	ScopeClaimsRead      = "claims:read"
+	ScopeClaimsWrite     = "claims:write"
+	ScopePiiRead         = "pii:read"
+)
+
+// Scopes lists every scope that can be granted to a principal.
+var Scopes = []string{
+	ScopeReceiversRead,
+	ScopeReceiversWrite,
+	ScopeReceiversDelete,
+	ScopeClaimsRead,
+	ScopeClaimsWrite,
+	ScopePiiRead,
+}
+
+const (
+	MethodApiKey = "api_key"
+	MethodJwt    = "jwt"
+)
+
+// DefaultTenant is the tenant that owned every receiver before tenants existed.
+const DefaultTenant = "default"
+
+type Principal struct {
+	Subject  string
+	Method   string
+	TenantId string
+	Roles    []string
+	Scopes   []string
+}
+
+// Credentials holds whatever the caller presented to identify itself.
+type Credentials struct {
+	ApiKey      string
+	BearerToken string
+}
+
+// Authenticator resolves credentials into a principal. It returns nil, nil when
+// the credentials are not of the kind it handles, so authenticators can be chained.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credentials Credentials) (*Principal, *internal_error.InternalError)
+}
+
+type (
+	principalKey struct{}
+	tenantKey    struct{}
+)
+
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func IsValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// ActorFromContext identifies who is performing the current operation, for
+// auditing and logging. Work without a principal is attributed to "system".
+func ActorFromContext(ctx context.Context) string {
+	if principal, ok := PrincipalFromContext(ctx); ok && principal.Subject != "" {
+		return principal.Method + ":" + principal.Subject
+	}
+
+	return "system"
+}
+
+// CanReadPII reports whether personal data, such as documents, emails, account
+// numbers and pix keys, can be returned unmasked: to principals with the pii:read
+// scope and to work without a principal, such as scripts and commands.
+func CanReadPII(ctx context.Context) bool {
+	principal, ok := PrincipalFromContext(ctx)
+	return !ok || principal.HasScope(ScopePiiRead)
+}
+
+// WithTenant scopes the context to a tenant without an authenticated principal,
+// for work that does not come from a request such as scripts and commands.
+func WithTenant(ctx context.Context, tenantId string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantId)
+}
+
+// TenantFromContext resolves the tenant from an explicit tenant scope or from the
+// authenticated principal, in that order.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	if tenantId, ok := ctx.Value(tenantKey{}).(string); ok && tenantId != "" {
+		return tenantId, true
+	}
+
+	if principal, ok := PrincipalFromContext(ctx); ok && principal.TenantId != "" {
+		return principal.TenantId, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantFromContext(t *testing.T) {
+	_, ok := TenantFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithPrincipal(context.Background(), &Principal{Subject: "key", TenantId: "acme"})
+	tenantId, ok := TenantFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenantId)
+
+	tenantId, ok = TenantFromContext(WithTenant(ctx, "globex"))
+	assert.True(t, ok)
+	assert.Equal(t, "globex", tenantId)
+}
+
+func TestPrincipalHasScope(t *testing.T) {
+	principal := &Principal{Scopes: []string{ScopeReceiversRead}}
+
+	assert.True(t, principal.HasScope(ScopeReceiversRead))
+	assert.False(t, principal.HasScope(ScopeReceiversWrite))
+}
+
+func TestCanReadPII(t *testing.T) {
+	assert.True(t, CanReadPII(WithTenant(context.Background(), "acme")), "work without a principal")
+
+	ctx := WithPrincipal(context.Background(), &Principal{Subject: "key", Scopes: []string{ScopeReceiversRead}})
+	assert.False(t, CanReadPII(ctx))
+
+	ctx = WithPrincipal(context.Background(), &Principal{Subject: "key", Scopes: []string{ScopeReceiversRead, ScopePiiRead}})
+	assert.True(t, CanReadPII(ctx))
+}
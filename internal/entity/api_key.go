@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/felipemagrassi/pix-api/pkg/entity"
+)
+
+const (
+	ApiKeyPrefix       = "pix"
+	apiKeySecretLength = 32
+	apiKeyPrefixLength = 8
+)
+
+type ApiKey struct {
+	ApiKeyId  entity.ID
+	TenantId  string
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+type ApiKeyRepositoryInterface interface {
+	FindApiKeyByHash(ctx context.Context, keyHash string) (*ApiKey, *internal_error.InternalError)
+	FindApiKeys(ctx context.Context) ([]ApiKey, *internal_error.InternalError)
+	CreateApiKey(ctx context.Context, apiKey *ApiKey) *internal_error.InternalError
+	RevokeApiKey(ctx context.Context, id entity.ID) *internal_error.InternalError
+}
+
+// NewApiKey generates a new random key and returns the entity together with the
+// plaintext key. Only the peppered hash is kept, so the plaintext cannot be recovered later.
+func NewApiKey(name, tenantId string, scopes []string, pepper []byte) (*ApiKey, string, *internal_error.InternalError) {
+	if name == "" {
+		return nil, "", internal_error.NewBadRequestError("Invalid api key", internal_error.Causes{Field: "name", Message: "Name is required"})
+	}
+
+	if tenantId == "" {
+		return nil, "", internal_error.NewBadRequestError("Invalid api key", internal_error.Causes{Field: "tenant_id", Message: "Tenant is required"})
+	}
+
+	if len(scopes) == 0 {
+		return nil, "", internal_error.NewBadRequestError("Invalid api key", internal_error.Causes{Field: "scopes", Message: "At least one scope is required"})
+	}
+
+	for _, scope := range scopes {
+		if !auth.IsValidScope(scope) {
+			return nil, "", internal_error.NewBadRequestError("Invalid api key", internal_error.Causes{Field: "scopes", Message: fmt.Sprintf("Unknown scope %s", scope)})
+		}
+	}
+
+	secret := make([]byte, apiKeySecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", internal_error.NewInternalServerError("error generating api key", err)
+	}
+
+	encodedSecret := base64.RawURLEncoding.EncodeToString(secret)
+	prefix := encodedSecret[:apiKeyPrefixLength]
+	plaintext := fmt.Sprintf("%s_%s", ApiKeyPrefix, encodedSecret)
+
+	apiKey := &ApiKey{
+		ApiKeyId:  entity.NewID(),
+		TenantId:  tenantId,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   HashApiKey(plaintext, pepper),
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+	}
+
+	return apiKey, plaintext, nil
+}
+
+// HashApiKey computes the HMAC-SHA256 of the key using the server-side pepper.
+func HashApiKey(key string, pepper []byte) string {
+	mac := hmac.New(sha256.New, pepper)
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (a *ApiKey) IsRevoked() bool {
+	return a.RevokedAt != nil
+}
+
+func (a *ApiKey) Revoke() {
+	now := time.Now()
+	a.RevokedAt = &now
+}
+
+// ScopesString encodes the scopes as a space separated list, as used in OAuth scopes.
+func (a *ApiKey) ScopesString() string {
+	return strings.Join(a.Scopes, " ")
+}
+
+func ParseScopes(scopes string) []string {
+	return strings.Fields(scopes)
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanCreateApiKey(t *testing.T) {
+	pepper := []byte("pepper")
+
+	apiKey, plaintext, err := NewApiKey("backoffice", "acme", []string{auth.ScopeReceiversRead}, pepper)
+	assert.Nil(t, err)
+
+	assert.True(t, strings.HasPrefix(plaintext, ApiKeyPrefix+"_"+apiKey.Prefix))
+	assert.Equal(t, HashApiKey(plaintext, pepper), apiKey.KeyHash)
+	assert.NotEqual(t, HashApiKey(plaintext, []byte("other pepper")), apiKey.KeyHash)
+	assert.Equal(t, "receivers:read", apiKey.ScopesString())
+	assert.Equal(t, "acme", apiKey.TenantId)
+	assert.False(t, apiKey.IsRevoked())
+
+	apiKey.Revoke()
+	assert.True(t, apiKey.IsRevoked())
+}
+
+func TestCannotCreateApiKeyWithUnknownScope(t *testing.T) {
+	apiKey, plaintext, err := NewApiKey("backoffice", "acme", []string{"receivers:everything"}, []byte("pepper"))
+	assert.Error(t, err)
+	assert.Nil(t, apiKey)
+	assert.Empty(t, plaintext)
+	assert.Equal(t, "scopes", err.Causes[0].Field)
+}
+
+func TestCannotCreateApiKeyWithoutScopes(t *testing.T) {
+	_, _, err := NewApiKey("backoffice", "acme", nil, []byte("pepper"))
+	assert.Error(t, err)
+}
+
+func TestCannotCreateApiKeyWithoutTenant(t *testing.T) {
+	_, _, err := NewApiKey("backoffice", "", []string{auth.ScopeReceiversRead}, []byte("pepper"))
+	assert.Error(t, err)
+	assert.Equal(t, "tenant_id", err.Causes[0].Field)
+}
@@ -0,0 +1,265 @@
+package entity
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/felipemagrassi/pix-api/internal/value_object"
+	"github.com/felipemagrassi/pix-api/pkg/entity"
+)
+
+type (
+	ClaimType   int
+	ClaimStatus int
+)
+
+const (
+	_ ClaimType = iota
+	// OwnershipClaim is opened by someone else than the holder of an email or
+	// phone key who proves to own it.
+	OwnershipClaim
+	// PortabilityClaim moves a key between receivers of the same document.
+	PortabilityClaim
+)
+
+const (
+	_ ClaimStatus = iota
+	ClaimOpen
+	ClaimWaitingResolution
+	ClaimConfirmed
+	ClaimCanceled
+	ClaimCompleted
+)
+
+// Reasons a claim was canceled.
+const (
+	ClaimCanceledByRequest       = "requested"
+	ClaimResolutionPeriodExpired = "resolution_period_expired"
+	ClaimCompletionPeriodExpired = "completion_period_expired"
+)
+
+var claimTypeMap = map[string]ClaimType{
+	"ownership":   OwnershipClaim,
+	"portability": PortabilityClaim,
+}
+
+var claimStatusMap = map[string]ClaimStatus{
+	"open":               ClaimOpen,
+	"waiting_resolution": ClaimWaitingResolution,
+	"confirmed":          ClaimConfirmed,
+	"canceled":           ClaimCanceled,
+	"completed":          ClaimCompleted,
+}
+
+// Claim asks the holder of a pix key, the donor, to hand it over to the claimer.
+// The donor confirms or cancels it within the resolution period, after which an
+// ownership claim is confirmed and a portability claim canceled; a confirmed claim
+// is completed by the claimer within the completion period, or canceled.
+type Claim struct {
+	ClaimId            entity.ID
+	TenantId           string
+	Type               ClaimType
+	Status             ClaimStatus
+	PixKey             *PixKey
+	ClaimerReceiverId  entity.ID
+	DonorReceiverId    entity.ID
+	CancelReason       string
+	ResolutionDeadline time.Time
+	CompletionDeadline *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type ClaimRepositoryInterface interface {
+	FindClaim(ctx context.Context, id entity.ID) (*Claim, *internal_error.InternalError)
+	// FindClaims filters by status unless it is zero and by claimer or donor unless
+	// receiverId is nil.
+	FindClaims(ctx context.Context, status ClaimStatus, receiverId *entity.ID, page int) ([]Claim, *internal_error.InternalError)
+	// FindClaimsToResolve returns, across tenants, the open claims and the active
+	// claims whose resolution or completion deadline is not after now.
+	FindClaimsToResolve(ctx context.Context, now time.Time, limit int) ([]Claim, *internal_error.InternalError)
+	// CreateClaim returns a conflict error when the key already has an active claim.
+	CreateClaim(ctx context.Context, claim *Claim) *internal_error.InternalError
+	// UpdateClaim saves claim if its status is still previous, and returns a
+	// conflict error otherwise, so concurrent transitions cannot both apply.
+	UpdateClaim(ctx context.Context, claim *Claim, previous ClaimStatus) *internal_error.InternalError
+}
+
+func NewClaim(claimType string, pixKey *PixKey, claimer, donor *Receiver, resolutionPeriod time.Duration) (*Claim, *internal_error.InternalError) {
+	parsedType, ok := ParseClaimType(claimType)
+	if !ok {
+		return nil, internal_error.NewBadRequestError("Invalid claim", internal_error.Causes{Field: "type", Message: "Type must be ownership or portability"})
+	}
+
+	if claimer.ReceiverId == donor.ReceiverId {
+		return nil, internal_error.NewBadRequestError("Invalid claim", internal_error.Causes{Field: "claimer_receiver_id", Message: "Receiver already holds the pix key"})
+	}
+
+	switch parsedType {
+	case OwnershipClaim:
+		keyType := pixKey.KeyType.Value()
+		if keyType != EmailKeyType && keyType != PhoneKeyType {
+			return nil, internal_error.NewBadRequestError("Invalid claim", internal_error.Causes{Field: "type", Message: "Ownership can only be claimed for email and phone keys"})
+		}
+	case PortabilityClaim:
+		claimerDocument := documentDigits(claimer.Document)
+		if claimerDocument == "" || claimerDocument != documentDigits(donor.Document) {
+			return nil, internal_error.NewBadRequestError("Invalid claim", internal_error.Causes{Field: "claimer_receiver_id", Message: "Portability requires receivers of the same document"})
+		}
+	}
+
+	now := time.Now()
+
+	return &Claim{
+		ClaimId:            entity.NewID(),
+		Type:               parsedType,
+		Status:             ClaimOpen,
+		PixKey:             pixKey,
+		ClaimerReceiverId:  claimer.ReceiverId,
+		DonorReceiverId:    donor.ReceiverId,
+		ResolutionDeadline: now.Add(resolutionPeriod),
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}, nil
+}
+
+func NewActiveClaimConflictError() *internal_error.InternalError {
+	return internal_error.NewConflictError("Pix key already claimed", internal_error.Causes{Field: "pix_key_value", Message: "Pix key already has an active claim"})
+}
+
+// IsActive reports whether the claim is still blocking its pix key.
+func (c *Claim) IsActive() bool {
+	return c.Status == ClaimOpen || c.Status == ClaimWaitingResolution || c.Status == ClaimConfirmed
+}
+
+// Acknowledge records that the donor was notified of the claim.
+func (c *Claim) Acknowledge(now time.Time) *internal_error.InternalError {
+	if c.Status != ClaimOpen {
+		return c.transitionError("acknowledged")
+	}
+
+	c.Status = ClaimWaitingResolution
+	c.UpdatedAt = now
+	return nil
+}
+
+// Confirm records that the donor agreed to hand over the key, starting the
+// completion period.
+func (c *Claim) Confirm(now time.Time, completionPeriod time.Duration) *internal_error.InternalError {
+	if c.Status != ClaimOpen && c.Status != ClaimWaitingResolution {
+		return c.transitionError("confirmed")
+	}
+
+	completionDeadline := now.Add(completionPeriod)
+	c.Status = ClaimConfirmed
+	c.CompletionDeadline = &completionDeadline
+	c.UpdatedAt = now
+	return nil
+}
+
+func (c *Claim) Cancel(now time.Time, reason string) *internal_error.InternalError {
+	if !c.IsActive() {
+		return c.transitionError("canceled")
+	}
+
+	c.Status = ClaimCanceled
+	c.CancelReason = reason
+	c.UpdatedAt = now
+	return nil
+}
+
+// Complete records that the key was transferred to the claimer.
+func (c *Claim) Complete(now time.Time) *internal_error.InternalError {
+	if c.Status != ClaimConfirmed {
+		return c.transitionError("completed")
+	}
+
+	c.Status = ClaimCompleted
+	c.UpdatedAt = now
+	return nil
+}
+
+// Resolve applies the transitions due at now: open claims are acknowledged, claims
+// past the resolution deadline are confirmed (ownership) or canceled (portability)
+// and confirmed claims past the completion deadline are canceled. It reports
+// whether the claim changed.
+func (c *Claim) Resolve(now time.Time, completionPeriod time.Duration) bool {
+	changed := false
+
+	if c.Status == ClaimOpen {
+		changed = c.Acknowledge(now) == nil
+	}
+
+	if c.Status == ClaimWaitingResolution && !now.Before(c.ResolutionDeadline) {
+		if c.Type == OwnershipClaim {
+			changed = c.Confirm(now, completionPeriod) == nil
+		} else {
+			changed = c.Cancel(now, ClaimResolutionPeriodExpired) == nil
+		}
+	}
+
+	if c.Status == ClaimConfirmed && c.CompletionDeadline != nil && !now.Before(*c.CompletionDeadline) {
+		changed = c.Cancel(now, ClaimCompletionPeriodExpired) == nil
+	}
+
+	return changed
+}
+
+func (c *Claim) transitionError(action string) *internal_error.InternalError {
+	return internal_error.NewConflictError("Claim cannot be "+action, internal_error.Causes{Field: "status", Message: "Claim is " + c.Status.String()})
+}
+
+func ParseClaimType(claimType string) (ClaimType, bool) {
+	parsed, ok := claimTypeMap[strings.ToLower(claimType)]
+	return parsed, ok
+}
+
+func ParseClaimStatus(status string) (ClaimStatus, bool) {
+	parsed, ok := claimStatusMap[strings.ToLower(status)]
+	return parsed, ok
+}
+
+func (ct ClaimType) String() string {
+	switch ct {
+	case OwnershipClaim:
+		return "OWNERSHIP"
+	case PortabilityClaim:
+		return "PORTABILITY"
+	default:
+		return ""
+	}
+}
+
+func (cs ClaimStatus) String() string {
+	switch cs {
+	case ClaimOpen:
+		return "OPEN"
+	case ClaimWaitingResolution:
+		return "WAITING_RESOLUTION"
+	case ClaimConfirmed:
+		return "CONFIRMED"
+	case ClaimCanceled:
+		return "CANCELED"
+	case ClaimCompleted:
+		return "COMPLETED"
+	default:
+		return ""
+	}
+}
+
+func documentDigits(document value_object.Document) string {
+	if document == nil {
+		return ""
+	}
+
+	var digits strings.Builder
+	for _, r := range document.String() {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	return digits.String()
+}
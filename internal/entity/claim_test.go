@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newClaimReceivers(t *testing.T, donorDocument, claimerDocument, pixKeyValue, pixKeyType string) (*Receiver, *Receiver) {
+	donor, err := NewReceiver(donorDocument, pixKeyValue, pixKeyType, "Felipe", "")
+	assert.Nil(t, err)
+
+	claimer, err := NewReceiver(claimerDocument, "maria@email.com", "email", "Maria", "")
+	assert.Nil(t, err)
+
+	return donor, claimer
+}
+
+func TestCannotClaimOwnershipOfDocumentKeys(t *testing.T) {
+	donor, claimer := newClaimReceivers(t, "12345678909", "98765432100", "12345678909", "cpf")
+
+	claim, err := NewClaim("ownership", donor.PixKey, claimer, donor, time.Hour)
+	assert.Nil(t, claim)
+	assert.Equal(t, "bad_request", err.Err)
+
+	claim, err = NewClaim("transfer", donor.PixKey, claimer, donor, time.Hour)
+	assert.Nil(t, claim)
+	assert.Equal(t, "type", err.Causes[0].Field)
+}
+
+func TestPortabilityRequiresSameDocument(t *testing.T) {
+	donor, claimer := newClaimReceivers(t, "12345678909", "98765432100", "felipe@email.com", "email")
+
+	_, err := NewClaim("portability", donor.PixKey, claimer, donor, time.Hour)
+	assert.Equal(t, "bad_request", err.Err)
+
+	donor, claimer = newClaimReceivers(t, "12345678909", "123.456.789-09", "felipe@email.com", "email")
+
+	claim, err := NewClaim("PORTABILITY", donor.PixKey, claimer, donor, time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, PortabilityClaim, claim.Type)
+	assert.Equal(t, ClaimOpen, claim.Status)
+}
+
+func TestResolveClaim(t *testing.T) {
+	donor, claimer := newClaimReceivers(t, "12345678909", "98765432100", "felipe@email.com", "email")
+	claim, err := NewClaim("ownership", donor.PixKey, claimer, donor, time.Hour)
+	assert.Nil(t, err)
+
+	now := claim.CreatedAt
+	assert.True(t, claim.Resolve(now, 2*time.Hour))
+	assert.Equal(t, ClaimWaitingResolution, claim.Status)
+	assert.False(t, claim.Resolve(now, 2*time.Hour), "nothing is due before the resolution deadline")
+
+	assert.True(t, claim.Resolve(now.Add(time.Hour), 2*time.Hour))
+	assert.Equal(t, ClaimConfirmed, claim.Status)
+	assert.Equal(t, now.Add(3*time.Hour), *claim.CompletionDeadline)
+
+	assert.True(t, claim.Resolve(now.Add(3*time.Hour), 2*time.Hour))
+	assert.Equal(t, ClaimCanceled, claim.Status)
+	assert.Equal(t, ClaimCompletionPeriodExpired, claim.CancelReason)
+
+	err = claim.Complete(now)
+	assert.Equal(t, "conflict", err.Err)
+	assert.Equal(t, "Claim is CANCELED", err.Causes[0].Message)
+}
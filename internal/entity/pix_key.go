@@ -2,11 +2,13 @@ package entity
 
 import (
 	"fmt"
+	"log/slog"
 	"regexp"
 	"strings"
 
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
 	"github.com/felipemagrassi/pix-api/internal/value_object"
+	"github.com/felipemagrassi/pix-api/pkg/entity"
 )
 
 type PixKey struct {
@@ -23,10 +25,27 @@ type (
 )
 
 const (
-	PhoneKeyPattern  = `^((?:\+?55)?)([1-9][0-9])(9[0-9]{8})$`
+	// PhoneKeyPattern matches an optional +55 or 55 country code, the area code and
+	// a mobile (9 digits) or landline (8 digits) number.
+	PhoneKeyPattern  = `^(?:\+?55)?([0-9]{2})([0-9]{8,9})$`
 	RandomKeyPattern = `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
 )
 
+var phoneKeyRegexp = regexp.MustCompile(PhoneKeyPattern)
+
+// brazilianAreaCodes lists the DDDs assigned by Anatel.
+var brazilianAreaCodes = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true,
+	"21": true, "22": true, "24": true, "27": true, "28": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "37": true, "38": true,
+	"41": true, "42": true, "43": true, "44": true, "45": true, "46": true, "47": true, "48": true, "49": true,
+	"51": true, "53": true, "54": true, "55": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true, "66": true, "67": true, "68": true, "69": true,
+	"71": true, "73": true, "74": true, "75": true, "77": true, "79": true,
+	"81": true, "82": true, "83": true, "84": true, "85": true, "86": true, "87": true, "88": true, "89": true,
+	"91": true, "92": true, "93": true, "94": true, "95": true, "96": true, "97": true, "98": true, "99": true,
+}
+
 type PixKeyType int
 
 const (
@@ -61,7 +80,7 @@ func NewPixKey(
 	}
 
 	newPixKey := &PixKey{
-		KeyValue: keyValue,
+		KeyValue: newPixKeyType.Normalize(keyValue),
 		KeyType:  newPixKeyType,
 	}
 
@@ -72,6 +91,22 @@ func NewPixKey(
 	return newPixKey, nil
 }
 
+// NewRandomPixKey generates a random (EVP) key, a UUIDv4. EVP keys are generated by
+// the institution, so callers must still check it is not registered.
+func NewRandomPixKey() *PixKey {
+	return &PixKey{
+		KeyValue: entity.NewID().String(),
+		KeyType:  &RandomPixKeyType{KeyType: RandomKeyType},
+	}
+}
+
+// IsGeneratedPixKey reports whether a key of keyType with keyValue asks for a
+// random key generated by the server, i.e. a random key without value.
+func IsGeneratedPixKey(keyValue, keyType string) bool {
+	parsedKeyType, ok := ParsePixKeyType(keyType)
+	return ok && parsedKeyType == RandomKeyType && keyValue == ""
+}
+
 func (pk *PixKey) Validate() *internal_error.InternalError {
 	if pk.KeyValue == "" {
 		return internal_error.NewBadRequestError("Invalid pix key", internal_error.Causes{Field: "key_value", Message: "Key Value is required"})
@@ -85,13 +120,68 @@ func (pk *PixKey) Validate() *internal_error.InternalError {
 		return internal_error.NewBadRequestError("Invalid pix key", internal_error.Causes{Field: "key_value", Message: "Key Value must be less than 140 characters"})
 	}
 
-	if pk.KeyType.ValidateKeyType(pk.KeyValue) != nil {
-		return internal_error.NewBadRequestError("Invalid pix key", internal_error.Causes{Field: "key_value", Message: "Invalid Key Value"})
+	if err := pk.KeyType.ValidateKeyType(pk.KeyValue); err != nil {
+		return internal_error.NewBadRequestError("Invalid pix key", err.Causes...)
 	}
 
 	return nil
 }
 
+// Mask returns the key value with its identifying part hidden, according to the key type.
+func (pk *PixKey) Mask() string {
+	return pk.KeyType.Mask(pk.KeyValue)
+}
+
+// LogValue logs the key type and the masked key value.
+func (pk *PixKey) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("type", pk.KeyType.GetTypeName()),
+		slog.String("value", pk.Mask()),
+	)
+}
+
+// Canonical returns the key value in the form keys are unique by, so the same key
+// written differently, e.g. a CPF with and without punctuation, is the same key.
+func (pk *PixKey) Canonical() string {
+	return pk.KeyType.Canonical(pk.KeyValue)
+}
+
+// PixKeyFilterCanonicals returns the canonical keys a pix key filter matches: value
+// as a key of keyType or, without a type (-1), as a key of every type it is valid
+// for, so 11999999999 finds the phone key +5511999999999 and Joao@Email.com the
+// email joao@email.com. A value valid for no type is returned as is.
+func PixKeyFilterCanonicals(value string, keyType PixKeyType) []string {
+	if keyType != -1 {
+		pixKeyType, err := NewPixKeyType(keyType)
+		if err != nil {
+			return []string{value}
+		}
+
+		return []string{pixKeyType.Canonical(pixKeyType.Normalize(value))}
+	}
+
+	var canonicals []string
+	for _, candidate := range []PixKeyType{CnpjKeyType, CpfKeyType, EmailKeyType, PhoneKeyType, RandomKeyType} {
+		pixKeyType, _ := NewPixKeyType(candidate)
+		normalized := pixKeyType.Normalize(value)
+		if pixKeyType.ValidateKeyType(normalized) == nil {
+			canonicals = append(canonicals, pixKeyType.Canonical(normalized))
+		}
+	}
+
+	if len(canonicals) == 0 {
+		return []string{value}
+	}
+
+	return canonicals
+}
+
+// NewPixKeyConflictError is returned when a pix key is already registered to another
+// receiver. It names the field only, so the other receiver is not disclosed.
+func NewPixKeyConflictError() *internal_error.InternalError {
+	return internal_error.NewConflictError("Pix key already registered", internal_error.Causes{Field: "pix_key_value", Message: "Pix key is already registered to another receiver"})
+}
+
 func ParsePixKeyType(pixKeyTypeStr string) (PixKeyType, bool) {
 	lowerPixKeyTypeStr := strings.ToLower(pixKeyTypeStr)
 	c, ok := pixKeyTypeMap[lowerPixKeyTypeStr]
@@ -106,6 +196,11 @@ type PixKeyTypeInterface interface {
 	ValidateKeyType(key string) *internal_error.InternalError
 	GetTypeName() string
 	Value() PixKeyType
+	Mask(key string) string
+	// Normalize returns the key as it is stored, applied by NewPixKey before
+	// validating it.
+	Normalize(key string) string
+	Canonical(key string) string
 }
 
 func NewPixKeyType(keyType PixKeyType) (PixKeyTypeInterface, *internal_error.InternalError) {
@@ -165,6 +260,85 @@ func (kt *RandomPixKeyType) Value() PixKeyType {
 	return PixKeyType(RandomKeyType)
 }
 
+func (kt *CnpjPixKeyType) Mask(key string) string {
+	return value_object.CNPJ(key).Mask()
+}
+
+func (kt *CpfPixKeyType) Mask(key string) string {
+	return value_object.CPF(key).Mask()
+}
+
+func (kt *EmailPixKeyType) Mask(key string) string {
+	return value_object.Email(key).Mask()
+}
+
+func (kt *PhonePixKeyType) Mask(key string) string {
+	return maskKeepingSuffix(key, 4)
+}
+
+func (kt *RandomPixKeyType) Mask(key string) string {
+	if len(key) < 12 {
+		return strings.Repeat("*", len(key))
+	}
+
+	return key[:8] + maskKeepingSuffix(key[8:], 4)
+}
+
+func (kt *CnpjPixKeyType) Normalize(key string) string {
+	return key
+}
+
+func (kt *CpfPixKeyType) Normalize(key string) string {
+	return key
+}
+
+func (kt *EmailPixKeyType) Normalize(key string) string {
+	return value_object.Email(key).Normalize().String()
+}
+
+// Normalize returns the phone in E.164, +55 followed by the area code and the
+// number, or key unchanged when it is not a phone number.
+func (kt *PhonePixKeyType) Normalize(key string) string {
+	matches := phoneKeyRegexp.FindStringSubmatch(key)
+	if matches == nil {
+		return key
+	}
+
+	return "+55" + matches[1] + matches[2]
+}
+
+func (kt *RandomPixKeyType) Normalize(key string) string {
+	return key
+}
+
+func (kt *CnpjPixKeyType) Canonical(key string) string {
+	return value_object.CNPJ(key).Digits()
+}
+
+func (kt *CpfPixKeyType) Canonical(key string) string {
+	return value_object.CPF(key).Digits()
+}
+
+func (kt *EmailPixKeyType) Canonical(key string) string {
+	return kt.Normalize(key)
+}
+
+func (kt *PhonePixKeyType) Canonical(key string) string {
+	return kt.Normalize(key)
+}
+
+func (kt *RandomPixKeyType) Canonical(key string) string {
+	return strings.ToLower(key)
+}
+
+func maskKeepingSuffix(key string, visible int) string {
+	if len(key) <= visible {
+		return strings.Repeat("*", len(key))
+	}
+
+	return strings.Repeat("*", len(key)-visible) + key[len(key)-visible:]
+}
+
 func (kt *CnpjPixKeyType) ValidateKeyType(key string) *internal_error.InternalError {
 	_, err := value_object.NewCNPJ(key)
 	if err != nil {
@@ -182,24 +356,41 @@ func (kt *CpfPixKeyType) ValidateKeyType(key string) *internal_error.InternalErr
 	return nil
 }
 
+// ValidateKeyType checks the DICT rules for email keys, see
+// value_object.Email.ValidatePixKey.
 func (kt *EmailPixKeyType) ValidateKeyType(key string) *internal_error.InternalError {
-	_, err := value_object.NewEmail(key)
-	if err != nil {
-		return internal_error.NewBadRequestError(fmt.Sprintf("Invalid %s Key", kt.GetTypeName()), internal_error.Causes{Field: "key_value", Message: "Invalid Key Value"})
+	if err := value_object.Email(key).ValidatePixKey(); err != nil {
+		return internal_error.NewBadRequestError(fmt.Sprintf("Invalid %s Key", kt.GetTypeName()), internal_error.Causes{Field: "key_value", Message: err.Causes[0].Message})
 	}
 
 	return nil
 }
 
+// ValidateKeyType accepts Brazilian mobile numbers only: a known area code followed
+// by 9 digits starting with 9.
 func (kt *PhonePixKeyType) ValidateKeyType(key string) *internal_error.InternalError {
-	re, err := regexp.Compile(PhoneKeyPattern)
-	if err != nil {
-		return internal_error.NewBadRequestError(fmt.Sprintf("Invalid %s key matching pattern", kt.GetTypeName()), internal_error.Causes{Field: "key_value", Message: "Invalid Key Value"})
+	invalid := func(message string) *internal_error.InternalError {
+		return internal_error.NewBadRequestError(fmt.Sprintf("Invalid %s Key", kt.GetTypeName()), internal_error.Causes{Field: "key_value", Message: message})
 	}
 
-	if !re.MatchString(key) {
-		return internal_error.NewBadRequestError(fmt.Sprintf("Invalid %s Key", kt.GetTypeName()), internal_error.Causes{Field: "key_value", Message: "Invalid Key Value"})
+	matches := phoneKeyRegexp.FindStringSubmatch(key)
+	if matches == nil {
+		return invalid("Phone must be +55 followed by the area code and the number")
 	}
+
+	areaCode, number := matches[1], matches[2]
+	if !brazilianAreaCodes[areaCode] {
+		return invalid(fmt.Sprintf("Invalid area code (DDD) %s", areaCode))
+	}
+
+	if len(number) == 8 && number[0] >= '2' && number[0] <= '5' {
+		return invalid("Landline numbers cannot be pix keys, use a mobile number")
+	}
+
+	if len(number) != 9 || number[0] != '9' {
+		return invalid("Mobile number must have 9 digits starting with 9")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/felipemagrassi/pix-api/internal/value_object"
+)
+
+// PixKeyLimits is the number of pix keys an owner can hold per account, which DICT
+// sets to 5 for natural persons (CPF) and 20 for companies (CNPJ). A limit of zero
+// or less is not enforced.
+type PixKeyLimits struct {
+	Cpf  int
+	Cnpj int
+}
+
+var DefaultPixKeyLimits = PixKeyLimits{Cpf: 5, Cnpj: 20}
+
+// CheckNewPixKey returns a bad request error when an owner with document, already
+// holding registered keys, cannot hold another one.
+func (l PixKeyLimits) CheckNewPixKey(document value_object.Document, registered int) *internal_error.InternalError {
+	limit, documentType := l.Cpf, "CPF"
+	if _, ok := document.(value_object.CNPJ); ok {
+		limit, documentType = l.Cnpj, "CNPJ"
+	}
+
+	if limit <= 0 || registered < limit {
+		return nil
+	}
+
+	return internal_error.NewBadRequestError("Pix key limit reached", internal_error.Causes{Field: "pix_key_value", Message: fmt.Sprintf("A %s can hold at most %d pix keys per account", documentType, limit)})
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/value_object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckNewPixKey(t *testing.T) {
+	limits := PixKeyLimits{Cpf: 5, Cnpj: 20}
+
+	assert.Nil(t, limits.CheckNewPixKey(value_object.CPF("12345678909"), 4))
+	err := limits.CheckNewPixKey(value_object.CPF("12345678909"), 5)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "bad_request", err.Err)
+		assert.Equal(t, "A CPF can hold at most 5 pix keys per account", err.Causes[0].Message)
+	}
+
+	assert.Nil(t, limits.CheckNewPixKey(value_object.CNPJ("41299131000107"), 19))
+	assert.NotNil(t, limits.CheckNewPixKey(value_object.CNPJ("41299131000107"), 20))
+
+	assert.Nil(t, PixKeyLimits{}.CheckNewPixKey(value_object.CPF("12345678909"), 100), "zero disables the limit")
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -134,7 +135,8 @@ func TestValidEmailKeyTypeTest(t *testing.T) {
 	expected := []string{
 		"govrada@peakvisionhdtv.com",
 		"govrada@gmail.com",
-		"govrada@gmail",
+		"govrada+pix@mail.gmail.com.br",
+		"govrada@xn--mller-kva.de",
 	}
 
 	for _, email := range expected {
@@ -155,6 +157,12 @@ func TestInvalidEmailKeyTypeTest(t *testing.T) {
 		"123",
 		"41.299.131/0001-07",
 		"41299131000107",
+		"govrada@gmail",
+		"Govrada@gmail.com",
+		".govrada@gmail.com",
+		"gov..rada@gmail.com",
+		"govrada@-gmail.com",
+		strings.Repeat("a", 68) + "@gmail.com",
 	}
 	for _, email := range expected {
 
@@ -199,6 +207,10 @@ func TestInvalidPhoneKeyTypeTest(t *testing.T) {
 		"55123999999999",
 		"(55) 123999999999",
 		"(55) 21 999999999",
+		"5520999999999",
+		"+5523999999999",
+		"1133334444",
+		"11899999999",
 	}
 	for _, phone := range expected {
 
@@ -244,3 +256,107 @@ func TestInvalidRandomKeyTypeTest(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestMaskPixKey(t *testing.T) {
+	cases := map[string][]string{
+		"cpf":    {"49877752042", "***.777.520-**"},
+		"cnpj":   {"41299131000107", "**.299.131/0001-**"},
+		"email":  {"govrada@gmail.com", "g******@gmail.com"},
+		"phone":  {"5511999999999", "**********9999"},
+		"random": {"7c7a2ba0-3fda-4f76-8c44-df1f8c1289ba", "7c7a2ba0************************89ba"},
+	}
+
+	for keyType, values := range cases {
+		t.Run(keyType, func(t *testing.T) {
+			key, err := NewPixKey(values[0], keyType)
+			assert.Nil(t, err)
+			assert.Equal(t, values[1], key.Mask())
+		})
+	}
+}
+
+func TestCanonicalPixKey(t *testing.T) {
+	cases := map[string][][]string{
+		"cpf":    {{"498.777.520-42", "49877752042"}, {"49877752042", "49877752042"}},
+		"cnpj":   {{"41.299.131/0001-07", "41299131000107"}},
+		"email":  {{"govrada@gmail.com", "govrada@gmail.com"}},
+		"phone":  {{"11999999999", "+5511999999999"}, {"5511999999999", "+5511999999999"}, {"+5511999999999", "+5511999999999"}},
+		"random": {{"7c7a2ba0-3fda-4f76-8c44-df1f8c1289ba", "7c7a2ba0-3fda-4f76-8c44-df1f8c1289ba"}},
+	}
+
+	for keyType, values := range cases {
+		t.Run(keyType, func(t *testing.T) {
+			for _, value := range values {
+				key, err := NewPixKey(value[0], keyType)
+				assert.Nil(t, err)
+				assert.Equal(t, value[1], key.Canonical())
+			}
+		})
+	}
+}
+
+func TestPixKeyFilterCanonicals(t *testing.T) {
+	assert.Equal(t, []string{"11999999999", "+5511999999999"}, PixKeyFilterCanonicals("11999999999", -1), "a valid cpf and phone")
+	assert.Equal(t, []string{"joao@email.com"}, PixKeyFilterCanonicals("Joao@Email.com", -1))
+	assert.Equal(t, []string{"49877752042"}, PixKeyFilterCanonicals("498.777.520-42", -1))
+	assert.Equal(t, []string{"+5511999999999"}, PixKeyFilterCanonicals("5511999999999", PhoneKeyType))
+	assert.Equal(t, []string{"unknown"}, PixKeyFilterCanonicals("unknown", -1))
+}
+
+func TestPhoneKeyIsNormalized(t *testing.T) {
+	for _, value := range []string{"11999999999", "5511999999999", "+5511999999999"} {
+		key, err := NewPixKey(value, "phone")
+		assert.Nil(t, err)
+		assert.Equal(t, "+5511999999999", key.KeyValue)
+	}
+}
+
+func TestInvalidPhoneKeyCauses(t *testing.T) {
+	cases := map[string]string{
+		"+5520999999999":  "Invalid area code (DDD) 20",
+		"1133334444":      "Landline numbers cannot be pix keys, use a mobile number",
+		"11899999999":     "Mobile number must have 9 digits starting with 9",
+		"(11) 99999-9999": "Phone must be +55 followed by the area code and the number",
+	}
+
+	for value, message := range cases {
+		t.Run(value, func(t *testing.T) {
+			_, err := NewPixKey(value, "phone")
+			assert.Equal(t, "Invalid pix key", err.Message)
+			assert.Equal(t, "key_value", err.Causes[0].Field)
+			assert.Equal(t, message, err.Causes[0].Message)
+		})
+	}
+}
+
+func TestEmailKeyIsNormalized(t *testing.T) {
+	pixKey, err := NewPixKey("Joao.Silva@Müller.DE", "email")
+	assert.Nil(t, err)
+	assert.Equal(t, "joao.silva@xn--mller-kva.de", pixKey.KeyValue)
+	assert.Equal(t, pixKey.KeyValue, pixKey.Canonical())
+}
+
+func TestInvalidEmailKeyCauses(t *testing.T) {
+	cases := map[string]string{
+		"govrada@gmail":                        "Email domain must have a top-level domain",
+		strings.Repeat("a", 68) + "@gmail.com": "Email must have at most 77 characters",
+		"govrada@gmail.com_":                   "Invalid Email",
+	}
+
+	for email, message := range cases {
+		_, err := NewPixKey(email, "email")
+		if assert.NotNil(t, err, email) {
+			assert.Equal(t, message, err.Causes[0].Message, email)
+		}
+	}
+}
+
+func TestNewRandomPixKey(t *testing.T) {
+	pixKey := NewRandomPixKey()
+	assert.Nil(t, pixKey.Validate())
+	assert.NotEqual(t, pixKey.KeyValue, NewRandomPixKey().KeyValue)
+
+	assert.True(t, IsGeneratedPixKey("", "random"))
+	assert.False(t, IsGeneratedPixKey(pixKey.KeyValue, "random"))
+	assert.False(t, IsGeneratedPixKey("", "email"))
+}
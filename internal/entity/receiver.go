@@ -2,6 +2,7 @@ package entity
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
@@ -21,6 +22,7 @@ const (
 
 type Receiver struct {
 	ReceiverId    entity.ID
+	TenantId      string
 	Name          string
 	Document      value_object.Document
 	Email         value_object.Email
@@ -33,12 +35,35 @@ type Receiver struct {
 	UpdatedAt     time.Time
 }
 
+// PixKeyOwner is who DICT limits the number of pix keys of: a document, in digits,
+// and an account.
+type PixKeyOwner struct {
+	Document      string
+	Bank          string
+	Office        string
+	AccountNumber string
+}
+
 type ReceiverRepositoryInterface interface {
 	FindReceiver(ctx context.Context, id entity.ID) (*Receiver, *internal_error.InternalError)
+	// FindReceiverByPixKey finds the receiver holding the key with the given
+	// canonical value, see PixKey.Canonical.
+	FindReceiverByPixKey(ctx context.Context, canonicalPixKey string) (*Receiver, *internal_error.InternalError)
+	// CountReceiversByOwner counts the receivers of the owner, each holding one key.
+	CountReceiversByOwner(ctx context.Context, owner PixKeyOwner) (int, *internal_error.InternalError)
+	// LockPixKeyOwner serializes the units of work registering keys for owner
+	// until the one of ctx ends, so the keys counted inside it stay within the
+	// limit. Outside a unit of work it does nothing.
+	LockPixKeyOwner(ctx context.Context, owner PixKeyOwner) *internal_error.InternalError
 	FindReceivers(ctx context.Context, status ReceiverStatus, name, pixKeyValue string, pixKeyType PixKeyType, page int) ([]Receiver, *internal_error.InternalError)
+	StreamReceivers(ctx context.Context, status ReceiverStatus, name, pixKeyValue string, pixKeyType PixKeyType, fn func(receiver *Receiver) *internal_error.InternalError) *internal_error.InternalError
 	CreateReceiver(ctx context.Context, receiver *Receiver) *internal_error.InternalError
 	UpdateReceiver(ctx context.Context, receiver *Receiver) *internal_error.InternalError
 	DeleteManyReceivers(ctx context.Context, ids []entity.ID) *internal_error.InternalError
+	// TransferPixKey deletes the donor if it still holds the pix key of receiver and
+	// creates receiver, in a single transaction. It returns a conflict error when
+	// another receiver holds the key.
+	TransferPixKey(ctx context.Context, donorId entity.ID, receiver *Receiver) *internal_error.InternalError
 }
 
 func NewReceiver(
@@ -60,7 +85,7 @@ func NewReceiver(
 		ReceiverId: entity.NewID(),
 		Name:       name,
 		Document:   newDocument,
-		Email:      value_object.Email(email),
+		Email:      value_object.Email(email).Normalize(),
 		Status:     Draft,
 		PixKey:     pixKey,
 		CreatedAt:  currentTime,
@@ -103,13 +128,68 @@ func (r *Receiver) GetStatus() ReceiverStatus {
 	return r.Status
 }
 
+// MaskAccountNumber keeps the last 4 digits of an account number, e.g. **3456.
+func MaskAccountNumber(accountNumber string) string {
+	return maskKeepingSuffix(accountNumber, 4)
+}
+
+// LogValue logs the receiver with its personal data masked.
+func (r *Receiver) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("receiver_id", r.ReceiverId.String()),
+		slog.String("tenant_id", r.TenantId),
+		slog.Int("status", int(r.Status)),
+		slog.String("account_number", MaskAccountNumber(r.AccountNumber)),
+		slog.String("email", r.Email.Mask()),
+	}
+	if r.Document != nil {
+		attrs = append(attrs, slog.String("document", r.Document.Mask()))
+	}
+	if r.PixKey != nil {
+		attrs = append(attrs, slog.Any("pix_key", r.PixKey))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// Owner returns the owner of the pix key of the receiver.
+func (r *Receiver) Owner() PixKeyOwner {
+	owner := PixKeyOwner{Bank: r.Bank, Office: r.Office, AccountNumber: r.AccountNumber}
+	if r.Document != nil {
+		owner.Document = r.Document.Digits()
+	}
+
+	return owner
+}
+
 func (r *Receiver) ValidateReceiverStatus() {
 	r.Status = Valid
 }
 
+// CopyWithPixKey returns a new draft receiver with the owner and account of the
+// receiver and another pix key, as each receiver holds a single key.
+func (r *Receiver) CopyWithPixKey(pixKey *PixKey) *Receiver {
+	currentTime := time.Now()
+
+	return &Receiver{
+		ReceiverId:    entity.NewID(),
+		TenantId:      r.TenantId,
+		Name:          r.Name,
+		Document:      r.Document,
+		Email:         r.Email,
+		Status:        Draft,
+		Bank:          r.Bank,
+		Office:        r.Office,
+		AccountNumber: r.AccountNumber,
+		PixKey:        pixKey,
+		CreatedAt:     currentTime,
+		UpdatedAt:     currentTime,
+	}
+}
+
 func (r *Receiver) updateValidReceiver(email string) *internal_error.InternalError {
 	if email != "" {
-		r.Email = value_object.Email(email)
+		r.Email = value_object.Email(email).Normalize()
 		r.UpdatedAt = time.Now()
 	}
 
@@ -138,7 +218,7 @@ func (r *Receiver) updateDraftReceiver(document, pixKeyValue, pixKeyType, name,
 	}
 
 	if email != "" {
-		r.Email = value_object.Email(email)
+		r.Email = value_object.Email(email).Normalize()
 	}
 
 	if pixKeyValue != "" && pixKeyType != "" {
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"bytes"
+	"log/slog"
 	"testing"
 
 	"github.com/felipemagrassi/pix-api/internal/value_object"
@@ -101,6 +103,16 @@ func TestCannotCreateReceiverWithInvalidEmail(t *testing.T) {
 	assert.Nil(t, receiver)
 }
 
+func TestReceiverEmailIsLowercased(t *testing.T) {
+	receiver, err := NewReceiver("12345678909", "felipe@email.com", "Email", "Felipe", "Felipe@Email.com")
+	assert.Nil(t, err)
+	assert.Equal(t, value_object.Email("felipe@email.com"), receiver.Email)
+
+	err = receiver.UpdateReceiver("", "", "", "", "Maria@Email.com")
+	assert.Nil(t, err)
+	assert.Equal(t, value_object.Email("maria@email.com"), receiver.Email)
+}
+
 func TestCannotCreateReceiverWithInvalidCpf(t *testing.T) {
 	input := map[string]string{
 		"name":        "Felipe",
@@ -236,3 +248,19 @@ func TestCanUpdateValidReceiverEmail(t *testing.T) {
 	assert.Equal(t, createdReceiver.Email, value_object.Email(newEmail))
 	assert.Equal(t, createdReceiver.GetStatus(), Valid)
 }
+
+func TestReceiverLogValueMasksPersonalData(t *testing.T) {
+	receiver, err := NewReceiver("12345678909", "govrada@gmail.com", "email", "Felipe", "felipe@email.com")
+	assert.Nil(t, err)
+	receiver.AccountNumber = "123456"
+
+	var buffer bytes.Buffer
+	slog.New(slog.NewTextHandler(&buffer, nil)).Info("receiver", "receiver", receiver)
+
+	logged := buffer.String()
+	assert.Contains(t, logged, "receiver.document=***.456.789-**")
+	assert.Contains(t, logged, "receiver.account_number=**3456")
+	assert.Contains(t, logged, "receiver.pix_key.value=g******@gmail.com")
+	assert.NotContains(t, logged, "12345678909")
+	assert.NotContains(t, logged, "felipe@email.com")
+}
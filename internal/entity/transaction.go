@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+)
+
+// TransactionManagerInterface runs several repository calls as a single unit:
+// the calls made with the context given to fn are committed together when fn
+// succeeds and rolled back when it fails.
+type TransactionManagerInterface interface {
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) *internal_error.InternalError) *internal_error.InternalError
+}
@@ -0,0 +1,149 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/felipemagrassi/pix-api/configuration/grpc_err"
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ApiKeyMetadata        = "x-api-key"
+	AuthorizationMetadata = "authorization"
+)
+
+// publicServices can be called without credentials so probes and tools such as
+// grpcurl work against a fresh deployment.
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// Authenticate resolves the call credentials from the x-api-key or authorization
+// metadata with the first authenticator that recognizes them, like the http
+// middleware, and stores the principal in the call context.
+func Authenticate(authenticators ...auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, authenticators)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthenticateStream is Authenticate for streaming calls.
+func AuthenticateStream(authenticators ...auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		ctx, err := authenticate(stream.Context(), authenticators)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// RequireScopes rejects calls whose principal was not granted the scope listed
+// for the method. Methods that are not listed are denied unless their service is
+// public, so a method added without a scope is never open.
+func RequireScopes(scopes map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		scope, found := scopes[info.FullMethod]
+		if !found {
+			if isPublic(info.FullMethod) {
+				return handler(ctx, req)
+			}
+			return nil, status.Error(codes.PermissionDenied, "No scope allows "+info.FullMethod)
+		}
+
+		principal, ok := auth.PrincipalFromContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "Missing credentials")
+		}
+
+		if !principal.HasScope(scope) {
+			return nil, status.Error(codes.PermissionDenied, "Missing scope "+scope)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func authenticate(ctx context.Context, authenticators []auth.Authenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	credentials := auth.Credentials{
+		ApiKey: firstMetadata(md, ApiKeyMetadata),
+	}
+
+	if bearer, found := strings.CutPrefix(firstMetadata(md, AuthorizationMetadata), "Bearer "); found {
+		credentials.BearerToken = strings.TrimSpace(bearer)
+	}
+
+	if credentials.ApiKey == "" && credentials.BearerToken == "" {
+		return ctx, status.Error(codes.Unauthenticated, "Missing credentials")
+	}
+
+	for _, authenticator := range authenticators {
+		principal, err := authenticator.Authenticate(ctx, credentials)
+		if err != nil {
+			if err.Err == "internal_server_error" {
+				logger.FromContext(ctx).Error("error authenticating call", "error", err.Error())
+			}
+			return ctx, grpc_err.ConvertError(err)
+		}
+
+		if principal != nil {
+			ctx = auth.WithPrincipal(ctx, principal)
+			ctx = logger.With(ctx, "tenant_id", principal.TenantId, "actor", auth.ActorFromContext(ctx))
+			return ctx, nil
+		}
+	}
+
+	return ctx, status.Error(codes.Unauthenticated, "Unsupported credentials")
+}
+
+func isPublic(fullMethod string) bool {
+	for _, service := range publicServices {
+		if strings.HasPrefix(fullMethod, service) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,103 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type staticAuthenticator struct {
+	apiKey    string
+	principal *auth.Principal
+}
+
+func (a *staticAuthenticator) Authenticate(ctx context.Context, credentials auth.Credentials) (*auth.Principal, *internal_error.InternalError) {
+	if credentials.ApiKey == "" {
+		return nil, nil
+	}
+
+	if credentials.ApiKey != a.apiKey {
+		return nil, internal_error.NewUnauthorizedError("Invalid api key")
+	}
+
+	return a.principal, nil
+}
+
+const (
+	readMethod   = "/pix.receiver.v1.ReceiverService/GetReceiver"
+	deleteMethod = "/pix.receiver.v1.ReceiverService/DeleteReceivers"
+	healthMethod = "/grpc.health.v1.Health/Check"
+	// unmappedMethod has no entry in the scopes given to RequireScopes.
+	unmappedMethod = "/pix.receiver.v1.ReceiverService/ListReceivers"
+)
+
+// call runs the authentication and scope interceptors for fullMethod and returns
+// the error and the principal the handler saw.
+func call(fullMethod, apiKey string) (*auth.Principal, error) {
+	authenticator := &staticAuthenticator{
+		apiKey:    "valid",
+		principal: &auth.Principal{Subject: "test", Method: auth.MethodApiKey, TenantId: "acme", Scopes: []string{auth.ScopeReceiversRead}},
+	}
+	authenticate := Authenticate(authenticator)
+	requireScopes := RequireScopes(map[string]string{
+		readMethod:   auth.ScopeReceiversRead,
+		deleteMethod: auth.ScopeReceiversDelete,
+	})
+
+	ctx := context.Background()
+	if apiKey != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(ApiKeyMetadata, apiKey))
+	}
+
+	var principal *auth.Principal
+	handler := func(ctx context.Context, req any) (any, error) {
+		principal, _ = auth.PrincipalFromContext(ctx)
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+	_, err := authenticate(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return requireScopes(ctx, req, info, handler)
+	})
+
+	return principal, err
+}
+
+func TestAuthenticate(t *testing.T) {
+	principal, err := call(readMethod, "valid")
+	assert.Nil(t, err)
+	assert.Equal(t, "acme", principal.TenantId)
+
+	_, err = call(readMethod, "")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = call(readMethod, "invalid")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, "Invalid api key", status.Convert(err).Message())
+}
+
+func TestAuthenticateSkipsPublicServices(t *testing.T) {
+	principal, err := call(healthMethod, "")
+	assert.Nil(t, err)
+	assert.Nil(t, principal)
+}
+
+func TestRequireScopes(t *testing.T) {
+	_, err := call(deleteMethod, "valid")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, "Missing scope receivers:delete", status.Convert(err).Message())
+}
+
+func TestRequireScopesDeniesUnmappedMethods(t *testing.T) {
+	principal, err := call(unmappedMethod, "valid")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, "No scope allows "+unmappedMethod, status.Convert(err).Message())
+	assert.Nil(t, principal)
+}
@@ -0,0 +1,81 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	RequestIdMetadata = "x-request-id"
+
+	maxRequestIdLength = 128
+)
+
+// RequestLogger propagates the caller's x-request-id, or assigns a new one, and
+// echoes it in the response header. It writes one line per call with its code
+// and latency, and turns a panic into an Internal status. It must run first.
+func RequestLogger() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		requestId := firstMetadata(md, RequestIdMetadata)
+		if !isValidRequestId(requestId) {
+			requestId = pkg_entity.NewID().String()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIdMetadata, requestId))
+
+		ctx = logger.WithRequestId(ctx, requestId)
+		ctx = logger.With(ctx, "grpc_method", info.FullMethod)
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logger.FromContext(ctx).Error("panic recovered", "panic", recovered)
+				err = status.Error(codes.Internal, "Internal server error")
+			}
+
+			code := status.Code(err)
+			logger.FromContext(ctx).Log(ctx, codeLevel(code), "call completed",
+				"code", code.String(),
+				"latency_ms", float64(time.Since(start).Microseconds())/1000,
+			)
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func codeLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+// isValidRequestId only accepts short, printable ASCII ids so a caller cannot
+// inject arbitrary content into the logs.
+func isValidRequestId(requestId string) bool {
+	if requestId == "" || len(requestId) > maxRequestIdLength {
+		return false
+	}
+
+	for i := 0; i < len(requestId); i++ {
+		if requestId[i] < 0x21 || requestId[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
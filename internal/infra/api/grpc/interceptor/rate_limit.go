@@ -0,0 +1,94 @@
+package interceptor
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/infra/rate_limiter"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	RateLimitLimitMetadata     = "ratelimit-limit"
+	RateLimitRemainingMetadata = "ratelimit-remaining"
+	RateLimitResetMetadata     = "ratelimit-reset"
+)
+
+// rateLimitMethod is the method of the limiter routes, which are the full gRPC
+// method names.
+const rateLimitMethod = "GRPC"
+
+// RateLimit enforces the limiter on each call, like the http middleware, sending
+// the RateLimit headers as metadata. A limiter keyed by api key or tenant must run
+// after Authenticate; one keyed by IP runs before it, so calls with invalid
+// credentials are limited too. Public services are not limited and store failures
+// let the call through.
+func RateLimit(limiter *rate_limiter.RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		result, err := limiter.Take(ctx, rateLimitMethod, info.FullMethod, rate_limiter.Identity(ctx, limiter.KeyBy(), peerIP(ctx)))
+		if err != nil {
+			logger.FromContext(ctx).Error("error taking rate limit token", "error", err)
+			return handler(ctx, req)
+		}
+
+		grpc.SetHeader(ctx, metadata.Pairs(
+			RateLimitLimitMetadata, strconv.Itoa(result.Limit),
+			RateLimitRemainingMetadata, strconv.Itoa(result.Remaining),
+			RateLimitResetMetadata, strconv.Itoa(ceilSeconds(result.ResetAfter)),
+		))
+
+		if !result.Allowed {
+			return nil, rateLimitExceededError(result.RetryAfter)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitExceededError returns a ResourceExhausted status with a RetryInfo
+// detail telling when a token is available again, rounded up to seconds as the
+// Retry-After header.
+func rateLimitExceededError(retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "Rate limit exceeded")
+
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(ceilSeconds(retryAfter)) * time.Second)})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// peerIP returns the IP of the client, or an empty string when the call has no
+// network peer.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,46 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/infra/rate_limiter"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitRejectsUnauthenticatedCallsOverTheLimit(t *testing.T) {
+	limiter, err := rate_limiter.NewRateLimiter(rate_limiter.NewMemoryStore(), rate_limiter.Limit{Requests: 1, Period: time.Minute, Burst: 1}, nil, rate_limiter.KeyByIP)
+	assert.NoError(t, err)
+
+	rateLimit := RateLimit(limiter)
+	authenticate := Authenticate(&staticAuthenticator{apiKey: "valid"})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ApiKeyMetadata, "invalid"))
+
+	call := func(fullMethod string) error {
+		info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+		_, err := rateLimit(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			return authenticate(ctx, req, info, func(ctx context.Context, req any) (any, error) { return nil, nil })
+		})
+		return err
+	}
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(call(readMethod)))
+
+	err = call(readMethod)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	details := status.Convert(err).Details()
+	assert.Len(t, details, 1)
+	retryInfo, ok := details[0].(*errdetails.RetryInfo)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, retryInfo.GetRetryDelay().AsDuration())
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(call(deleteMethod)))
+	assert.NoError(t, call(healthMethod))
+	assert.NoError(t, call(healthMethod))
+}
@@ -0,0 +1,227 @@
+package receiver_service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/grpc_err"
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	receiverv1 "github.com/felipemagrassi/pix-api/pkg/pb/receiver/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MethodScopes lists the scope each ReceiverService method requires, matching
+// the scopes of the /receiver routes.
+var MethodScopes = map[string]string{
+	receiverv1.ReceiverService_CreateReceiver_FullMethodName:  auth.ScopeReceiversWrite,
+	receiverv1.ReceiverService_UpdateReceiver_FullMethodName:  auth.ScopeReceiversWrite,
+	receiverv1.ReceiverService_GetReceiver_FullMethodName:     auth.ScopeReceiversRead,
+	receiverv1.ReceiverService_ListReceivers_FullMethodName:   auth.ScopeReceiversRead,
+	receiverv1.ReceiverService_DeleteReceivers_FullMethodName: auth.ScopeReceiversDelete,
+}
+
+// ReceiverService serves the receiver gRPC API with the same use case as the
+// REST controller.
+type ReceiverService struct {
+	receiverv1.UnimplementedReceiverServiceServer
+
+	receiverUseCase receiver_usecase.ReceiverUseCaseInterface
+}
+
+func NewReceiverService(receiverUseCase receiver_usecase.ReceiverUseCaseInterface) *ReceiverService {
+	return &ReceiverService{
+		receiverUseCase: receiverUseCase,
+	}
+}
+
+func (s *ReceiverService) CreateReceiver(ctx context.Context, req *receiverv1.CreateReceiverRequest) (*receiverv1.CreateReceiverResponse, error) {
+	keyValue, keyType := pixKeyInput(req.GetPixKey())
+
+	output, err := s.receiverUseCase.CreateReceiver(ctx, receiver_usecase.CreateReceiverInput{
+		Name:        req.GetName(),
+		Document:    req.GetDocument(),
+		Email:       req.GetEmail(),
+		PixKeyValue: keyValue,
+		PixKeyType:  keyType,
+	})
+	if err != nil {
+		return nil, convertError(ctx, "error creating receiver", err)
+	}
+
+	return &receiverv1.CreateReceiverResponse{
+		ReceiverId: output.ReceiverId,
+		PixKey:     pixKeyOutput(output.PixKey),
+	}, nil
+}
+
+func (s *ReceiverService) UpdateReceiver(ctx context.Context, req *receiverv1.UpdateReceiverRequest) (*receiverv1.UpdateReceiverResponse, error) {
+	receiverId, parseErr := pkg_entity.ParseID(req.GetReceiverId())
+	if parseErr != nil {
+		logger.FromContext(ctx).Warn("error parsing id", "error", parseErr)
+		return nil, invalidIdError("receiver_id")
+	}
+
+	keyValue, keyType := pixKeyInput(req.GetPixKey())
+
+	err := s.receiverUseCase.UpdateReceiver(ctx, receiverId, receiver_usecase.UpdateReceiverInput{
+		Name:        req.GetName(),
+		Document:    req.GetDocument(),
+		Email:       req.GetEmail(),
+		PixKeyValue: keyValue,
+		PixKeyType:  keyType,
+	})
+	if err != nil {
+		return nil, convertError(ctx, "error updating receiver", err)
+	}
+
+	return &receiverv1.UpdateReceiverResponse{}, nil
+}
+
+func (s *ReceiverService) GetReceiver(ctx context.Context, req *receiverv1.GetReceiverRequest) (*receiverv1.GetReceiverResponse, error) {
+	receiverId, parseErr := pkg_entity.ParseID(req.GetReceiverId())
+	if parseErr != nil {
+		logger.FromContext(ctx).Warn("error parsing id", "error", parseErr)
+		return nil, invalidIdError("receiver_id")
+	}
+
+	receiver, err := s.receiverUseCase.FindReceiverById(ctx, receiverId)
+	if err != nil {
+		return nil, convertError(ctx, "error finding receiver", err)
+	}
+
+	return &receiverv1.GetReceiverResponse{Receiver: receiverOutput(receiver)}, nil
+}
+
+func (s *ReceiverService) ListReceivers(ctx context.Context, req *receiverv1.ListReceiversRequest) (*receiverv1.ListReceiversResponse, error) {
+	page := int(req.GetPage())
+	if page < 1 {
+		page = 1
+	}
+
+	input := receiver_usecase.FindReceiversInput{
+		Status:      entity.ReceiverStatus(-1),
+		Name:        req.GetName(),
+		PixKeyValue: req.GetPixKeyValue(),
+		PixKeyType:  entity.PixKeyType(-1),
+		Page:        page,
+	}
+	if req.GetStatus() != receiverv1.ReceiverStatus_RECEIVER_STATUS_UNSPECIFIED {
+		input.Status = entity.ReceiverStatus(req.GetStatus())
+	}
+	if req.GetPixKeyType() != receiverv1.PixKeyType_PIX_KEY_TYPE_UNSPECIFIED {
+		input.PixKeyType = entity.PixKeyType(req.GetPixKeyType())
+	}
+
+	output, err := s.receiverUseCase.FindReceivers(ctx, input)
+	if err != nil {
+		return nil, convertError(ctx, "error finding receivers", err)
+	}
+
+	receivers := make([]*receiverv1.Receiver, 0, len(output.Receivers))
+	for i := range output.Receivers {
+		receivers = append(receivers, receiverOutput(&output.Receivers[i]))
+	}
+
+	return &receiverv1.ListReceiversResponse{
+		CurrentPage: int32(output.CurrentPage),
+		Receivers:   receivers,
+	}, nil
+}
+
+func (s *ReceiverService) DeleteReceivers(ctx context.Context, req *receiverv1.DeleteReceiversRequest) (*receiverv1.DeleteReceiversResponse, error) {
+	receiverIds := make([]pkg_entity.ID, 0, len(req.GetReceiverIds()))
+	for _, id := range req.GetReceiverIds() {
+		receiverId, parseErr := pkg_entity.ParseID(id)
+		if parseErr != nil {
+			logger.FromContext(ctx).Warn("error parsing id", "error", parseErr)
+			return nil, invalidIdError("receiver_ids")
+		}
+		receiverIds = append(receiverIds, receiverId)
+	}
+
+	err := s.receiverUseCase.DeleteReceivers(ctx, receiver_usecase.DeleteReceiversInput{ReceiverIds: receiverIds})
+	if err != nil {
+		return nil, convertError(ctx, "error deleting receivers", err)
+	}
+
+	return &receiverv1.DeleteReceiversResponse{}, nil
+}
+
+// pixKeyInput converts the key to the value and type name the use case parses.
+// An unspecified type is sent as empty so the use case reports it as invalid.
+func pixKeyInput(pixKey *receiverv1.PixKey) (string, string) {
+	if pixKey == nil {
+		return "", ""
+	}
+
+	keyType := ""
+	if pixKey.GetType() != receiverv1.PixKeyType_PIX_KEY_TYPE_UNSPECIFIED {
+		keyType = strings.ToLower(entity.PixKeyType(pixKey.GetType()).String())
+	}
+
+	return pixKey.GetValue(), keyType
+}
+
+func receiverOutput(receiver *receiver_usecase.FindReceiverOutput) *receiverv1.Receiver {
+	output := &receiverv1.Receiver{
+		ReceiverId:    receiver.ReceiverId,
+		Name:          receiver.Name,
+		Document:      receiver.Document,
+		Email:         receiver.Email,
+		Status:        receiverv1.ReceiverStatus(receiver.Status),
+		Bank:          receiver.Bank,
+		Office:        receiver.Office,
+		AccountNumber: receiver.AccountNumber,
+		PixKey:        pixKeyOutput(receiver.PixKey),
+		CreatedAt:     timestamp(receiver.CreatedAt),
+		UpdatedAt:     timestamp(receiver.UpdatedAt),
+	}
+
+	return output
+}
+
+func pixKeyOutput(pixKey *receiver_usecase.PixKeyOutput) *receiverv1.PixKey {
+	if pixKey == nil {
+		return nil
+	}
+
+	keyType, _ := entity.ParsePixKeyType(pixKey.KeyType)
+	return &receiverv1.PixKey{
+		Type:  receiverv1.PixKeyType(keyType),
+		Value: pixKey.KeyValue,
+	}
+}
+
+// timestamp parses the RFC 3339 times of the use case output, leaving unparsable
+// ones unset.
+func timestamp(value string) *timestamppb.Timestamp {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+
+	return timestamppb.New(parsed)
+}
+
+func invalidIdError(field string) error {
+	return grpc_err.NewInvalidArgumentError("Invalid ID", &errdetails.BadRequest_FieldViolation{Field: field, Description: "Invalid ID"})
+}
+
+// convertError logs unexpected errors, which the client only sees by message.
+func convertError(ctx context.Context, message string, err *internal_error.InternalError) error {
+	switch err.Err {
+	case "bad_request", "not_found":
+		logger.FromContext(ctx).Warn(message, "error", err.Error())
+	default:
+		logger.FromContext(ctx).Error(message, "error", err.Error())
+	}
+
+	return grpc_err.ConvertError(err)
+}
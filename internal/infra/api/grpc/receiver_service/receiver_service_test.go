@@ -0,0 +1,210 @@
+package receiver_service
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	receiverv1 "github.com/felipemagrassi/pix-api/pkg/pb/receiver/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeReceiverUseCase struct {
+	created    receiver_usecase.CreateReceiverInput
+	findInput  receiver_usecase.FindReceiversInput
+	deletedIds []pkg_entity.ID
+	receiver   *receiver_usecase.FindReceiverOutput
+	err        *internal_error.InternalError
+}
+
+func (f *fakeReceiverUseCase) CreateReceiver(ctx context.Context, input receiver_usecase.CreateReceiverInput) (*receiver_usecase.CreateReceiverOutput, *internal_error.InternalError) {
+	f.created = input
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &receiver_usecase.CreateReceiverOutput{
+		ReceiverId: "0f8fad5b-d9cb-469f-a165-70867728950e",
+		PixKey:     &receiver_usecase.PixKeyOutput{KeyValue: input.PixKeyValue, KeyType: "Cpf"},
+	}, nil
+}
+
+func (f *fakeReceiverUseCase) AddRandomPixKey(ctx context.Context, receiverId pkg_entity.ID) (*receiver_usecase.CreateReceiverOutput, *internal_error.InternalError) {
+	return nil, f.err
+}
+
+func (f *fakeReceiverUseCase) UpdateReceiver(ctx context.Context, receiverId pkg_entity.ID, input receiver_usecase.UpdateReceiverInput) *internal_error.InternalError {
+	return f.err
+}
+
+func (f *fakeReceiverUseCase) FindReceivers(ctx context.Context, input receiver_usecase.FindReceiversInput) (*receiver_usecase.FindReceiversOutput, *internal_error.InternalError) {
+	f.findInput = input
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &receiver_usecase.FindReceiversOutput{CurrentPage: input.Page, Receivers: []receiver_usecase.FindReceiverOutput{*f.receiver}}, nil
+}
+
+func (f *fakeReceiverUseCase) FindReceiverById(ctx context.Context, receiverId pkg_entity.ID) (*receiver_usecase.FindReceiverOutput, *internal_error.InternalError) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.receiver, nil
+}
+
+func (f *fakeReceiverUseCase) DeleteReceivers(ctx context.Context, input receiver_usecase.DeleteReceiversInput) *internal_error.InternalError {
+	f.deletedIds = input.ReceiverIds
+	return f.err
+}
+
+func (f *fakeReceiverUseCase) ExportReceivers(ctx context.Context, input receiver_usecase.ExportReceiversInput, w io.Writer) (*receiver_usecase.ExportReceiversOutput, *internal_error.InternalError) {
+	return &receiver_usecase.ExportReceiversOutput{}, f.err
+}
+
+func (f *fakeReceiverUseCase) ValidateReceiver(ctx context.Context, receiverId pkg_entity.ID) *internal_error.InternalError {
+	return f.err
+}
+
+func (f *fakeReceiverUseCase) ImportReceivers(ctx context.Context, input receiver_usecase.ImportReceiversInput, r io.Reader) (*receiver_usecase.ImportReceiversOutput, *internal_error.InternalError) {
+	return nil, f.err
+}
+
+func newTestClient(t *testing.T, useCase receiver_usecase.ReceiverUseCaseInterface) receiverv1.ReceiverServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	receiverv1.RegisterReceiverServiceServer(server, NewReceiverService(useCase))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return receiverv1.NewReceiverServiceClient(conn)
+}
+
+func testReceiver() *receiver_usecase.FindReceiverOutput {
+	return &receiver_usecase.FindReceiverOutput{
+		ReceiverId: pkg_entity.NewID().String(),
+		Name:       "Maria",
+		Document:   "12345678909",
+		Email:      "MARIA@EXAMPLE.COM",
+		Status:     entity.Draft,
+		PixKey:     &receiver_usecase.PixKeyOutput{KeyValue: "12345678909", KeyType: "Cpf"},
+		CreatedAt:  "2024-06-01T12:00:00Z",
+		UpdatedAt:  "2024-06-02T12:00:00Z",
+	}
+}
+
+func TestCreateReceiver(t *testing.T) {
+	useCase := &fakeReceiverUseCase{}
+	client := newTestClient(t, useCase)
+
+	res, err := client.CreateReceiver(context.Background(), &receiverv1.CreateReceiverRequest{
+		Name:     "Maria",
+		Document: "12345678909",
+		PixKey:   &receiverv1.PixKey{Type: receiverv1.PixKeyType_PIX_KEY_TYPE_CPF, Value: "12345678909"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "cpf", useCase.created.PixKeyType)
+	assert.Equal(t, "12345678909", useCase.created.PixKeyValue)
+	assert.Equal(t, "0f8fad5b-d9cb-469f-a165-70867728950e", res.GetReceiverId())
+	assert.Equal(t, receiverv1.PixKeyType_PIX_KEY_TYPE_CPF, res.GetPixKey().GetType())
+	assert.Equal(t, "12345678909", res.GetPixKey().GetValue())
+}
+
+func TestCreateReceiverFieldViolations(t *testing.T) {
+	useCase := &fakeReceiverUseCase{
+		err: internal_error.NewBadRequestError("Invalid pix key type", internal_error.Causes{Field: "key_type", Message: "Invalid Key Type"}),
+	}
+	client := newTestClient(t, useCase)
+
+	_, err := client.CreateReceiver(context.Background(), &receiverv1.CreateReceiverRequest{Name: "Maria"})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "", useCase.created.PixKeyType)
+
+	assert.Len(t, st.Details(), 1)
+	badRequest := st.Details()[0].(*errdetails.BadRequest)
+	assert.Equal(t, "key_type", badRequest.FieldViolations[0].Field)
+}
+
+func TestGetReceiver(t *testing.T) {
+	useCase := &fakeReceiverUseCase{receiver: testReceiver()}
+	client := newTestClient(t, useCase)
+
+	res, err := client.GetReceiver(context.Background(), &receiverv1.GetReceiverRequest{ReceiverId: useCase.receiver.ReceiverId})
+	assert.Nil(t, err)
+	assert.Equal(t, useCase.receiver.ReceiverId, res.Receiver.ReceiverId)
+	assert.Equal(t, receiverv1.ReceiverStatus_RECEIVER_STATUS_DRAFT, res.Receiver.Status)
+	assert.Equal(t, receiverv1.PixKeyType_PIX_KEY_TYPE_CPF, res.Receiver.PixKey.Type)
+	assert.Equal(t, "2024-06-01T12:00:00Z", res.Receiver.CreatedAt.AsTime().Format("2006-01-02T15:04:05Z07:00"))
+
+	_, err = client.GetReceiver(context.Background(), &receiverv1.GetReceiverRequest{ReceiverId: "not-an-id"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	useCase.err = internal_error.NewNotFoundError("receiver not found")
+	_, err = client.GetReceiver(context.Background(), &receiverv1.GetReceiverRequest{ReceiverId: useCase.receiver.ReceiverId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestListReceiversFilters(t *testing.T) {
+	useCase := &fakeReceiverUseCase{receiver: testReceiver()}
+	client := newTestClient(t, useCase)
+
+	res, err := client.ListReceivers(context.Background(), &receiverv1.ListReceiversRequest{Name: "Mar%"})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), res.CurrentPage)
+	assert.Len(t, res.Receivers, 1)
+	assert.Equal(t, entity.ReceiverStatus(-1), useCase.findInput.Status)
+	assert.Equal(t, entity.PixKeyType(-1), useCase.findInput.PixKeyType)
+	assert.Equal(t, "Mar%", useCase.findInput.Name)
+
+	_, err = client.ListReceivers(context.Background(), &receiverv1.ListReceiversRequest{
+		Status:     receiverv1.ReceiverStatus_RECEIVER_STATUS_VALID,
+		PixKeyType: receiverv1.PixKeyType_PIX_KEY_TYPE_EMAIL,
+		Page:       3,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, entity.Valid, useCase.findInput.Status)
+	assert.Equal(t, entity.EmailKeyType, useCase.findInput.PixKeyType)
+	assert.Equal(t, 3, useCase.findInput.Page)
+
+	useCase.err = internal_error.NewInternalServerError("error finding receivers", io.ErrUnexpectedEOF)
+	_, err = client.ListReceivers(context.Background(), &receiverv1.ListReceiversRequest{})
+	st := status.Convert(err)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "error finding receivers", st.Message())
+}
+
+func TestDeleteReceivers(t *testing.T) {
+	useCase := &fakeReceiverUseCase{}
+	client := newTestClient(t, useCase)
+
+	ids := []string{pkg_entity.NewID().String(), pkg_entity.NewID().String()}
+	_, err := client.DeleteReceivers(context.Background(), &receiverv1.DeleteReceiversRequest{ReceiverIds: ids})
+	assert.Nil(t, err)
+	assert.Len(t, useCase.deletedIds, 2)
+
+	_, err = client.DeleteReceivers(context.Background(), &receiverv1.DeleteReceiversRequest{ReceiverIds: []string{"bad"}})
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "receiver_ids", st.Details()[0].(*errdetails.BadRequest).FieldViolations[0].Field)
+}
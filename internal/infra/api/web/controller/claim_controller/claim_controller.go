@@ -0,0 +1,251 @@
+package claim_controller
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/rest_err"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/felipemagrassi/pix-api/internal/usecase/claim_usecase"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type ClaimController struct {
+	claimUseCase claim_usecase.ClaimUseCaseInterface
+}
+
+func NewClaimController(claimUseCase claim_usecase.ClaimUseCaseInterface) *ClaimController {
+	return &ClaimController{
+		claimUseCase: claimUseCase,
+	}
+}
+
+// FindClaims lists the claims of the tenant
+//
+//	@Summary      Find Claims
+//	@Description  get pix key ownership and portability claims
+//	@Tags         claims
+//	@Accept       json
+//	@Produce      json
+//	@Param        status    query     string  false  "Status (open, waiting_resolution, confirmed, canceled, completed)"
+//	@Param        receiver_id    query     string  false  "Claims where the receiver is the claimer or the donor"
+//	@Param        page    query     int  false  "Current page"
+//	@Success      200  {object}   claim_usecase.FindClaimsOutput
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
+//	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
+//	@Failure      500  {object}  rest_err.RestErr
+//	@Router       /claims [get]
+func (r *ClaimController) FindClaims(c *gin.Context) {
+	input := claim_usecase.FindClaimsInput{Page: 1}
+
+	if pageInt, convErr := strconv.Atoi(c.Query("page")); convErr == nil {
+		input.Page = pageInt
+	}
+
+	if rawStatus := c.Query("status"); rawStatus != "" {
+		status, ok := entity.ParseClaimStatus(rawStatus)
+		if !ok {
+			writeError(c, rest_err.NewBadRequestError("Invalid status", rest_err.Causes{Field: "status", Message: "Status must be open, waiting_resolution, confirmed, canceled or completed"}))
+			return
+		}
+		input.Status = status
+	}
+
+	if rawReceiverId := c.Query("receiver_id"); rawReceiverId != "" {
+		receiverId, parseErr := pkg_entity.ParseID(rawReceiverId)
+		if parseErr != nil {
+			writeError(c, rest_err.NewBadRequestError("Invalid ID", rest_err.Causes{Field: "receiver_id", Message: "Invalid ID"}))
+			return
+		}
+		input.ReceiverId = &receiverId
+	}
+
+	claims, err := r.claimUseCase.FindClaims(c.Request.Context(), input)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("error finding claims", "error", err.Error())
+		writeError(c, rest_err.ConvertError(err))
+		return
+	}
+
+	c.JSON(200, claims)
+}
+
+// FindClaim find existing claim
+//
+//	@Summary      Find Claim
+//	@Description  get a pix key claim
+//	@Tags         claims
+//	@Accept       json
+//	@Produce      json
+//	@Param        claimId    path     string  true  "Claim uuid"
+//	@Success      200  {object}   claim_usecase.ClaimOutput
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
+//	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
+//	@Failure      404  {object}  rest_err.RestErr
+//	@Failure      500  {object}  rest_err.RestErr
+//	@Router       /claims/{claimId} [get]
+func (r *ClaimController) FindClaimById(c *gin.Context) {
+	claimId, ok := parseClaimId(c)
+	if !ok {
+		return
+	}
+
+	claim, err := r.claimUseCase.FindClaimById(c.Request.Context(), claimId)
+	if err != nil {
+		if err.Err != "not_found" {
+			logger.FromContext(c.Request.Context()).Error("error finding claim", "error", err.Error())
+		}
+		writeError(c, rest_err.ConvertError(err))
+		return
+	}
+
+	c.JSON(200, claim)
+}
+
+// OpenClaim open a claim for a pix key
+//
+//	@Summary      Open Claim
+//	@Description  Ask the receiver holding a pix key to hand it over (ownership or portability)
+//	@Tags         claims
+//	@Accept       json
+//	@Produce      json
+//	@Param        request   body     claim_usecase.OpenClaimInput  true  "Claim body"
+//	@Success      201  {object}  claim_usecase.ClaimOutput
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
+//	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
+//	@Failure      404  {object}  rest_err.RestErr
+//	@Failure      409  {object}  rest_err.RestErr
+//	@Failure      500  {object}  rest_err.RestErr
+//	@Router       /claims [post]
+func (r *ClaimController) OpenClaim(c *gin.Context) {
+	var openClaimInput claim_usecase.OpenClaimInput
+
+	if err := c.ShouldBindJSON(&openClaimInput); err != nil {
+		restErr := rest_err.NewBadRequestError("Invalid JSON", rest_err.Causes{Field: "json", Message: "Invalid JSON"})
+		logger.FromContext(c.Request.Context()).Warn("error binding json", "error", err)
+		writeError(c, restErr)
+		return
+	}
+
+	claim, err := r.claimUseCase.OpenClaim(c.Request.Context(), openClaimInput)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("error opening claim", "error", err.Error())
+		writeError(c, rest_err.ConvertError(err))
+		return
+	}
+
+	c.JSON(201, claim)
+}
+
+// ConfirmClaim
+//
+//	@Summary      Confirm Claim
+//	@Description  Confirm, on behalf of the donor, that the pix key can be handed over
+//	@Tags         claims
+//	@Produce      json
+//	@Param        claimId    path     string  true  "Claim uuid"
+//	@Success      200  {object}  claim_usecase.ClaimOutput
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
+//	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
+//	@Failure      404  {object}  rest_err.RestErr
+//	@Failure      409  {object}  rest_err.RestErr
+//	@Failure      500  {object}  rest_err.RestErr
+//	@Router       /claims/{claimId}/confirm [post]
+func (r *ClaimController) ConfirmClaim(c *gin.Context) {
+	r.transition(c, "error confirming claim", r.claimUseCase.ConfirmClaim)
+}
+
+// CancelClaim
+//
+//	@Summary      Cancel Claim
+//	@Description  Cancel an active claim, leaving the pix key with the donor
+//	@Tags         claims
+//	@Produce      json
+//	@Param        claimId    path     string  true  "Claim uuid"
+//	@Success      200  {object}  claim_usecase.ClaimOutput
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
+//	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
+//	@Failure      404  {object}  rest_err.RestErr
+//	@Failure      409  {object}  rest_err.RestErr
+//	@Failure      500  {object}  rest_err.RestErr
+//	@Router       /claims/{claimId}/cancel [post]
+func (r *ClaimController) CancelClaim(c *gin.Context) {
+	r.transition(c, "error canceling claim", r.claimUseCase.CancelClaim)
+}
+
+// CompleteClaim
+//
+//	@Summary      Complete Claim
+//	@Description  Transfer the pix key of a confirmed claim to the claimer, deleting the donor
+//	@Tags         claims
+//	@Produce      json
+//	@Param        claimId    path     string  true  "Claim uuid"
+//	@Success      200  {object}  claim_usecase.ClaimOutput
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
+//	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
+//	@Failure      404  {object}  rest_err.RestErr
+//	@Failure      409  {object}  rest_err.RestErr
+//	@Failure      500  {object}  rest_err.RestErr
+//	@Router       /claims/{claimId}/complete [post]
+func (r *ClaimController) CompleteClaim(c *gin.Context) {
+	r.transition(c, "error completing claim", r.claimUseCase.CompleteClaim)
+}
+
+func (r *ClaimController) transition(c *gin.Context, logMessage string, apply func(ctx context.Context, claimId pkg_entity.ID) (*claim_usecase.ClaimOutput, *internal_error.InternalError)) {
+	claimId, ok := parseClaimId(c)
+	if !ok {
+		return
+	}
+
+	claim, err := apply(c.Request.Context(), claimId)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error(logMessage, "error", err.Error())
+		writeError(c, rest_err.ConvertError(err))
+		return
+	}
+
+	c.JSON(200, claim)
+}
+
+func parseClaimId(c *gin.Context) (pkg_entity.ID, bool) {
+	claimId, parseErr := pkg_entity.ParseID(c.Param("claimId"))
+	if parseErr != nil {
+		logger.FromContext(c.Request.Context()).Warn("error parsing id", "error", parseErr)
+		writeError(c, rest_err.NewBadRequestError("Invalid ID", rest_err.Causes{Field: "id", Message: "Invalid ID"}))
+		return pkg_entity.ID{}, false
+	}
+
+	return claimId, true
+}
+
+// writeError answers the request with restErr tagged with the request id.
+func writeError(c *gin.Context, restErr *rest_err.RestErr) {
+	c.JSON(restErr.Code, restErr.WithRequestId(logger.RequestIdFromContext(c.Request.Context())))
+}
@@ -0,0 +1,47 @@
+package health_controller
+
+import (
+	"net/http"
+
+	"github.com/felipemagrassi/pix-api/internal/infra/health"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController struct {
+	registry *health.Registry
+}
+
+func NewHealthController(registry *health.Registry) *HealthController {
+	return &HealthController{
+		registry: registry,
+	}
+}
+
+// Liveness only reports that the process is serving requests; it never checks
+// dependencies so a database outage does not get the process restarted.
+func (h *HealthController) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusUp})
+}
+
+// Readiness answers 503 when a critical dependency is down, so the instance is
+// taken out of the load balancer until it recovers.
+func (h *HealthController) Readiness(c *gin.Context) {
+	report := h.registry.Check(c.Request.Context())
+
+	c.JSON(statusCode(report), gin.H{"status": report.Status})
+}
+
+// Health returns the status and details of every dependency for operators.
+func (h *HealthController) Health(c *gin.Context) {
+	report := h.registry.Check(c.Request.Context())
+
+	c.JSON(statusCode(report), report)
+}
+
+func statusCode(report health.Report) int {
+	if report.Status == health.StatusDown {
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusOK
+}
@@ -1,9 +1,13 @@
 package receiver_controller
 
 import (
-	"log/slog"
+	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/felipemagrassi/pix-api/configuration/logger"
 	"github.com/felipemagrassi/pix-api/configuration/rest_err"
 	"github.com/felipemagrassi/pix-api/internal/entity"
 	"github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase"
@@ -34,40 +38,35 @@ func NewReceiverController(receiverUseCase receiver_usecase.ReceiverUseCaseInter
 //	@Param        pix_key_type    query     int  false  "Filter by Pix Key Types (1...6)"
 //	@Param        page    query     int  false  "Current page"
 //	@Success      200  {array}   receiver_usecase.FindReceiversOutput
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
 //	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
 //	@Failure      404  {object}  rest_err.RestErr
 //	@Failure      500  {object}  rest_err.RestErr
 //	@Router       / [get]
 func (r *ReceiverController) FindReceivers(c *gin.Context) {
-	intStatus, convErr := strconv.Atoi(c.Query("status"))
-	if convErr != nil {
-		intStatus = -1
-	}
-	name := c.Query("name")
-	pixKeyValue := c.Query("pix_key")
-	pixKeyType, convErr := strconv.Atoi(c.Query("pix_key_type"))
-	if convErr != nil {
-		pixKeyType = -1
-	}
+	status, name, pixKeyValue, pixKeyType := parseReceiversFilter(c)
 	pageInt, convErr := strconv.Atoi(c.Query("page"))
 	if convErr != nil {
 		pageInt = 1
 	}
 
 	findReceiverInput := receiver_usecase.FindReceiversInput{
-		Status:      entity.ReceiverStatus(intStatus),
+		Status:      status,
 		Name:        name,
 		PixKeyValue: pixKeyValue,
-		PixKeyType:  entity.PixKeyType(pixKeyType),
+		PixKeyType:  pixKeyType,
 		Page:        pageInt,
 	}
 
 	receivers, err := r.receiverUseCase.FindReceivers(c.Request.Context(), findReceiverInput)
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
-		slog.Error("error finding receivers")
-		slog.Error(err.Error())
-		c.JSON(errRest.Code, errRest)
+		logger.FromContext(c.Request.Context()).Error("error finding receivers", "error", err.Error())
+		writeError(c, errRest)
 		return
 	}
 
@@ -83,7 +82,12 @@ func (r *ReceiverController) FindReceivers(c *gin.Context) {
 //	@Produce      json
 //	@Param        receiverId    query     int  true  "Receiver uuid"
 //	@Success      200  {array}   receiver_usecase.FindReceiverOutput
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
 //	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
 //	@Failure      404  {object}  rest_err.RestErr
 //	@Failure      500  {object}  rest_err.RestErr
 //	@Router       /{id} [get]
@@ -93,8 +97,8 @@ func (r *ReceiverController) FindReceiverById(c *gin.Context) {
 	receiverId, parseErr := pkg_entity.ParseID(id)
 	if parseErr != nil {
 		errRest := rest_err.NewBadRequestError("Invalid ID", rest_err.Causes{Field: "id", Message: "Invalid ID"})
-		slog.Error("error parsing id", parseErr)
-		c.JSON(errRest.Code, errRest)
+		logger.FromContext(c.Request.Context()).Warn("error parsing id", "error", parseErr)
+		writeError(c, errRest)
 		return
 	}
 
@@ -102,12 +106,12 @@ func (r *ReceiverController) FindReceiverById(c *gin.Context) {
 	if err != nil {
 		if err.Err == "not_found" {
 			errRest := rest_err.ConvertError(err)
-			c.JSON(errRest.Code, errRest)
+			writeError(c, errRest)
 			return
 		} else {
 			errRest := rest_err.ConvertError(err)
-			slog.Error("error finding receiver")
-			c.JSON(errRest.Code, errRest)
+			logger.FromContext(c.Request.Context()).Error("error finding receiver", "error", err.Error())
+			writeError(c, errRest)
 			return
 
 		}
@@ -124,9 +128,15 @@ func (r *ReceiverController) FindReceiverById(c *gin.Context) {
 //	@Accept       json
 //	@Produce      json
 //	@Param        request   body     receiver_usecase.CreateReceiverInput  true  "Receiver body"
-//	@Success      201  {object}  string
+//	@Success      201  {object}  receiver_usecase.CreateReceiverOutput
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
 //	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
 //	@Failure      404  {object}  rest_err.RestErr
+//	@Failure      409  {object}  rest_err.RestErr
 //	@Failure      500  {object}  rest_err.RestErr
 //	@Router       / [post]
 func (r *ReceiverController) CreateReceiver(c *gin.Context) {
@@ -134,20 +144,58 @@ func (r *ReceiverController) CreateReceiver(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&createReceiverInput); err != nil {
 		restErr := rest_err.NewBadRequestError("Invalid JSON", rest_err.Causes{Field: "json", Message: "Invalid JSON"})
-		slog.Error("error binding json")
-		c.JSON(restErr.Code, restErr)
+		logger.FromContext(c.Request.Context()).Warn("error binding json", "error", err)
+		writeError(c, restErr)
 		return
 	}
 
-	err := r.receiverUseCase.CreateReceiver(c.Request.Context(), createReceiverInput)
+	receiver, err := r.receiverUseCase.CreateReceiver(c.Request.Context(), createReceiverInput)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
-		slog.Error("error creating receiver")
-		c.JSON(restErr.Code, restErr)
+		logger.FromContext(c.Request.Context()).Error("error creating receiver", "error", err.Error())
+		writeError(c, restErr)
+		return
+	}
+
+	c.JSON(201, gin.H{"message": "Receiver created successfully", "receiver_id": receiver.ReceiverId, "pix_key": receiver.PixKey})
+}
+
+// AddRandomPixKey
+//
+//	@Summary      Add Random Pix Key
+//	@Description  Generate another random (EVP) key for the owner and account of a receiver, registered to a new draft receiver
+//	@Tags         receivers
+//	@Produce      json
+//	@Param        receiverId    path     string  true  "Receiver uuid"
+//	@Success      201  {object}  receiver_usecase.CreateReceiverOutput
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
+//	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
+//	@Failure      404  {object}  rest_err.RestErr
+//	@Failure      409  {object}  rest_err.RestErr
+//	@Failure      500  {object}  rest_err.RestErr
+//	@Router       /{receiverId}/pix-keys/random [post]
+func (r *ReceiverController) AddRandomPixKey(c *gin.Context) {
+	receiverId, parseErr := pkg_entity.ParseID(c.Param("receiverId"))
+	if parseErr != nil {
+		logger.FromContext(c.Request.Context()).Warn("error parsing id", "error", parseErr)
+		writeError(c, rest_err.NewBadRequestError("Invalid ID", rest_err.Causes{Field: "id", Message: "Invalid ID"}))
+		return
+	}
+
+	receiver, err := r.receiverUseCase.AddRandomPixKey(c.Request.Context(), receiverId)
+	if err != nil {
+		if err.Err != "not_found" {
+			logger.FromContext(c.Request.Context()).Error("error adding random pix key", "error", err.Error())
+		}
+		writeError(c, rest_err.ConvertError(err))
 		return
 	}
 
-	c.JSON(201, gin.H{"message": "Receiver created successfully"})
+	c.JSON(201, receiver)
 }
 
 // UpdateReceiver
@@ -160,8 +208,14 @@ func (r *ReceiverController) CreateReceiver(c *gin.Context) {
 //	@Param        receiverId   query string  true  "Receiver id"
 //	@Param        request   body     receiver_usecase.UpdateReceiverInput  true  "Receiver body"
 //	@Success      201  {object}  string
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
 //	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
 //	@Failure      404  {object}  rest_err.RestErr
+//	@Failure      409  {object}  rest_err.RestErr
 //	@Failure      500  {object}  rest_err.RestErr
 //	@Router       / [put]
 func (r *ReceiverController) UpdateReceiver(c *gin.Context) {
@@ -170,8 +224,8 @@ func (r *ReceiverController) UpdateReceiver(c *gin.Context) {
 	receiverId, parseErr := pkg_entity.ParseID(id)
 	if parseErr != nil {
 		restErr := rest_err.NewBadRequestError("Invalid ID", rest_err.Causes{Field: "id", Message: "Invalid ID"})
-		slog.Error("error parsing id")
-		c.JSON(restErr.Code, restErr)
+		logger.FromContext(c.Request.Context()).Warn("error parsing id", "error", parseErr)
+		writeError(c, restErr)
 		return
 	}
 
@@ -179,16 +233,16 @@ func (r *ReceiverController) UpdateReceiver(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&updateReceiverInput); err != nil {
 		restErr := rest_err.NewBadRequestError("Invalid JSON", rest_err.Causes{Field: "json", Message: "Invalid JSON"})
-		slog.Error("error binding json", err)
-		c.JSON(restErr.Code, restErr)
+		logger.FromContext(c.Request.Context()).Warn("error binding json", "error", err)
+		writeError(c, restErr)
 		return
 	}
 
 	err := r.receiverUseCase.UpdateReceiver(c.Request.Context(), receiverId, updateReceiverInput)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
-		slog.Error("error updating receiver")
-		c.JSON(restErr.Code, restErr)
+		logger.FromContext(c.Request.Context()).Error("error updating receiver", "error", err.Error())
+		writeError(c, restErr)
 		return
 	}
 
@@ -204,7 +258,12 @@ func (r *ReceiverController) UpdateReceiver(c *gin.Context) {
 //	@Produce      json
 //	@Param        ids   query     string  true  "Receiver uuids"
 //	@Success      204  {object}  string
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
 //	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
 //	@Failure      404  {object}  rest_err.RestErr
 //	@Failure      500  {object}  rest_err.RestErr
 //	@Router       / [delete]
@@ -216,8 +275,8 @@ func (r *ReceiverController) DeleteReceivers(c *gin.Context) {
 		receiverId, parseErr := pkg_entity.ParseID(id)
 		if parseErr != nil {
 			restErr := rest_err.NewBadRequestError("Invalid ID", rest_err.Causes{Field: "id", Message: "Invalid ID"})
-			slog.Error("error parsing id")
-			c.JSON(restErr.Code, restErr)
+			logger.FromContext(c.Request.Context()).Warn("error parsing id", "error", parseErr)
+			writeError(c, restErr)
 			return
 		}
 		receiverIds = append(receiverIds, receiverId)
@@ -226,10 +285,145 @@ func (r *ReceiverController) DeleteReceivers(c *gin.Context) {
 	err := r.receiverUseCase.DeleteReceivers(c.Request.Context(), receiver_usecase.DeleteReceiversInput{ReceiverIds: receiverIds})
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
-		slog.Error("error deleting receivers")
-		c.JSON(restErr.Code, restErr)
+		logger.FromContext(c.Request.Context()).Error("error deleting receivers", "error", err.Error())
+		writeError(c, restErr)
 		return
 	}
 
 	c.JSON(204, nil)
 }
+
+// ExportReceivers streams all receivers matching the filters
+//
+//	@Summary      Export Receivers
+//	@Description  stream receivers matching the filters as CSV or NDJSON
+//	@Tags         receivers
+//	@Produce      text/csv
+//	@Produce      application/x-ndjson
+//	@Param        status    query     int  false  "Status (1,2)"
+//	@Param        name    query     string  false  "Filter by receiver name"
+//	@Param        pix_key    query     string  false  "Filter by Pix Key"
+//	@Param        pix_key_type    query     int  false  "Filter by Pix Key Types (1...6)"
+//	@Param        format    query     string  false  "Export format (csv, ndjson)"
+//	@Param        columns    query     string  false  "Comma separated list of columns"
+//	@Param        mask    query     bool  false  "Mask personal data (default true), false requires the pii:read scope"
+//	@Success      200  {string}  string
+//	@Security     ApiKeyAuth
+//	@Security     BearerAuth
+//	@Failure      400  {object}  rest_err.RestErr
+//	@Failure      401  {object}  rest_err.RestErr
+//	@Failure      403  {object}  rest_err.RestErr
+//	@Failure      429  {object}  rest_err.RestErr
+//	@Failure      500  {object}  rest_err.RestErr
+//	@Router       /export [get]
+func (r *ReceiverController) ExportReceivers(c *gin.Context) {
+	status, name, pixKeyValue, pixKeyType := parseReceiversFilter(c)
+
+	mask, convErr := strconv.ParseBool(c.DefaultQuery("mask", "true"))
+	if convErr != nil {
+		restErr := rest_err.NewBadRequestError("Invalid mask", rest_err.Causes{Field: "mask", Message: "Mask must be a boolean"})
+		writeError(c, restErr)
+		return
+	}
+
+	columns := make([]string, 0)
+	if rawColumns := c.Query("columns"); rawColumns != "" {
+		for _, column := range strings.Split(rawColumns, ",") {
+			columns = append(columns, strings.TrimSpace(column))
+		}
+	}
+
+	format := c.DefaultQuery("format", receiver_usecase.ExportFormatCSV)
+
+	exportInput := receiver_usecase.ExportReceiversInput{
+		Status:      status,
+		Name:        name,
+		PixKeyValue: pixKeyValue,
+		PixKeyType:  pixKeyType,
+		Format:      format,
+		Columns:     columns,
+		Mask:        mask,
+	}
+
+	// Exports can take longer than the server write timeout, so lift it for this
+	// response; writers that do not support deadlines are left as they are.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	writer := &exportWriter{c: c, format: format}
+	output, err := r.receiverUseCase.ExportReceivers(c.Request.Context(), exportInput, writer)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("error exporting receivers", "rows", output.Rows, "error", err.Error())
+		if writer.started {
+			// The 200 status is already sent, so the failure is reported in the
+			// trailer for clients to tell a truncated export from a complete one.
+			c.Writer.Header().Set(ExportErrorTrailer, rest_err.ConvertError(err).Message)
+			c.Abort()
+			return
+		}
+
+		restErr := rest_err.ConvertError(err)
+		writeError(c, restErr)
+		return
+	}
+
+	if !writer.started {
+		writer.writeHeaders()
+	}
+}
+
+// ExportErrorTrailer is the trailer set when an export fails after its first rows
+// were sent.
+const ExportErrorTrailer = "Export-Error"
+
+// exportWriter only commits the response headers on the first write, so errors
+// raised before any row is produced can still be answered with a JSON body.
+type exportWriter struct {
+	c       *gin.Context
+	format  string
+	started bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.writeHeaders()
+	}
+
+	n, err := w.c.Writer.Write(p)
+	w.c.Writer.Flush()
+	return n, err
+}
+
+func (w *exportWriter) writeHeaders() {
+	w.started = true
+
+	contentType := "text/csv"
+	if w.format == receiver_usecase.ExportFormatNDJSON {
+		contentType = "application/x-ndjson"
+	}
+
+	w.c.Header("Content-Type", contentType)
+	w.c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=receivers.%s", w.format))
+	w.c.Header("Trailer", ExportErrorTrailer)
+	w.c.Status(http.StatusOK)
+	w.c.Writer.WriteHeaderNow()
+}
+
+func parseReceiversFilter(c *gin.Context) (entity.ReceiverStatus, string, string, entity.PixKeyType) {
+	intStatus, convErr := strconv.Atoi(c.Query("status"))
+	if convErr != nil {
+		intStatus = -1
+	}
+	name := c.Query("name")
+	pixKeyValue := c.Query("pix_key")
+	pixKeyType, convErr := strconv.Atoi(c.Query("pix_key_type"))
+	if convErr != nil {
+		pixKeyType = -1
+	}
+
+	return entity.ReceiverStatus(intStatus), name, pixKeyValue, entity.PixKeyType(pixKeyType)
+}
+
+// writeError answers the request with restErr tagged with the request id.
+func writeError(c *gin.Context, restErr *rest_err.RestErr) {
+	c.JSON(restErr.Code, restErr.WithRequestId(logger.RequestIdFromContext(c.Request.Context())))
+}
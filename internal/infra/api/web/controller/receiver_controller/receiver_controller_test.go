@@ -0,0 +1,69 @@
+package receiver_controller
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStreamRepository streams rows receivers and then fails, as a database
+// connection lost in the middle of an export would.
+type failingStreamRepository struct {
+	*receiver_repository.MemoryReceiverRepository
+	receiver *entity.Receiver
+	rows     int
+}
+
+func (r *failingStreamRepository) StreamReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, fn func(receiver *entity.Receiver) *internal_error.InternalError) *internal_error.InternalError {
+	for i := 0; i < r.rows; i++ {
+		if err := fn(r.receiver); err != nil {
+			return err
+		}
+	}
+
+	return internal_error.NewInternalServerError("error streaming receivers", io.ErrUnexpectedEOF)
+}
+
+func TestExportReceiversReportsFailuresAfterStreamingStarted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	receiver, err := entity.NewReceiver("12345678909", "govrada@gmail.com", "email", "Felipe", "felipe@email.com")
+	require.Nil(t, err)
+
+	// Enough rows to fill the export buffer, so part of the file is sent before
+	// the repository fails.
+	repo := &failingStreamRepository{MemoryReceiverRepository: &receiver_repository.MemoryReceiverRepository{}, receiver: receiver, rows: 200}
+	controller := NewReceiverController(receiver_usecase.NewReceiverUseCase(repo))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(auth.WithTenant(c.Request.Context(), "acme"))
+	})
+	router.GET("/receiver/export", controller.ExportReceivers)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	res, httpErr := http.Get(server.URL + "/receiver/export?format=csv")
+	require.NoError(t, httpErr)
+	defer res.Body.Close()
+
+	body, httpErr := io.ReadAll(res.Body)
+	require.NoError(t, httpErr)
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.True(t, strings.HasPrefix(string(body), strings.Join(receiver_usecase.ExportColumns, ",")+"\n"))
+	assert.Equal(t, "error streaming receivers", res.Trailer.Get(ExportErrorTrailer))
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/rest_err"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ApiKeyHeader        = "X-API-Key"
+	AuthorizationHeader = "Authorization"
+	PrincipalKey        = "principal"
+)
+
+// Authenticate resolves the request credentials with the first authenticator that
+// recognizes them and stores the principal in both the gin and the request context.
+func Authenticate(authenticators ...auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credentials := auth.Credentials{
+			ApiKey: c.GetHeader(ApiKeyHeader),
+		}
+
+		if bearer, found := strings.CutPrefix(c.GetHeader(AuthorizationHeader), "Bearer "); found {
+			credentials.BearerToken = strings.TrimSpace(bearer)
+		}
+
+		if credentials.ApiKey == "" && credentials.BearerToken == "" {
+			restErr := rest_err.NewUnauthorizedError("Missing credentials")
+			abortWithError(c, restErr)
+			return
+		}
+
+		for _, authenticator := range authenticators {
+			principal, err := authenticator.Authenticate(c.Request.Context(), credentials)
+			if err != nil {
+				restErr := rest_err.ConvertError(err)
+				if restErr.Code >= 500 {
+					logger.FromContext(c.Request.Context()).Error("error authenticating request", "error", err.Error())
+				}
+				abortWithError(c, restErr)
+				return
+			}
+
+			if principal != nil {
+				c.Set(PrincipalKey, principal)
+				ctx := auth.WithPrincipal(c.Request.Context(), principal)
+				ctx = logger.With(ctx, "tenant_id", principal.TenantId, "actor", auth.ActorFromContext(ctx))
+				c.Request = c.Request.WithContext(ctx)
+				c.Next()
+				return
+			}
+		}
+
+		restErr := rest_err.NewUnauthorizedError("Unsupported credentials")
+		abortWithError(c, restErr)
+	}
+}
+
+// RequireScope rejects requests whose principal was not granted the scope.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := auth.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			restErr := rest_err.NewUnauthorizedError("Missing credentials")
+			abortWithError(c, restErr)
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			restErr := rest_err.NewForbiddenError("Missing scope " + scope)
+			abortWithError(c, restErr)
+			return
+		}
+
+		c.Next()
+	}
+}
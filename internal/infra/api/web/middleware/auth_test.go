@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/configuration/rest_err"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticAuthenticator struct {
+	apiKey    string
+	principal *auth.Principal
+}
+
+func (a *staticAuthenticator) Authenticate(ctx context.Context, credentials auth.Credentials) (*auth.Principal, *internal_error.InternalError) {
+	if credentials.ApiKey == "" {
+		return nil, nil
+	}
+
+	if credentials.ApiKey != a.apiKey {
+		return nil, internal_error.NewUnauthorizedError("Invalid api key")
+	}
+
+	return a.principal, nil
+}
+
+func newAuthRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	authenticator := &staticAuthenticator{
+		apiKey:    "valid",
+		principal: &auth.Principal{Subject: "test", Method: auth.MethodApiKey, Scopes: []string{auth.ScopeReceiversRead}},
+	}
+
+	router := gin.New()
+	router.Use(Authenticate(authenticator))
+	router.GET("/read", RequireScope(auth.ScopeReceiversRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.DELETE("/delete", RequireScope(auth.ScopeReceiversDelete), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	return router
+}
+
+func performRequest(router *gin.Engine, method, path, apiKey string) (*httptest.ResponseRecorder, *rest_err.RestErr) {
+	req := httptest.NewRequest(method, path, nil)
+	if apiKey != "" {
+		req.Header.Set(ApiKeyHeader, apiKey)
+	}
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	var restErr *rest_err.RestErr
+	_ = json.Unmarshal(res.Body.Bytes(), &restErr)
+	return res, restErr
+}
+
+func TestAuthenticateAllowsScopedRequest(t *testing.T) {
+	res, _ := performRequest(newAuthRouter(), http.MethodGet, "/read", "valid")
+	assert.Equal(t, http.StatusOK, res.Code)
+}
+
+func TestAuthenticateRejectsMissingCredentials(t *testing.T) {
+	res, restErr := performRequest(newAuthRouter(), http.MethodGet, "/read", "")
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Equal(t, "unauthorized", restErr.Err)
+}
+
+func TestAuthenticateRejectsInvalidKey(t *testing.T) {
+	res, restErr := performRequest(newAuthRouter(), http.MethodGet, "/read", "invalid")
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Equal(t, "Invalid api key", restErr.Message)
+}
+
+func TestRequireScopeRejectsMissingScope(t *testing.T) {
+	res, restErr := performRequest(newAuthRouter(), http.MethodDelete, "/delete", "valid")
+	assert.Equal(t, http.StatusForbidden, res.Code)
+	assert.Equal(t, "forbidden", restErr.Err)
+	assert.Equal(t, 403, restErr.Code)
+}
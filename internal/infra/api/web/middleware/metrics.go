@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/infra/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that did not match any route, so unknown paths
+// cannot grow the number of series.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and latency of every request by route template.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		m.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/rest_err"
+	"github.com/felipemagrassi/pix-api/internal/infra/rate_limiter"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// RateLimit enforces the limiter on each request. A limiter keyed by api key or
+// tenant must run after Authenticate; one keyed by IP runs before it, so requests
+// with invalid credentials are limited too. Store failures let the request through
+// rather than failing the API.
+func RateLimit(limiter *rate_limiter.RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+
+		result, err := limiter.Take(c.Request.Context(), c.Request.Method, route, rate_limiter.Identity(c.Request.Context(), limiter.KeyBy(), c.ClientIP()))
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Error("error taking rate limit token", "error", err)
+			c.Next()
+			return
+		}
+
+		c.Header(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+		c.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		c.Header(RateLimitResetHeader, strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			c.Header(RetryAfterHeader, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			restErr := rest_err.NewTooManyRequestsError("Rate limit exceeded")
+			abortWithError(c, restErr)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
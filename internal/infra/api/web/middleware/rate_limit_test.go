@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/rest_err"
+	"github.com/felipemagrassi/pix-api/internal/infra/rate_limiter"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitRejectsRequestsOverTheLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter, err := rate_limiter.NewRateLimiter(rate_limiter.NewMemoryStore(), rate_limiter.Limit{Requests: 1, Period: time.Minute, Burst: 1}, nil, rate_limiter.KeyByIP)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.Use(RateLimit(limiter))
+	router.GET("/receiver", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/receiver", nil))
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "1", res.Header().Get(RateLimitLimitHeader))
+	assert.Equal(t, "0", res.Header().Get(RateLimitRemainingHeader))
+	assert.Equal(t, "60", res.Header().Get(RateLimitResetHeader))
+
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/receiver", nil))
+	assert.Equal(t, http.StatusTooManyRequests, res.Code)
+	assert.Equal(t, "60", res.Header().Get(RetryAfterHeader))
+
+	var restErr rest_err.RestErr
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &restErr))
+	assert.Equal(t, "too_many_requests", restErr.Err)
+	assert.Equal(t, http.StatusTooManyRequests, restErr.Code)
+}
+
+func TestRateLimitIgnoresForwardedForFromUntrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter, err := rate_limiter.NewRateLimiter(rate_limiter.NewMemoryStore(), rate_limiter.Limit{Requests: 1, Period: time.Minute, Burst: 1}, nil, rate_limiter.KeyByIP)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	assert.NoError(t, router.SetTrustedProxies(nil))
+	router.Use(RateLimit(limiter))
+	router.GET("/receiver", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	codes := make([]int, 0, 2)
+	for _, forwardedFor := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/receiver", nil)
+		req.RemoteAddr = "198.51.100.7:4321"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		codes = append(codes, res.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	RequestIdHeader = "X-Request-ID"
+	RequestIdKey    = "request_id"
+
+	maxRequestIdLength = 128
+)
+
+// RequestID propagates the caller's X-Request-ID, or assigns a new one, echoes it
+// in the response and adds it to the request logger.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(RequestIdHeader)
+		if !isValidRequestId(requestId) {
+			requestId = pkg_entity.NewID().String()
+		}
+
+		c.Set(RequestIdKey, requestId)
+		c.Header(RequestIdHeader, requestId)
+		c.Request = c.Request.WithContext(logger.WithRequestId(c.Request.Context(), requestId))
+
+		c.Next()
+	}
+}
+
+// isValidRequestId only accepts short, printable ASCII ids so a caller cannot
+// inject arbitrary content into the logs.
+func isValidRequestId(requestId string) bool {
+	if requestId == "" || len(requestId) > maxRequestIdLength {
+		return false
+	}
+
+	for i := 0; i < len(requestId); i++ {
+		if requestId[i] < 0x21 || requestId[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
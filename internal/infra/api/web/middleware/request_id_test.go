@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/rest_err"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newLoggingRouter(buf *bytes.Buffer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	log, _ := logger.New(buf, "info", "json")
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(logger.WithLogger(c.Request.Context(), log))
+	})
+	router.Use(RequestID(), RequestLogger(), Recovery())
+	router.GET("/receiver/:receiverId", func(c *gin.Context) {
+		c.Request = c.Request.WithContext(auth.WithTenant(c.Request.Context(), "acme"))
+		logger.FromContext(c.Request.Context()).Info("handler")
+		c.Status(http.StatusOK)
+	})
+	router.GET("/protected", Authenticate(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	return router
+}
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	lines := make([]map[string]any, 0)
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var line map[string]any
+		assert.Nil(t, json.Unmarshal([]byte(raw), &line))
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestRequestIdIsPropagated(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggingRouter(&buf)
+
+	req := httptest.NewRequest(http.MethodGet, "/receiver/123", nil)
+	req.Header.Set(RequestIdHeader, "req-123")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "req-123", res.Header().Get(RequestIdHeader))
+
+	lines := decodeLogLines(t, &buf)
+	assert.Len(t, lines, 2)
+
+	assert.Equal(t, "handler", lines[0]["msg"])
+	assert.Equal(t, "req-123", lines[0]["request_id"])
+	assert.Equal(t, "/receiver/:receiverId", lines[0]["route"])
+
+	assert.Equal(t, "request completed", lines[1]["msg"])
+	assert.Equal(t, "req-123", lines[1]["request_id"])
+	assert.Equal(t, "acme", lines[1]["tenant_id"])
+	assert.Equal(t, float64(http.StatusOK), lines[1]["status"])
+	assert.Contains(t, lines[1], "latency_ms")
+}
+
+func TestRequestLoggerAddsTraceIds(t *testing.T) {
+	var buf bytes.Buffer
+	log, _ := logger.New(&buf, "info", "json")
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(logger.WithLogger(c.Request.Context(), log))
+	})
+	router.Use(otelgin.Middleware("test", otelgin.WithTracerProvider(provider), otelgin.WithPropagators(propagation.TraceContext{})))
+	router.Use(RequestID(), RequestLogger())
+	router.GET("/receiver", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/receiver", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := decodeLogLines(t, &buf)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", lines[0]["trace_id"])
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, lines[0]["span_id"], spans[0].SpanContext().SpanID().String())
+}
+
+func TestRequestIdIsGenerated(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggingRouter(&buf)
+
+	for _, header := range []string{"", "has spaces", strings.Repeat("a", 200)} {
+		req := httptest.NewRequest(http.MethodGet, "/receiver/123", nil)
+		if header != "" {
+			req.Header.Set(RequestIdHeader, header)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+
+		requestId := res.Header().Get(RequestIdHeader)
+		assert.NotEmpty(t, requestId)
+		assert.NotEqual(t, header, requestId)
+	}
+}
+
+func TestRequestIdInErrorBody(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggingRouter(&buf)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set(RequestIdHeader, "req-401")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	var restErr rest_err.RestErr
+	assert.Nil(t, json.Unmarshal(res.Body.Bytes(), &restErr))
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Equal(t, "req-401", restErr.RequestId)
+
+	lines := decodeLogLines(t, &buf)
+	assert.Equal(t, "WARN", lines[len(lines)-1]["level"])
+}
+
+func TestRecoveryAnswersWithRestErr(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggingRouter(&buf)
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(RequestIdHeader, "req-500")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	var restErr rest_err.RestErr
+	assert.Nil(t, json.Unmarshal(res.Body.Bytes(), &restErr))
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.Equal(t, "req-500", restErr.RequestId)
+
+	lines := decodeLogLines(t, &buf)
+	assert.Equal(t, "panic recovered", lines[0]["msg"])
+	assert.Equal(t, "req-500", lines[0]["request_id"])
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/rest_err"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestLogger adds the route and trace ids to the request logger and writes one
+// line per request with its status and latency. It must run after RequestID.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		route := c.FullPath()
+		ctx := logger.With(c.Request.Context(), "method", c.Request.Method, "route", route)
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			ctx = logger.With(ctx, "trace_id", spanContext.TraceID().String(), "span_id", spanContext.SpanID().String())
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		ctx = c.Request.Context()
+		status := c.Writer.Status()
+
+		attrs := []any{
+			"path", c.Request.URL.Path,
+			"status", status,
+			"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"client_ip", c.ClientIP(),
+			"bytes", c.Writer.Size(),
+		}
+		if tenantId, ok := auth.TenantFromContext(ctx); ok {
+			attrs = append(attrs, "tenant_id", tenantId)
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		logger.FromContext(ctx).Log(ctx, level, "request completed", attrs...)
+	}
+}
+
+// Recovery turns a panic into a 500 in the RestErr format and logs it with the
+// request logger.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		logger.FromContext(c.Request.Context()).Error("panic recovered", "panic", recovered)
+		abortWithError(c, rest_err.NewInternalServerError("Internal server error", nil))
+	})
+}
+
+// abortWithError writes restErr tagged with the request id and stops the chain.
+func abortWithError(c *gin.Context, restErr *rest_err.RestErr) {
+	c.AbortWithStatusJSON(restErr.Code, restErr.WithRequestId(logger.RequestIdFromContext(c.Request.Context())))
+}
@@ -0,0 +1,230 @@
+package jwt_authenticator
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+)
+
+// minRefreshInterval bounds how often an unknown kid can force a reload, so
+// tokens with random kids cannot be used to hammer the JWKS source.
+const minRefreshInterval = 30 * time.Second
+
+var ErrKeyNotFound = errors.New("signing key not found")
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// KeySet caches the public keys of a JWKS loaded from a local file or an URL.
+// Keys are reloaded once the cache TTL expires or when a token references a kid
+// that is not cached yet, which is how key rotation is picked up.
+type KeySet struct {
+	source     string
+	ttl        time.Duration
+	httpClient *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	refreshedAt time.Time
+}
+
+func NewKeySet(source string, ttl time.Duration) *KeySet {
+	return &KeySet{
+		source:     source,
+		ttl:        ttl,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		keys:       make(map[string]crypto.PublicKey),
+	}
+}
+
+func (ks *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	ks.mu.RLock()
+	key, found := ks.keys[kid]
+	stale := time.Since(ks.fetchedAt) > ks.ttl
+	canRefresh := time.Since(ks.refreshedAt) > minRefreshInterval
+	ks.mu.RUnlock()
+
+	if found && !stale {
+		return key, nil
+	}
+
+	if stale || canRefresh {
+		if err := ks.Refresh(ctx); err != nil {
+			if found {
+				// Keep serving the cached key while the source is unavailable.
+				return key, nil
+			}
+			return nil, err
+		}
+	}
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, found = ks.keys[kid]
+	if !found {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (ks *KeySet) Refresh(ctx context.Context) error {
+	ks.mu.Lock()
+	ks.refreshedAt = time.Now()
+	ks.mu.Unlock()
+
+	raw, err := ks.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseKeySet(ctx, raw)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.fetchedAt = time.Now()
+	ks.mu.Unlock()
+
+	return nil
+}
+
+func (ks *KeySet) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(ks.source, "http://") && !strings.HasPrefix(ks.source, "https://") {
+		return os.ReadFile(strings.TrimPrefix(ks.source, "file://"))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ks.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching jwks: unexpected status %d", res.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(res.Body, 1<<20))
+}
+
+// parseKeySet keeps the keys that can verify RS256 or ES256 tokens. Other keys
+// are skipped, so a provider publishing e.g. an OKP key next to its signing keys
+// still works; the set only fails to load when no usable key is left.
+func parseKeySet(ctx context.Context, raw []byte) (map[string]crypto.PublicKey, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("parsing jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			logger.FromContext(ctx).Warn("skipping jwk", "kid", jwk.Kid, "kty", jwk.Kty, "error", err)
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("parsing jwks: no usable RS256 or ES256 keys")
+	}
+
+	return keys, nil
+}
+
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	if jwk.Alg != "" && jwk.Alg != "RS256" && jwk.Alg != "ES256" {
+		return nil, fmt.Errorf("unsupported algorithm %s", jwk.Alg)
+	}
+
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		// ES256 is the only EC algorithm accepted, which signs with P-256.
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		curve := elliptic.P256()
+
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on curve")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(decoded), nil
+}
@@ -0,0 +1,174 @@
+package jwt_authenticator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	DefaultTenantClaim = "tenant_id"
+	DefaultRolesClaim  = "roles"
+)
+
+type Config struct {
+	Issuer      string
+	Audience    string
+	Leeway      time.Duration
+	TenantClaim string
+	RolesClaim  string
+	// RoleScopes maps the roles found in the token to the scopes they grant.
+	RoleScopes map[string][]string
+}
+
+// JwtAuthenticator validates RS256/ES256 bearer tokens against a JWKS and maps
+// their claims into an auth.Principal.
+type JwtAuthenticator struct {
+	keySet *KeySet
+	config Config
+	parser *jwt.Parser
+}
+
+func NewJwtAuthenticator(keySet *KeySet, config Config) *JwtAuthenticator {
+	if config.TenantClaim == "" {
+		config.TenantClaim = DefaultTenantClaim
+	}
+
+	if config.RolesClaim == "" {
+		config.RolesClaim = DefaultRolesClaim
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+	}
+
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return &JwtAuthenticator{
+		keySet: keySet,
+		config: config,
+		parser: jwt.NewParser(options...),
+	}
+}
+
+func (a *JwtAuthenticator) Authenticate(ctx context.Context, credentials auth.Credentials) (*auth.Principal, *internal_error.InternalError) {
+	if credentials.BearerToken == "" {
+		return nil, nil
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := a.parser.ParseWithClaims(credentials.BearerToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return a.keySet.Key(ctx, kid)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrKeyNotFound) && !isTokenValidationError(err) {
+			logger.FromContext(ctx).Error("error validating token", "error", err)
+		}
+		return nil, internal_error.NewUnauthorizedError("Invalid token")
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, internal_error.NewUnauthorizedError("Token has no subject")
+	}
+
+	tenantId, _ := claims[a.config.TenantClaim].(string)
+	if tenantId == "" {
+		return nil, internal_error.NewUnauthorizedError("Token has no tenant")
+	}
+
+	roles := stringsClaim(claims[a.config.RolesClaim])
+
+	return &auth.Principal{
+		Subject:  subject,
+		Method:   auth.MethodJwt,
+		TenantId: tenantId,
+		Roles:    roles,
+		Scopes:   a.scopes(claims, roles),
+	}, nil
+}
+
+// scopes merges the scopes granted by the roles with the ones listed in the
+// standard scope claim, ignoring anything that is not a known scope.
+func (a *JwtAuthenticator) scopes(claims jwt.MapClaims, roles []string) []string {
+	granted := make([]string, 0)
+	seen := make(map[string]bool)
+
+	add := func(scope string) {
+		if auth.IsValidScope(scope) && !seen[scope] {
+			seen[scope] = true
+			granted = append(granted, scope)
+		}
+	}
+
+	for _, role := range roles {
+		for _, scope := range a.config.RoleScopes[role] {
+			add(scope)
+		}
+	}
+
+	if scope, ok := claims["scope"].(string); ok {
+		for _, s := range strings.Fields(scope) {
+			add(s)
+		}
+	}
+
+	return granted
+}
+
+// ParseRoleScopes parses role mappings in the "role=scope scope;role=scope" format.
+func ParseRoleScopes(value string) map[string][]string {
+	roleScopes := make(map[string][]string)
+	for _, mapping := range strings.Split(value, ";") {
+		role, scopes, found := strings.Cut(mapping, "=")
+		if !found || strings.TrimSpace(role) == "" {
+			continue
+		}
+
+		roleScopes[strings.TrimSpace(role)] = strings.Fields(scopes)
+	}
+
+	return roleScopes
+}
+
+func stringsClaim(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return []string{}
+	}
+}
+
+func isTokenValidationError(err error) bool {
+	return errors.Is(err, jwt.ErrTokenMalformed) ||
+		errors.Is(err, jwt.ErrTokenExpired) ||
+		errors.Is(err, jwt.ErrTokenNotValidYet) ||
+		errors.Is(err, jwt.ErrTokenInvalidIssuer) ||
+		errors.Is(err, jwt.ErrTokenInvalidAudience) ||
+		errors.Is(err, jwt.ErrTokenRequiredClaimMissing) ||
+		errors.Is(err, jwt.ErrTokenSignatureInvalid)
+}
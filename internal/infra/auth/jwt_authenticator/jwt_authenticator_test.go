@@ -0,0 +1,209 @@
+package jwt_authenticator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testIssuer   = "https://gateway.local"
+	testAudience = "pix-api"
+)
+
+func rsaJwk(kid string, key *rsa.PublicKey) jsonWebKey {
+	return jsonWebKey{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJwk(kid string, key *ecdsa.PublicKey) jsonWebKey {
+	return jsonWebKey{
+		Kty: "EC",
+		Kid: kid,
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func writeJwks(t *testing.T, path string, keys ...jsonWebKey) {
+	raw, err := json.Marshal(jsonWebKeySet{Keys: keys})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(path, raw, 0o600))
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":       "user-1",
+		"iss":       testIssuer,
+		"aud":       testAudience,
+		"exp":       time.Now().Add(time.Hour).Unix(),
+		"tenant_id": "acme",
+		"roles":     []string{"operator"},
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func newTestAuthenticator(source string) *JwtAuthenticator {
+	return NewJwtAuthenticator(NewKeySet(source, time.Minute), Config{
+		Issuer:     testIssuer,
+		Audience:   testAudience,
+		RoleScopes: ParseRoleScopes("operator=receivers:read receivers:write;admin=receivers:read receivers:write receivers:delete"),
+	})
+}
+
+func TestAuthenticateValidTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJwks(t, path, rsaJwk("rsa-1", &rsaKey.PublicKey), ecJwk("ec-1", &ecKey.PublicKey))
+	authenticator := newTestAuthenticator(path)
+
+	tokens := map[string]string{
+		"RS256": sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims()),
+		"ES256": sign(t, jwt.SigningMethodES256, "ec-1", ecKey, validClaims()),
+	}
+
+	for name, token := range tokens {
+		t.Run(name, func(t *testing.T) {
+			principal, authErr := authenticator.Authenticate(context.Background(), auth.Credentials{BearerToken: token})
+			assert.Nil(t, authErr)
+			assert.Equal(t, "user-1", principal.Subject)
+			assert.Equal(t, "acme", principal.TenantId)
+			assert.Equal(t, auth.MethodJwt, principal.Method)
+			assert.Equal(t, []string{"operator"}, principal.Roles)
+			assert.Equal(t, []string{auth.ScopeReceiversRead, auth.ScopeReceiversWrite}, principal.Scopes)
+		})
+	}
+}
+
+func TestAuthenticateRejectsInvalidTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJwks(t, path, rsaJwk("rsa-1", &rsaKey.PublicKey))
+	authenticator := newTestAuthenticator(path)
+
+	withClaim := func(key string, value interface{}) jwt.MapClaims {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tokens := map[string]string{
+		"expired":         sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, withClaim("exp", time.Now().Add(-time.Hour).Unix())),
+		"without expiry":  sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, withClaim("exp", nil)),
+		"wrong issuer":    sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, withClaim("iss", "https://evil.local")),
+		"wrong audience":  sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, withClaim("aud", "other-api")),
+		"without tenant":  sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, withClaim("tenant_id", nil)),
+		"unknown kid":     sign(t, jwt.SigningMethodRS256, "rsa-2", rsaKey, validClaims()),
+		"wrong signature": sign(t, jwt.SigningMethodRS256, "rsa-1", otherKey, validClaims()),
+		"hmac":            sign(t, jwt.SigningMethodHS256, "rsa-1", []byte("secret"), validClaims()),
+		"malformed":       "not.a.token",
+	}
+
+	for name, token := range tokens {
+		t.Run(name, func(t *testing.T) {
+			principal, authErr := authenticator.Authenticate(context.Background(), auth.Credentials{BearerToken: token})
+			assert.Nil(t, principal)
+			assert.NotNil(t, authErr)
+			assert.Equal(t, "unauthorized", authErr.Err)
+		})
+	}
+}
+
+func TestAuthenticateIgnoresOtherCredentials(t *testing.T) {
+	authenticator := newTestAuthenticator(filepath.Join(t.TempDir(), "missing.json"))
+
+	principal, authErr := authenticator.Authenticate(context.Background(), auth.Credentials{ApiKey: "pix_key"})
+	assert.Nil(t, principal)
+	assert.Nil(t, authErr)
+}
+
+func TestKeySetPicksUpRotatedKeysFromURL(t *testing.T) {
+	firstKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rotatedKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	keys := []jsonWebKey{rsaJwk("rsa-1", &firstKey.PublicKey)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: keys})
+	}))
+	defer server.Close()
+
+	authenticator := newTestAuthenticator(server.URL)
+
+	principal, authErr := authenticator.Authenticate(context.Background(), auth.Credentials{BearerToken: sign(t, jwt.SigningMethodRS256, "rsa-1", firstKey, validClaims())})
+	assert.Nil(t, authErr)
+	assert.NotNil(t, principal)
+
+	keys = append(keys, rsaJwk("rsa-2", &rotatedKey.PublicKey))
+	authenticator.keySet.refreshedAt = time.Time{}
+
+	principal, authErr = authenticator.Authenticate(context.Background(), auth.Credentials{BearerToken: sign(t, jwt.SigningMethodRS256, "rsa-2", rotatedKey, validClaims())})
+	assert.Nil(t, authErr)
+	assert.NotNil(t, principal)
+}
+
+func TestKeySetSkipsUnsupportedKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	unsupported := []jsonWebKey{
+		{Kty: "oct", Kid: "hmac-1", Use: "sig"},
+		{Kty: "OKP", Kid: "ed-1", Use: "sig", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+		{Kty: "EC", Kid: "ec-384", Use: "sig", Crv: "P-384"},
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJwks(t, path, unsupported...)
+	keySet := NewKeySet(path, time.Minute)
+	assert.ErrorContains(t, keySet.Refresh(context.Background()), "no usable RS256 or ES256 keys")
+
+	writeJwks(t, path, append(unsupported, rsaJwk("rsa-1", &rsaKey.PublicKey))...)
+	authenticator := newTestAuthenticator(path)
+
+	principal, authErr := authenticator.Authenticate(context.Background(), auth.Credentials{BearerToken: sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims())})
+	assert.Nil(t, authErr)
+	assert.NotNil(t, principal)
+	assert.Len(t, authenticator.keySet.keys, 1)
+}
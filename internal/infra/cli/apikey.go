@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/usecase/api_key_usecase"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+)
+
+const apiKeyUsage = `usage: %s apikey <command> [flags]
+
+commands:
+  create --name NAME [--tenant TENANT] --scopes receivers:read,receivers:write
+  list
+  revoke --id API_KEY_ID
+
+every command accepts --output table|json (default %s)`
+
+// RunApiKey manages api keys from the command line, so keys are only ever issued
+// by operators with access to the database.
+func RunApiKey(ctx context.Context, w io.Writer, program, defaultOutput string, apiKeyUseCase api_key_usecase.ApiKeyUseCaseInterface, args []string) error {
+	usage := fmt.Errorf(apiKeyUsage, program, defaultOutput)
+	if len(args) == 0 {
+		return usage
+	}
+
+	flags := flag.NewFlagSet("apikey "+args[0], flag.ContinueOnError)
+	output := flags.String("output", defaultOutput, "output format, table or json")
+
+	switch args[0] {
+	case "create":
+		name := flags.String("name", "", "api key name")
+		tenant := flags.String("tenant", auth.DefaultTenant, "tenant the api key belongs to")
+		scopes := flags.String("scopes", "", "comma separated list of scopes")
+		out, err := ParseFlags(flags, args[1:], w, output)
+		if err != nil {
+			return err
+		}
+
+		created, createErr := apiKeyUseCase.CreateApiKey(ctx, api_key_usecase.CreateApiKeyInput{
+			Name:     *name,
+			TenantId: *tenant,
+			Scopes:   SplitList(*scopes),
+		})
+		if createErr != nil {
+			return Error(createErr)
+		}
+
+		return out.Print(created, []string{"API KEY ID", "TENANT", "NAME", "SCOPES", "KEY"}, [][]string{{
+			created.ApiKeyId, created.TenantId, created.Name, strings.Join(created.Scopes, ","), created.Key,
+		}})
+	case "list":
+		out, err := ParseFlags(flags, args[1:], w, output)
+		if err != nil {
+			return err
+		}
+
+		apiKeys, findErr := apiKeyUseCase.FindApiKeys(ctx)
+		if findErr != nil {
+			return Error(findErr)
+		}
+
+		rows := make([][]string, 0, len(apiKeys))
+		for _, apiKey := range apiKeys {
+			rows = append(rows, []string{apiKey.ApiKeyId, apiKey.TenantId, apiKey.Name, apiKey.Prefix, strings.Join(apiKey.Scopes, ","), apiKey.CreatedAt, apiKey.RevokedAt})
+		}
+
+		return out.Print(apiKeys, []string{"API KEY ID", "TENANT", "NAME", "PREFIX", "SCOPES", "CREATED AT", "REVOKED AT"}, rows)
+	case "revoke":
+		id := flags.String("id", "", "api key id")
+		out, err := ParseFlags(flags, args[1:], w, output)
+		if err != nil {
+			return err
+		}
+
+		apiKeyId, parseErr := pkg_entity.ParseID(*id)
+		if parseErr != nil {
+			return fmt.Errorf("invalid api key id: %w", parseErr)
+		}
+
+		if revokeErr := apiKeyUseCase.RevokeApiKey(ctx, apiKeyId); revokeErr != nil {
+			return Error(revokeErr)
+		}
+
+		return out.Message("api key %s revoked", apiKeyId)
+	default:
+		return usage
+	}
+}
+
+// ParseFlags parses args and builds the output selected by the output flag.
+func ParseFlags(flags *flag.FlagSet, args []string, w io.Writer, output *string) (*Output, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return NewOutput(w, *output)
+}
+
+// SplitList splits a comma separated list, dropping blank entries.
+func SplitList(list string) []string {
+	result := make([]string, 0)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
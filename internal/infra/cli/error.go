@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+)
+
+// Error turns a use case error into a message listing its causes, e.g.
+// "Invalid Receiver (pix_key: Pix Key is required)".
+func Error(err *internal_error.InternalError) error {
+	if len(err.Causes) == 0 {
+		return errors.New(err.Message)
+	}
+
+	causes := make([]string, 0, len(err.Causes))
+	for _, cause := range err.Causes {
+		causes = append(causes, cause.Field+": "+cause.Message)
+	}
+
+	return errors.New(err.Message + " (" + strings.Join(causes, ", ") + ")")
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/felipemagrassi/pix-api/internal/infra/database/storage"
+	"github.com/golang-migrate/migrate/v4"
+)
+
+const migrateUsage = `usage: %s migrate <command>
+
+commands:
+  up           apply every pending migration
+  down N       revert the last N migrations
+  goto V       migrate up or down to version V
+  version      print the current version
+  force V      set the version to V without migrating and clear the dirty flag`
+
+// RunMigrate runs the embedded migrations, so deploys can migrate in a separate
+// step with DB_AUTO_MIGRATE=false on the api replicas.
+func RunMigrate(ctx context.Context, w io.Writer, program string, migrator storage.Migrator, args []string) error {
+	usage := fmt.Errorf(migrateUsage, program)
+	if len(args) == 0 {
+		return usage
+	}
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(ctx); err != nil {
+			return err
+		}
+	case "down":
+		steps, err := migrateArgument(args, usage)
+		if err != nil {
+			return err
+		}
+
+		if err := migrator.Down(ctx, steps); err != nil {
+			return err
+		}
+	case "goto":
+		version, err := migrateArgument(args, usage)
+		if err != nil {
+			return err
+		}
+		if version < 0 {
+			return errors.New("version must not be negative")
+		}
+
+		if err := migrator.Goto(ctx, uint(version)); err != nil {
+			return err
+		}
+	case "force":
+		version, err := migrateArgument(args, usage)
+		if err != nil {
+			return err
+		}
+
+		if err := migrator.Force(ctx, version); err != nil {
+			return err
+		}
+	case "version":
+	default:
+		return usage
+	}
+
+	version, dirty, err := migrator.Version(ctx)
+	if errors.Is(err, migrate.ErrNilVersion) {
+		fmt.Fprintln(w, "no migration applied")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		fmt.Fprintf(w, "version %d (dirty)\n", version)
+		return nil
+	}
+
+	fmt.Fprintf(w, "version %d\n", version)
+	return nil
+}
+
+func migrateArgument(args []string, usage error) (int, error) {
+	if len(args) != 2 {
+		return 0, usage
+	}
+
+	value, err := strconv.Atoi(args[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s argument %q: %w", args[0], args[1], err)
+	}
+
+	return value, nil
+}
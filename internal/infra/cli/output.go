@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+// Output writes command results either as an aligned table for people or as
+// indented JSON for scripts.
+type Output struct {
+	w      io.Writer
+	format string
+}
+
+func NewOutput(w io.Writer, format string) (*Output, error) {
+	if format != OutputTable && format != OutputJSON {
+		return nil, fmt.Errorf("invalid output %q: must be %s or %s", format, OutputTable, OutputJSON)
+	}
+
+	return &Output{w: w, format: format}, nil
+}
+
+// Print writes value as JSON, or header and rows as a table.
+func (o *Output) Print(value any, header []string, rows [][]string) error {
+	if o.format == OutputJSON {
+		encoder := json.NewEncoder(o.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	writer := tabwriter.NewWriter(o.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	return writer.Flush()
+}
+
+// Message writes a confirmation, as {"message": ...} in JSON.
+func (o *Output) Message(format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	if o.format == OutputJSON {
+		return o.Print(map[string]string{"message": message}, nil, nil)
+	}
+
+	_, err := fmt.Fprintln(o.w, message)
+	return err
+}
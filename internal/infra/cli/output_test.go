@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputTable(t *testing.T) {
+	var buffer bytes.Buffer
+	out, err := NewOutput(&buffer, OutputTable)
+	assert.Nil(t, err)
+
+	assert.Nil(t, out.Print(nil, []string{"ID", "NAME"}, [][]string{{"1", "Felipe"}, {"22", "Ana"}}))
+	assert.Equal(t, "ID  NAME\n1   Felipe\n22  Ana\n", buffer.String())
+}
+
+func TestOutputJSON(t *testing.T) {
+	var buffer bytes.Buffer
+	out, err := NewOutput(&buffer, OutputJSON)
+	assert.Nil(t, err)
+
+	assert.Nil(t, out.Print(map[string]int{"created": 2}, []string{"CREATED"}, [][]string{{"2"}}))
+	assert.Nil(t, out.Message("receiver %s validated", "1"))
+	assert.Equal(t, "{\n  \"created\": 2\n}\n{\n  \"message\": \"receiver 1 validated\"\n}\n", buffer.String())
+
+	_, err = NewOutput(&buffer, "yaml")
+	assert.ErrorContains(t, err, `invalid output "yaml"`)
+}
+
+func TestError(t *testing.T) {
+	err := Error(internal_error.NewBadRequestError("Invalid Receiver", internal_error.Causes{Field: "pix_key", Message: "Pix Key is required"}))
+	assert.EqualError(t, err, "Invalid Receiver (pix_key: Pix Key is required)")
+
+	assert.EqualError(t, Error(internal_error.NewNotFoundError("receiver not found")), "receiver not found")
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/felipemagrassi/pix-api/internal/infra/database/storage"
+)
+
+const reencryptUsage = `usage: %s reencrypt [--all]
+
+encrypts the receivers and claims still in plaintext and re-encrypts those whose
+data key was wrapped by a previous master key; --all rewrites every receiver and
+claim, to rebuild the blind indexes after changing ENCRYPTION_INDEX_KEY`
+
+// RunReencrypt rewrites the receivers and claims with the current encryption keys.
+// It can run while the api serves requests and be repeated after an interruption.
+func RunReencrypt(ctx context.Context, w io.Writer, program string, reencrypter storage.Reencrypter, args []string) error {
+	flags := flag.NewFlagSet("reencrypt", flag.ContinueOnError)
+	all := flags.Bool("all", false, "rewrite every receiver and claim")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf(reencryptUsage, program)
+	}
+
+	rewritten, err := reencrypter.ReencryptReceivers(ctx, *all)
+	fmt.Fprintf(w, "%d receivers re-encrypted\n", rewritten)
+	if err != nil {
+		return Error(err)
+	}
+
+	rewritten, err = reencrypter.ReencryptClaims(ctx, *all)
+	fmt.Fprintf(w, "%d claims re-encrypted\n", rewritten)
+	if err != nil {
+		return Error(err)
+	}
+
+	return nil
+}
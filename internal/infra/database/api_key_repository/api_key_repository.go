@@ -0,0 +1,109 @@
+package api_key_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type ApiKeyEntity struct {
+	ApiKeyId  pkg_entity.ID  `db:"api_key_id"`
+	TenantId  string         `db:"tenant_id"`
+	Name      string         `db:"name"`
+	Prefix    string         `db:"prefix"`
+	KeyHash   string         `db:"key_hash"`
+	Scopes    string         `db:"scopes"`
+	CreatedAt string         `db:"created_at"`
+	RevokedAt sql.NullString `db:"revoked_at"`
+}
+
+type ApiKeyRepository struct {
+	Db *sqlx.DB
+}
+
+func NewApiKeyRepository(db *sqlx.DB) *ApiKeyRepository {
+	return &ApiKeyRepository{Db: db}
+}
+
+func (r *ApiKeyRepository) FindApiKeyByHash(ctx context.Context, keyHash string) (*entity.ApiKey, *internal_error.InternalError) {
+	var apiKey ApiKeyEntity
+	err := r.Db.GetContext(ctx, &apiKey, "SELECT * FROM api_keys WHERE key_hash = $1", keyHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError("api key not found")
+		}
+		logger.FromContext(ctx).Error("error finding api key", "error", err)
+		return nil, internal_error.NewInternalServerError("error finding api key", err)
+	}
+
+	result := mapApiKeyEntityToApiKey(apiKey)
+	return &result, nil
+}
+
+func (r *ApiKeyRepository) FindApiKeys(ctx context.Context) ([]entity.ApiKey, *internal_error.InternalError) {
+	var apiKeyEntities []ApiKeyEntity
+	err := r.Db.SelectContext(ctx, &apiKeyEntities, "SELECT * FROM api_keys ORDER BY created_at DESC")
+	if err != nil {
+		logger.FromContext(ctx).Error("error finding api keys", "error", err)
+		return nil, internal_error.NewInternalServerError("error finding api keys", err)
+	}
+
+	apiKeys := make([]entity.ApiKey, 0, len(apiKeyEntities))
+	for _, apiKey := range apiKeyEntities {
+		apiKeys = append(apiKeys, mapApiKeyEntityToApiKey(apiKey))
+	}
+
+	return apiKeys, nil
+}
+
+func (r *ApiKeyRepository) CreateApiKey(ctx context.Context, apiKey *entity.ApiKey) *internal_error.InternalError {
+	_, err := r.Db.ExecContext(ctx, "INSERT INTO api_keys (api_key_id, tenant_id, name, prefix, key_hash, scopes, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)", apiKey.ApiKeyId, apiKey.TenantId, apiKey.Name, apiKey.Prefix, apiKey.KeyHash, apiKey.ScopesString(), apiKey.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("error creating api key", "error", err)
+		return internal_error.NewInternalServerError("error creating api key", err)
+	}
+
+	return nil
+}
+
+func (r *ApiKeyRepository) RevokeApiKey(ctx context.Context, id pkg_entity.ID) *internal_error.InternalError {
+	res, err := r.Db.ExecContext(ctx, "UPDATE api_keys SET revoked_at = $1 WHERE api_key_id = $2 AND revoked_at IS NULL", time.Now(), id)
+	if err != nil {
+		logger.FromContext(ctx).Error("error revoking api key", "error", err)
+		return internal_error.NewInternalServerError("error revoking api key", err)
+	}
+
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		return internal_error.NewNotFoundError("api key not found")
+	}
+
+	return nil
+}
+
+func mapApiKeyEntityToApiKey(apiKeyEntity ApiKeyEntity) entity.ApiKey {
+	createdAt, _ := time.Parse(time.RFC3339, apiKeyEntity.CreatedAt)
+
+	apiKey := entity.ApiKey{
+		ApiKeyId:  apiKeyEntity.ApiKeyId,
+		TenantId:  apiKeyEntity.TenantId,
+		Name:      apiKeyEntity.Name,
+		Prefix:    apiKeyEntity.Prefix,
+		KeyHash:   apiKeyEntity.KeyHash,
+		Scopes:    entity.ParseScopes(apiKeyEntity.Scopes),
+		CreatedAt: createdAt,
+	}
+
+	if apiKeyEntity.RevokedAt.Valid {
+		revokedAt, _ := time.Parse(time.RFC3339, apiKeyEntity.RevokedAt.String)
+		apiKey.RevokedAt = &revokedAt
+	}
+
+	return apiKey
+}
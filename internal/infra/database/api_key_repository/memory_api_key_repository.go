@@ -0,0 +1,114 @@
+package api_key_repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/snapshot"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+)
+
+// MemoryApiKeyRepository keeps api keys in the process memory, with the same not
+// found errors as ApiKeyRepository and an optional JSON snapshot.
+type MemoryApiKeyRepository struct {
+	mu      sync.RWMutex
+	apiKeys map[pkg_entity.ID]entity.ApiKey
+}
+
+func NewMemoryApiKeyRepository() *MemoryApiKeyRepository {
+	return &MemoryApiKeyRepository{apiKeys: make(map[pkg_entity.ID]entity.ApiKey)}
+}
+
+func (r *MemoryApiKeyRepository) FindApiKeyByHash(ctx context.Context, keyHash string) (*entity.ApiKey, *internal_error.InternalError) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, apiKey := range r.apiKeys {
+		if apiKey.KeyHash == keyHash {
+			return &apiKey, nil
+		}
+	}
+
+	return nil, internal_error.NewNotFoundError("api key not found")
+}
+
+func (r *MemoryApiKeyRepository) FindApiKeys(ctx context.Context) ([]entity.ApiKey, *internal_error.InternalError) {
+	return r.sortedApiKeys(), nil
+}
+
+func (r *MemoryApiKeyRepository) CreateApiKey(ctx context.Context, apiKey *entity.ApiKey) *internal_error.InternalError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.apiKeys {
+		if existing.ApiKeyId == apiKey.ApiKeyId || existing.KeyHash == apiKey.KeyHash {
+			return internal_error.NewInternalServerError("error creating api key", errors.New("duplicate api key"))
+		}
+	}
+
+	r.apiKeys[apiKey.ApiKeyId] = *apiKey
+
+	return nil
+}
+
+func (r *MemoryApiKeyRepository) RevokeApiKey(ctx context.Context, id pkg_entity.ID) *internal_error.InternalError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	apiKey, found := r.apiKeys[id]
+	if !found || apiKey.RevokedAt != nil {
+		return internal_error.NewNotFoundError("api key not found")
+	}
+
+	revokedAt := time.Now()
+	apiKey.RevokedAt = &revokedAt
+	r.apiKeys[id] = apiKey
+
+	return nil
+}
+
+// SaveSnapshot writes every api key, including its hash, to path as JSON.
+func (r *MemoryApiKeyRepository) SaveSnapshot(path string) error {
+	return snapshot.Save(path, r.sortedApiKeys())
+}
+
+// LoadSnapshot replaces the api keys with the ones saved at path, if any.
+func (r *MemoryApiKeyRepository) LoadSnapshot(path string) error {
+	var apiKeys []entity.ApiKey
+	found, err := snapshot.Load(path, &apiKeys)
+	if err != nil || !found {
+		return err
+	}
+
+	loaded := make(map[pkg_entity.ID]entity.ApiKey, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		loaded[apiKey.ApiKeyId] = apiKey
+	}
+
+	r.mu.Lock()
+	r.apiKeys = loaded
+	r.mu.Unlock()
+
+	return nil
+}
+
+// sortedApiKeys returns the api keys newest first, as ApiKeyRepository lists them.
+func (r *MemoryApiKeyRepository) sortedApiKeys() []entity.ApiKey {
+	r.mu.RLock()
+	apiKeys := make([]entity.ApiKey, 0, len(r.apiKeys))
+	for _, apiKey := range r.apiKeys {
+		apiKeys = append(apiKeys, apiKey)
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(apiKeys, func(i, j int) bool {
+		return apiKeys[i].CreatedAt.After(apiKeys[j].CreatedAt)
+	})
+
+	return apiKeys
+}
@@ -0,0 +1,150 @@
+package claim_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/encryption"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+)
+
+// pixKeyCanonicalIndexPurpose is the purpose of the blind index stored in
+// pix_key_canonical, see encryption.Keyring.BlindIndex.
+const pixKeyCanonicalIndexPurpose = "claims.pix_key_canonical"
+
+const reencryptBatchSize = 500
+
+// reencryptStartId sorts before every claim id, as a uuid and as text.
+const reencryptStartId = "00000000-0000-0000-0000-000000000000"
+
+// sealClaimEntity encrypts the pix key of a plaintext claim entity with a new data
+// key and replaces its canonical form by a blind index, so the unique index on the
+// active claims of a key still applies. Without a keyring the entity is left in
+// plaintext.
+func sealClaimEntity(keyring *encryption.Keyring, claimEntity *ClaimEntity) error {
+	if keyring == nil {
+		return nil
+	}
+
+	dataKey, wrappedKey, err := keyring.NewDataKey()
+	if err != nil {
+		return err
+	}
+
+	pixKey, err := dataKey.Encrypt(claimEntity.PixKey, claimEntity.ClaimId.String()+":pix_key")
+	if err != nil {
+		return err
+	}
+
+	claimEntity.DataKey = wrappedKey
+	claimEntity.PixKey = pixKey
+	claimEntity.PixKeyCanonical = keyring.BlindIndex(pixKeyCanonicalIndexPurpose, claimEntity.PixKeyCanonical)
+
+	return nil
+}
+
+// openClaimEntity decrypts a claim entity sealed by sealClaimEntity and recomputes
+// its canonical pix key. Entities without a data key are already in plaintext.
+func openClaimEntity(keyring *encryption.Keyring, claimEntity *ClaimEntity) error {
+	if claimEntity.DataKey == "" {
+		return nil
+	}
+	if keyring == nil {
+		return errors.New("claim is encrypted and encryption is not configured")
+	}
+
+	dataKey, err := keyring.UnwrapDataKey(claimEntity.DataKey)
+	if err != nil {
+		return err
+	}
+
+	if claimEntity.PixKey, err = dataKey.Decrypt(claimEntity.PixKey, claimEntity.ClaimId.String()+":pix_key"); err != nil {
+		return fmt.Errorf("decrypting pix key: %w", err)
+	}
+
+	claimEntity.PixKeyCanonical = ""
+	if pixKeyType, typeErr := entity.NewPixKeyType(entity.PixKeyType(claimEntity.PixKeyType)); typeErr == nil {
+		claimEntity.PixKeyCanonical = pixKeyType.Canonical(claimEntity.PixKey)
+	}
+
+	return nil
+}
+
+// mapClaimEntity decrypts the entity when it is encrypted and maps it to a claim.
+func (r *ClaimRepository) mapClaimEntity(ctx context.Context, claimEntity ClaimEntity) (entity.Claim, *internal_error.InternalError) {
+	if err := openClaimEntity(r.Keyring, &claimEntity); err != nil {
+		logger.FromContext(ctx).Error("error decrypting claim", "claim_id", claimEntity.ClaimId.String(), "error", err)
+		return entity.Claim{}, internal_error.NewInternalServerError("error decrypting claim", err)
+	}
+
+	return mapClaimEntityToClaim(claimEntity), nil
+}
+
+func (r *ClaimRepository) mapClaimEntities(ctx context.Context, claimEntities []ClaimEntity) ([]entity.Claim, *internal_error.InternalError) {
+	claims := make([]entity.Claim, 0, len(claimEntities))
+	for _, claimEntity := range claimEntities {
+		claim, err := r.mapClaimEntity(ctx, claimEntity)
+		if err != nil {
+			return nil, err
+		}
+		claims = append(claims, claim)
+	}
+
+	return claims, nil
+}
+
+// ReencryptClaims encrypts the claims in plaintext and re-encrypts those of a
+// previous master key, or every claim when all is set, across tenants, as
+// ReceiverRepository.ReencryptReceivers does for receivers. With row level
+// security it must run as the table owner too.
+func (r *ClaimRepository) ReencryptClaims(ctx context.Context, all bool) (int, *internal_error.InternalError) {
+	if r.Keyring == nil {
+		return 0, internal_error.NewBadRequestError("encryption is not configured")
+	}
+
+	selectQuery := "SELECT * FROM claims WHERE claim_id > $1 ORDER BY claim_id LIMIT $2"
+	updateQuery := "UPDATE claims SET pix_key = $1, pix_key_canonical = $2, data_key = $3 WHERE claim_id = $4 AND data_key = $5"
+
+	rewritten := 0
+	lastId := reencryptStartId
+	for {
+		var batch []ClaimEntity
+		if err := r.Db.SelectContext(ctx, &batch, selectQuery, lastId, reencryptBatchSize); err != nil {
+			logger.FromContext(ctx).Error("error reading claims to re-encrypt", "error", err)
+			return rewritten, internal_error.NewInternalServerError("error re-encrypting claims", err)
+		}
+
+		for _, claimEntity := range batch {
+			lastId = claimEntity.ClaimId.String()
+			if !all && r.Keyring.IsCurrent(claimEntity.DataKey) {
+				continue
+			}
+
+			previousDataKey := claimEntity.DataKey
+			if err := openClaimEntity(r.Keyring, &claimEntity); err != nil {
+				logger.FromContext(ctx).Error("error decrypting claim", "claim_id", lastId, "error", err)
+				return rewritten, internal_error.NewInternalServerError("error re-encrypting claims", err)
+			}
+			if err := sealClaimEntity(r.Keyring, &claimEntity); err != nil {
+				return rewritten, internal_error.NewInternalServerError("error re-encrypting claims", err)
+			}
+
+			res, err := r.Db.ExecContext(ctx, updateQuery, claimEntity.PixKey, claimEntity.PixKeyCanonical, claimEntity.DataKey, claimEntity.ClaimId, previousDataKey)
+			if err != nil {
+				logger.FromContext(ctx).Error("error re-encrypting claim", "claim_id", lastId, "error", err)
+				return rewritten, internal_error.NewInternalServerError("error re-encrypting claims", err)
+			}
+
+			if rowsAffected, _ := res.RowsAffected(); rowsAffected > 0 {
+				rewritten++
+			}
+		}
+
+		if len(batch) < reencryptBatchSize {
+			return rewritten, nil
+		}
+	}
+}
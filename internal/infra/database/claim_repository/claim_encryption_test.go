@@ -0,0 +1,119 @@
+package claim_repository_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/configuration/database/sqlite"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/claim_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/encryption"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newKeyring returns a keyring whose master keys are filled with the given bytes,
+// the first one current.
+func newKeyring(t *testing.T, masterKeys ...byte) *encryption.Keyring {
+	keys := make([][]byte, 0, len(masterKeys))
+	for _, b := range masterKeys {
+		keys = append(keys, bytes.Repeat([]byte{b}, encryption.KeySize))
+	}
+
+	keyring, err := encryption.NewKeyring(keys, bytes.Repeat([]byte{9}, encryption.KeySize))
+	require.NoError(t, err)
+	return keyring
+}
+
+type storedClaim struct {
+	PixKey          string `db:"pix_key"`
+	PixKeyCanonical string `db:"pix_key_canonical"`
+	DataKey         string `db:"data_key"`
+}
+
+func openEncryptionTestDatabase(t *testing.T) *sqlx.DB {
+	db, err := sqlite.InitializeDatabase(context.Background(), filepath.Join(t.TempDir(), "pix.db"), true)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func findStoredClaim(t *testing.T, db *sqlx.DB, claimId string) storedClaim {
+	var stored storedClaim
+	require.NoError(t, db.Get(&stored, "SELECT pix_key, pix_key_canonical, data_key FROM claims WHERE claim_id = $1", claimId))
+	return stored
+}
+
+func TestEncryptedClaimIsStoredAsCiphertext(t *testing.T) {
+	db := openEncryptionTestDatabase(t)
+	ctx := auth.WithTenant(context.Background(), auth.DefaultTenant)
+
+	repo := claim_repository.NewSQLiteClaimRepository(db)
+	repo.Keyring = newKeyring(t, 1)
+	claim := newClaim(t, "joao@example.com", baseTime)
+	require.Nil(t, repo.CreateClaim(ctx, claim))
+
+	stored := findStoredClaim(t, db, claim.ClaimId.String())
+	assert.NotEmpty(t, stored.DataKey)
+	assert.NotContains(t, stored.PixKey, "joao")
+	assert.NotContains(t, stored.PixKeyCanonical, "joao")
+
+	found, err := repo.FindClaim(ctx, claim.ClaimId)
+	require.Nil(t, err)
+	assert.Equal(t, "joao@example.com", found.PixKey.KeyValue)
+
+	err = repo.CreateClaim(ctx, newClaim(t, "joao@example.com", baseTime))
+	require.NotNil(t, err)
+	assert.Equal(t, "conflict", err.Err)
+
+	_, err = claim_repository.NewSQLiteClaimRepository(db).FindClaim(ctx, claim.ClaimId)
+	require.NotNil(t, err)
+	assert.Equal(t, "internal_server_error", err.Err)
+}
+
+func TestReencryptClaims(t *testing.T) {
+	db := openEncryptionTestDatabase(t)
+	ctx := auth.WithTenant(context.Background(), auth.DefaultTenant)
+
+	claim := newClaim(t, "joao@example.com", baseTime)
+	require.Nil(t, claim_repository.NewSQLiteClaimRepository(db).CreateClaim(ctx, claim))
+	assert.Equal(t, "joao@example.com", findStoredClaim(t, db, claim.ClaimId.String()).PixKey)
+
+	repo := claim_repository.NewSQLiteClaimRepository(db)
+	repo.Keyring = newKeyring(t, 1)
+
+	err := repo.CreateClaim(ctx, newClaim(t, "joao@example.com", baseTime))
+	require.NotNil(t, err)
+	assert.Equal(t, "conflict", err.Err)
+
+	rewritten, err := repo.ReencryptClaims(ctx, false)
+	require.Nil(t, err)
+	assert.Equal(t, 1, rewritten)
+	assert.NotEqual(t, "joao@example.com", findStoredClaim(t, db, claim.ClaimId.String()).PixKey)
+
+	err = repo.CreateClaim(ctx, newClaim(t, "joao@example.com", baseTime))
+	require.NotNil(t, err)
+	assert.Equal(t, "conflict", err.Err)
+
+	rewritten, err = repo.ReencryptClaims(ctx, false)
+	require.Nil(t, err)
+	assert.Equal(t, 0, rewritten)
+
+	rotatedRepo := claim_repository.NewSQLiteClaimRepository(db)
+	rotatedRepo.Keyring = newKeyring(t, 2, 1)
+	rewritten, err = rotatedRepo.ReencryptClaims(ctx, false)
+	require.Nil(t, err)
+	assert.Equal(t, 1, rewritten)
+
+	withoutOldKey := claim_repository.NewSQLiteClaimRepository(db)
+	withoutOldKey.Keyring = newKeyring(t, 2)
+	claims, err := withoutOldKey.FindClaims(ctx, entity.ClaimOpen, nil, 1)
+	require.Nil(t, err)
+	require.Len(t, claims, 1)
+	assert.Equal(t, "joao@example.com", claims[0].PixKey.KeyValue)
+}
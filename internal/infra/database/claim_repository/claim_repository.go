@@ -0,0 +1,351 @@
+package claim_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/transaction"
+	"github.com/felipemagrassi/pix-api/internal/infra/encryption"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// ClaimEntity is a claims row. With encryption enabled PixKey holds ciphertext,
+// sealed with the wrapped DataKey, and PixKeyCanonical a blind index.
+type ClaimEntity struct {
+	ClaimId            pkg_entity.ID `db:"claim_id" json:"claim_id"`
+	TenantId           string        `db:"tenant_id" json:"tenant_id"`
+	Type               int           `db:"type" json:"type"`
+	Status             int           `db:"status" json:"status"`
+	PixKey             string        `db:"pix_key" json:"pix_key"`
+	PixKeyType         int           `db:"pix_key_type" json:"pix_key_type"`
+	PixKeyCanonical    string        `db:"pix_key_canonical" json:"pix_key_canonical"`
+	ClaimerReceiverId  pkg_entity.ID `db:"claimer_receiver_id" json:"claimer_receiver_id"`
+	DonorReceiverId    pkg_entity.ID `db:"donor_receiver_id" json:"donor_receiver_id"`
+	CancelReason       string        `db:"cancel_reason" json:"cancel_reason"`
+	ResolutionDeadline string        `db:"resolution_deadline" json:"resolution_deadline"`
+	CompletionDeadline *string       `db:"completion_deadline" json:"completion_deadline,omitempty"`
+	DataKey            string        `db:"data_key" json:"-"`
+	CreatedAt          string        `db:"created_at" json:"created_at"`
+	UpdatedAt          string        `db:"updated_at" json:"updated_at"`
+}
+
+// activeClaimIndex is the unique index on the canonical pix key of the active
+// claims of each tenant.
+const activeClaimIndex = "claims_tenant_id_pix_key_canonical_active_idx"
+
+// claimsPageSize is the number of claims returned per page by every backend.
+const claimsPageSize = 10
+
+// sqliteTimeLayout matches the layout of the sqlite receiver repository, so times
+// stored as text compare in chronological order.
+const sqliteTimeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// ClaimRepository stores claims in postgres or sqlite.
+type ClaimRepository struct {
+	Db *sqlx.DB
+	// Keyring encrypts the pix keys when set.
+	Keyring *encryption.Keyring
+	// RowLevelSecurity sets app.tenant_id on every transaction, so the claims
+	// policy applies when the API connects as a role that does not own the table.
+	// Postgres only.
+	RowLevelSecurity bool
+	formatTime       func(t time.Time) interface{}
+	// resolveQuery selects the claims to resolve across tenants, given the
+	// resolution time and the batch size.
+	resolveQuery string
+}
+
+func NewClaimRepository(db *sqlx.DB) *ClaimRepository {
+	return &ClaimRepository{
+		Db:         db,
+		formatTime: func(t time.Time) interface{} { return t },
+		// The function bypasses the tenant isolation policy, see migration 000010.
+		resolveQuery: "SELECT * FROM find_claims_to_resolve($1, $2)",
+	}
+}
+
+func NewSQLiteClaimRepository(db *sqlx.DB) *ClaimRepository {
+	return &ClaimRepository{
+		Db:         db,
+		formatTime: func(t time.Time) interface{} { return t.UTC().Format(sqliteTimeLayout) },
+		// Open (1) claims are due at once, waiting resolution (2) ones past their
+		// resolution deadline and confirmed (3) ones past their completion deadline.
+		resolveQuery: "SELECT * FROM claims WHERE status = 1 OR (status = 2 AND resolution_deadline <= $1) OR (status = 3 AND completion_deadline <= $1) ORDER BY created_at LIMIT $2",
+	}
+}
+
+func (r *ClaimRepository) FindClaim(ctx context.Context, id pkg_entity.ID) (*entity.Claim, *internal_error.InternalError) {
+	var claim ClaimEntity
+	err := r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		err := sqlx.GetContext(ctx, q, &claim, "SELECT * FROM claims WHERE claim_id = $1 AND tenant_id = $2", id, tenantId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal_error.NewNotFoundError("claim not found")
+		}
+		if err != nil {
+			logger.FromContext(ctx).Error("error finding claim", "error", err)
+			return internal_error.NewInternalServerError("error finding claim", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, mapErr := r.mapClaimEntity(ctx, claim)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+	return &result, nil
+}
+
+func (r *ClaimRepository) FindClaims(ctx context.Context, status entity.ClaimStatus, receiverId *pkg_entity.ID, page int) ([]entity.Claim, *internal_error.InternalError) {
+	var claimEntities []ClaimEntity
+	err := r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		query, args := buildClaimsQuery(tenantId, status, receiverId, page)
+		if err := sqlx.SelectContext(ctx, q, &claimEntities, query, args...); err != nil {
+			logger.FromContext(ctx).Error("error finding claims", "error", err)
+			return internal_error.NewInternalServerError("error finding claims", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapClaimEntities(ctx, claimEntities)
+}
+
+// buildClaimsQuery returns the query and arguments of a page of the claims of the
+// tenant.
+func buildClaimsQuery(tenantId string, status entity.ClaimStatus, receiverId *pkg_entity.ID, page int) (string, []interface{}) {
+	query := "SELECT * FROM claims WHERE tenant_id = $1"
+	args := []interface{}{tenantId}
+
+	if status != 0 {
+		args = append(args, status)
+		query += " AND status = $" + strconv.Itoa(len(args))
+	}
+
+	if receiverId != nil {
+		args = append(args, *receiverId)
+		query += " AND (claimer_receiver_id = $" + strconv.Itoa(len(args)) + " OR donor_receiver_id = $" + strconv.Itoa(len(args)) + ")"
+	}
+
+	args = append(args, claimsPageSize, (page-1)*claimsPageSize)
+	query += " ORDER BY created_at DESC LIMIT $" + strconv.Itoa(len(args)-1) + " OFFSET $" + strconv.Itoa(len(args))
+
+	return query, args
+}
+
+func (r *ClaimRepository) FindClaimsToResolve(ctx context.Context, now time.Time, limit int) ([]entity.Claim, *internal_error.InternalError) {
+	var claimEntities []ClaimEntity
+	err := r.Db.SelectContext(ctx, &claimEntities, r.resolveQuery, r.formatTime(now), limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("error finding claims to resolve", "error", err)
+		return nil, internal_error.NewInternalServerError("error finding claims to resolve", err)
+	}
+
+	return r.mapClaimEntities(ctx, claimEntities)
+}
+
+func (r *ClaimRepository) CreateClaim(ctx context.Context, claim *entity.Claim) *internal_error.InternalError {
+	sealed := mapClaimToClaimEntity(claim)
+	if err := sealClaimEntity(r.Keyring, &sealed); err != nil {
+		logger.FromContext(ctx).Error("error encrypting claim", "error", err)
+		return internal_error.NewInternalServerError("error encrypting claim", err)
+	}
+
+	return r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		if err := r.checkPlaintextActiveClaim(ctx, q, tenantId, claim.PixKey.Canonical()); err != nil {
+			return err
+		}
+
+		query := "INSERT INTO claims (claim_id, tenant_id, type, status, pix_key, pix_key_type, pix_key_canonical, claimer_receiver_id, donor_receiver_id, cancel_reason, resolution_deadline, completion_deadline, data_key, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)"
+		_, err := q.ExecContext(ctx, query, claim.ClaimId, tenantId, claim.Type, claim.Status, sealed.PixKey, claim.PixKey.KeyType.Value(), sealed.PixKeyCanonical, claim.ClaimerReceiverId, claim.DonorReceiverId, claim.CancelReason, r.formatTime(claim.ResolutionDeadline), r.formatNullableTime(claim.CompletionDeadline), sealed.DataKey, r.formatTime(claim.CreatedAt), r.formatTime(claim.UpdatedAt))
+		if isActiveClaimViolation(err) {
+			return entity.NewActiveClaimConflictError()
+		}
+		if err != nil {
+			logger.FromContext(ctx).Error("error creating claim", "error", err)
+			return internal_error.NewInternalServerError("error creating claim", err)
+		}
+
+		claim.TenantId = tenantId
+		return nil
+	})
+}
+
+func (r *ClaimRepository) UpdateClaim(ctx context.Context, claim *entity.Claim, previous entity.ClaimStatus) *internal_error.InternalError {
+	return r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		query := "UPDATE claims SET status = $1, cancel_reason = $2, completion_deadline = $3, updated_at = $4 WHERE claim_id = $5 AND tenant_id = $6 AND status = $7"
+		res, err := q.ExecContext(ctx, query, claim.Status, claim.CancelReason, r.formatNullableTime(claim.CompletionDeadline), r.formatTime(claim.UpdatedAt), claim.ClaimId, tenantId, previous)
+		if err != nil {
+			logger.FromContext(ctx).Error("error updating claim", "error", err)
+			return internal_error.NewInternalServerError("error updating claim", err)
+		}
+
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			return newClaimChangedError()
+		}
+
+		return nil
+	})
+}
+
+// runInTenant runs fn with the tenant resolved from the context, in the
+// transaction of the unit of work of the context, if any. With row level security
+// it sets app.tenant_id, in a transaction of its own outside a unit of work.
+func (r *ClaimRepository) runInTenant(ctx context.Context, fn func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	if tx, ok := transaction.FromContext(ctx); ok {
+		if err := r.setTenant(ctx, tx, tenantId); err != nil {
+			return err
+		}
+
+		return fn(tx, tenantId)
+	}
+
+	if !r.RowLevelSecurity {
+		return fn(r.Db, tenantId)
+	}
+
+	tx, err := r.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return internal_error.NewInternalServerError("error starting transaction", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.setTenant(ctx, tx, tenantId); err != nil {
+		return err
+	}
+
+	if err := fn(tx, tenantId); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return internal_error.NewInternalServerError("error committing transaction", err)
+	}
+
+	return nil
+}
+
+func (r *ClaimRepository) setTenant(ctx context.Context, tx *sqlx.Tx, tenantId string) *internal_error.InternalError {
+	if !r.RowLevelSecurity {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantId); err != nil {
+		return internal_error.NewInternalServerError("error setting tenant", err)
+	}
+
+	return nil
+}
+
+// checkPlaintextActiveClaim rejects a claim on a key with an active claim written
+// before encryption was enabled, which the unique index cannot match against a
+// blind index until the reencrypt command rewrites it.
+func (r *ClaimRepository) checkPlaintextActiveClaim(ctx context.Context, q sqlx.QueryerContext, tenantId, canonicalPixKey string) *internal_error.InternalError {
+	if r.Keyring == nil {
+		return nil
+	}
+
+	var count int
+	query := "SELECT count(*) FROM claims WHERE tenant_id = $1 AND pix_key_canonical = $2 AND data_key = '' AND status IN (1, 2, 3)"
+	if err := sqlx.GetContext(ctx, q, &count, query, tenantId, canonicalPixKey); err != nil {
+		logger.FromContext(ctx).Error("error finding active claims", "error", err)
+		return internal_error.NewInternalServerError("error creating claim", err)
+	}
+	if count > 0 {
+		return entity.NewActiveClaimConflictError()
+	}
+
+	return nil
+}
+
+func (r *ClaimRepository) formatNullableTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+
+	return r.formatTime(*t)
+}
+
+// isActiveClaimViolation reports whether err is the unique index on the active
+// claims of a key rejecting a write, the only unique constraint on claims besides
+// the primary key.
+func isActiveClaimViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505" && pqErr.Constraint == activeClaimIndex
+	}
+
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
+
+func newClaimChangedError() *internal_error.InternalError {
+	return internal_error.NewConflictError("Claim was changed concurrently", internal_error.Causes{Field: "status", Message: "Claim status changed, reload it and retry"})
+}
+
+func requireTenant(ctx context.Context) (string, *internal_error.InternalError) {
+	tenantId, ok := auth.TenantFromContext(ctx)
+	if !ok {
+		return "", internal_error.NewInternalServerError("tenant not resolved", nil)
+	}
+
+	return tenantId, nil
+}
+
+func mapClaimEntitiesToClaims(claimEntities []ClaimEntity) []entity.Claim {
+	claims := make([]entity.Claim, 0, len(claimEntities))
+	for _, claim := range claimEntities {
+		claims = append(claims, mapClaimEntityToClaim(claim))
+	}
+
+	return claims
+}
+
+func mapClaimEntityToClaim(claimEntity ClaimEntity) entity.Claim {
+	pixKeyType, _ := entity.NewPixKeyType(entity.PixKeyType(claimEntity.PixKeyType))
+	resolutionDeadline, _ := time.Parse(time.RFC3339, claimEntity.ResolutionDeadline)
+	createdAt, _ := time.Parse(time.RFC3339, claimEntity.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, claimEntity.UpdatedAt)
+
+	claim := entity.Claim{
+		ClaimId:            claimEntity.ClaimId,
+		TenantId:           claimEntity.TenantId,
+		Type:               entity.ClaimType(claimEntity.Type),
+		Status:             entity.ClaimStatus(claimEntity.Status),
+		PixKey:             &entity.PixKey{KeyValue: claimEntity.PixKey, KeyType: pixKeyType},
+		ClaimerReceiverId:  claimEntity.ClaimerReceiverId,
+		DonorReceiverId:    claimEntity.DonorReceiverId,
+		CancelReason:       claimEntity.CancelReason,
+		ResolutionDeadline: resolutionDeadline,
+		CreatedAt:          createdAt,
+		UpdatedAt:          updatedAt,
+	}
+
+	if claimEntity.CompletionDeadline != nil {
+		completionDeadline, _ := time.Parse(time.RFC3339, *claimEntity.CompletionDeadline)
+		claim.CompletionDeadline = &completionDeadline
+	}
+
+	return claim
+}
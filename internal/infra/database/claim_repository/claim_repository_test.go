@@ -0,0 +1,151 @@
+package claim_repository_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/database/sqlite"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/claim_repository"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var baseTime = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func backends(t *testing.T) map[string]entity.ClaimRepositoryInterface {
+	db, err := sqlite.InitializeDatabase(context.Background(), filepath.Join(t.TempDir(), "pix.db"), true)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return map[string]entity.ClaimRepositoryInterface{
+		"memory": claim_repository.NewMemoryClaimRepository(),
+		"sqlite": claim_repository.NewSQLiteClaimRepository(db),
+	}
+}
+
+func newClaim(t *testing.T, pixKeyValue string, createdAt time.Time) *entity.Claim {
+	pixKey, err := entity.NewPixKey(pixKeyValue, "email")
+	require.Nil(t, err)
+
+	return &entity.Claim{
+		ClaimId:            pkg_entity.NewID(),
+		Type:               entity.OwnershipClaim,
+		Status:             entity.ClaimOpen,
+		PixKey:             pixKey,
+		ClaimerReceiverId:  pkg_entity.NewID(),
+		DonorReceiverId:    pkg_entity.NewID(),
+		ResolutionDeadline: createdAt.Add(time.Hour),
+		CreatedAt:          createdAt,
+		UpdatedAt:          createdAt,
+	}
+}
+
+func TestClaimRepository(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := auth.WithTenant(context.Background(), "tenant-"+name)
+			otherCtx := auth.WithTenant(context.Background(), "other-"+name)
+
+			claim := newClaim(t, name+"@email.com", baseTime)
+			require.Nil(t, repo.CreateClaim(ctx, claim))
+
+			found, err := repo.FindClaim(ctx, claim.ClaimId)
+			require.Nil(t, err)
+			assert.Equal(t, "tenant-"+name, found.TenantId)
+			assert.Equal(t, entity.ClaimOpen, found.Status)
+			assert.Equal(t, name+"@email.com", found.PixKey.KeyValue)
+			assert.True(t, found.ResolutionDeadline.Equal(claim.ResolutionDeadline))
+			assert.Nil(t, found.CompletionDeadline)
+
+			_, err = repo.FindClaim(otherCtx, claim.ClaimId)
+			assert.Equal(t, "not_found", err.Err)
+
+			duplicate := newClaim(t, name+"@email.com", baseTime.Add(time.Minute))
+			assert.Equal(t, "conflict", repo.CreateClaim(ctx, duplicate).Err, "a key has one active claim")
+			assert.Nil(t, repo.CreateClaim(otherCtx, duplicate), "claims are unique per tenant")
+
+			require.Nil(t, claim.Confirm(baseTime.Add(time.Minute), time.Hour))
+			require.Nil(t, repo.UpdateClaim(ctx, claim, entity.ClaimOpen))
+			assert.Equal(t, "conflict", repo.UpdateClaim(ctx, claim, entity.ClaimOpen).Err, "the status changed")
+
+			found, err = repo.FindClaim(ctx, claim.ClaimId)
+			require.Nil(t, err)
+			assert.Equal(t, entity.ClaimConfirmed, found.Status)
+			require.NotNil(t, found.CompletionDeadline)
+			assert.True(t, found.CompletionDeadline.Equal(baseTime.Add(time.Minute+time.Hour)))
+
+			claims, err := repo.FindClaims(ctx, entity.ClaimConfirmed, &claim.DonorReceiverId, 1)
+			require.Nil(t, err)
+			assert.Len(t, claims, 1)
+
+			claims, err = repo.FindClaims(ctx, entity.ClaimOpen, nil, 1)
+			require.Nil(t, err)
+			assert.Empty(t, claims)
+
+			require.Nil(t, claim.Cancel(baseTime.Add(2*time.Minute), entity.ClaimCanceledByRequest))
+			require.Nil(t, repo.UpdateClaim(ctx, claim, entity.ClaimConfirmed))
+			assert.Nil(t, repo.CreateClaim(ctx, newClaim(t, name+"@email.com", baseTime.Add(3*time.Minute))), "a canceled claim frees the key")
+		})
+	}
+}
+
+func TestClaimRepositoryFindClaimsToResolve(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := auth.WithTenant(context.Background(), "tenant")
+			otherCtx := auth.WithTenant(context.Background(), "other")
+
+			open := newClaim(t, "open@email.com", baseTime)
+			require.Nil(t, repo.CreateClaim(otherCtx, open))
+
+			waiting := newClaim(t, "waiting@email.com", baseTime.Add(time.Minute))
+			waiting.Status = entity.ClaimWaitingResolution
+			require.Nil(t, repo.CreateClaim(ctx, waiting))
+
+			expired := newClaim(t, "expired@email.com", baseTime.Add(2*time.Minute))
+			expired.Status = entity.ClaimWaitingResolution
+			expired.ResolutionDeadline = baseTime
+			require.Nil(t, repo.CreateClaim(ctx, expired))
+
+			confirmed := newClaim(t, "confirmed@email.com", baseTime.Add(3*time.Minute))
+			require.Nil(t, confirmed.Confirm(baseTime.Add(-time.Hour), time.Hour))
+			require.Nil(t, repo.CreateClaim(ctx, confirmed))
+
+			due, err := repo.FindClaimsToResolve(context.Background(), baseTime.Add(30*time.Minute), 10)
+			require.Nil(t, err)
+
+			ids := make([]pkg_entity.ID, 0, len(due))
+			for _, claim := range due {
+				ids = append(ids, claim.ClaimId)
+			}
+			assert.Equal(t, []pkg_entity.ID{open.ClaimId, expired.ClaimId, confirmed.ClaimId}, ids)
+
+			due, err = repo.FindClaimsToResolve(context.Background(), baseTime.Add(30*time.Minute), 1)
+			require.Nil(t, err)
+			assert.Len(t, due, 1)
+		})
+	}
+}
+
+func TestMemoryClaimRepositorySnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "claims.json")
+	ctx := auth.WithTenant(context.Background(), "tenant")
+
+	repo := claim_repository.NewMemoryClaimRepository()
+	claim := newClaim(t, "felipe@email.com", baseTime)
+	require.Nil(t, repo.CreateClaim(ctx, claim))
+	require.NoError(t, repo.SaveSnapshot(path))
+
+	restored := claim_repository.NewMemoryClaimRepository()
+	require.NoError(t, restored.LoadSnapshot(path))
+
+	found, err := restored.FindClaim(ctx, claim.ClaimId)
+	require.Nil(t, err)
+	assert.Equal(t, claim.PixKey.Canonical(), found.PixKey.Canonical())
+	assert.True(t, found.CreatedAt.Equal(baseTime))
+}
@@ -0,0 +1,239 @@
+package claim_repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/snapshot"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+)
+
+// MemoryClaimRepository keeps claims in the process memory, with the same errors
+// as ClaimRepository and an optional JSON snapshot.
+type MemoryClaimRepository struct {
+	mu     sync.RWMutex
+	claims map[pkg_entity.ID]ClaimEntity
+}
+
+func NewMemoryClaimRepository() *MemoryClaimRepository {
+	return &MemoryClaimRepository{claims: make(map[pkg_entity.ID]ClaimEntity)}
+}
+
+func (r *MemoryClaimRepository) FindClaim(ctx context.Context, id pkg_entity.ID) (*entity.Claim, *internal_error.InternalError) {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return nil, tenantErr
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	claim, found := r.claims[id]
+	if !found || claim.TenantId != tenantId {
+		return nil, internal_error.NewNotFoundError("claim not found")
+	}
+
+	result := mapClaimEntityToClaim(claim)
+	return &result, nil
+}
+
+func (r *MemoryClaimRepository) FindClaims(ctx context.Context, status entity.ClaimStatus, receiverId *pkg_entity.ID, page int) ([]entity.Claim, *internal_error.InternalError) {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return nil, tenantErr
+	}
+
+	r.mu.RLock()
+	matching := make([]ClaimEntity, 0)
+	for _, claim := range r.claims {
+		if claim.TenantId != tenantId {
+			continue
+		}
+		if status != 0 && entity.ClaimStatus(claim.Status) != status {
+			continue
+		}
+		if receiverId != nil && claim.ClaimerReceiverId != *receiverId && claim.DonorReceiverId != *receiverId {
+			continue
+		}
+		matching = append(matching, claim)
+	}
+	r.mu.RUnlock()
+
+	sortByCreatedAtDesc(matching)
+
+	start := (page - 1) * claimsPageSize
+	if start < 0 || start >= len(matching) {
+		return []entity.Claim{}, nil
+	}
+
+	end := start + claimsPageSize
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	return mapClaimEntitiesToClaims(matching[start:end]), nil
+}
+
+func (r *MemoryClaimRepository) FindClaimsToResolve(ctx context.Context, now time.Time, limit int) ([]entity.Claim, *internal_error.InternalError) {
+	r.mu.RLock()
+	due := make([]entity.Claim, 0)
+	for _, claimEntity := range r.claims {
+		claim := mapClaimEntityToClaim(claimEntity)
+		if isDue(claim, now) {
+			due = append(due, claim)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].CreatedAt.Before(due[j].CreatedAt)
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (r *MemoryClaimRepository) CreateClaim(ctx context.Context, claim *entity.Claim) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	canonicalPixKey := claim.PixKey.Canonical()
+	for _, existing := range r.claims {
+		if existing.TenantId == tenantId && existing.PixKeyCanonical == canonicalPixKey && isActiveStatus(existing.Status) {
+			return entity.NewActiveClaimConflictError()
+		}
+	}
+
+	claim.TenantId = tenantId
+	r.claims[claim.ClaimId] = mapClaimToClaimEntity(claim)
+
+	return nil
+}
+
+func (r *MemoryClaimRepository) UpdateClaim(ctx context.Context, claim *entity.Claim, previous entity.ClaimStatus) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, found := r.claims[claim.ClaimId]
+	if !found || current.TenantId != tenantId || entity.ClaimStatus(current.Status) != previous {
+		return newClaimChangedError()
+	}
+
+	updated := current
+	updated.Status = int(claim.Status)
+	updated.CancelReason = claim.CancelReason
+	updated.CompletionDeadline = formatNullableTime(claim.CompletionDeadline)
+	updated.UpdatedAt = claim.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	r.claims[claim.ClaimId] = updated
+
+	return nil
+}
+
+// SaveSnapshot writes every claim to path as JSON.
+func (r *MemoryClaimRepository) SaveSnapshot(path string) error {
+	r.mu.RLock()
+	claims := make([]ClaimEntity, 0, len(r.claims))
+	for _, claim := range r.claims {
+		claims = append(claims, claim)
+	}
+	r.mu.RUnlock()
+
+	sortByCreatedAtDesc(claims)
+
+	return snapshot.Save(path, claims)
+}
+
+// LoadSnapshot replaces the claims with the ones saved at path, if any.
+func (r *MemoryClaimRepository) LoadSnapshot(path string) error {
+	var claims []ClaimEntity
+	found, err := snapshot.Load(path, &claims)
+	if err != nil || !found {
+		return err
+	}
+
+	loaded := make(map[pkg_entity.ID]ClaimEntity, len(claims))
+	for _, claim := range claims {
+		loaded[claim.ClaimId] = claim
+	}
+
+	r.mu.Lock()
+	r.claims = loaded
+	r.mu.Unlock()
+
+	return nil
+}
+
+// isDue mirrors the filter of ClaimRepository.FindClaimsToResolve.
+func isDue(claim entity.Claim, now time.Time) bool {
+	switch claim.Status {
+	case entity.ClaimOpen:
+		return true
+	case entity.ClaimWaitingResolution:
+		return !now.Before(claim.ResolutionDeadline)
+	case entity.ClaimConfirmed:
+		return claim.CompletionDeadline != nil && !now.Before(*claim.CompletionDeadline)
+	default:
+		return false
+	}
+}
+
+func isActiveStatus(status int) bool {
+	claimStatus := entity.ClaimStatus(status)
+	return claimStatus == entity.ClaimOpen || claimStatus == entity.ClaimWaitingResolution || claimStatus == entity.ClaimConfirmed
+}
+
+func sortByCreatedAtDesc(claims []ClaimEntity) {
+	createdAt := make(map[pkg_entity.ID]time.Time, len(claims))
+	for _, claim := range claims {
+		createdAt[claim.ClaimId], _ = time.Parse(time.RFC3339Nano, claim.CreatedAt)
+	}
+
+	sort.SliceStable(claims, func(i, j int) bool {
+		return createdAt[claims[i].ClaimId].After(createdAt[claims[j].ClaimId])
+	})
+}
+
+func formatNullableTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.UTC().Format(time.RFC3339Nano)
+	return &formatted
+}
+
+func mapClaimToClaimEntity(claim *entity.Claim) ClaimEntity {
+	return ClaimEntity{
+		ClaimId:            claim.ClaimId,
+		TenantId:           claim.TenantId,
+		Type:               int(claim.Type),
+		Status:             int(claim.Status),
+		PixKey:             claim.PixKey.KeyValue,
+		PixKeyType:         int(claim.PixKey.KeyType.Value()),
+		PixKeyCanonical:    claim.PixKey.Canonical(),
+		ClaimerReceiverId:  claim.ClaimerReceiverId,
+		DonorReceiverId:    claim.DonorReceiverId,
+		CancelReason:       claim.CancelReason,
+		ResolutionDeadline: claim.ResolutionDeadline.UTC().Format(time.RFC3339Nano),
+		CompletionDeadline: formatNullableTime(claim.CompletionDeadline),
+		CreatedAt:          claim.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:          claim.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
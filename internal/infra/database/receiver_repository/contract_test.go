@@ -0,0 +1,42 @@
+package receiver_repository_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/configuration/database/sqlite"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository_contract"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryReceiverRepositoryContract(t *testing.T) {
+	receiver_repository_contract.Run(t, func(t *testing.T) entity.ReceiverRepositoryInterface {
+		return receiver_repository.NewMemoryReceiverRepository()
+	})
+}
+
+func TestSQLiteReceiverRepositoryContract(t *testing.T) {
+	db, err := sqlite.InitializeDatabase(context.Background(), filepath.Join(t.TempDir(), "pix.db"), true)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	receiver_repository_contract.Run(t, func(t *testing.T) entity.ReceiverRepositoryInterface {
+		return receiver_repository.NewSQLiteReceiverRepository(db)
+	})
+}
+
+func TestEncryptedSQLiteReceiverRepositoryContract(t *testing.T) {
+	db, err := sqlite.InitializeDatabase(context.Background(), filepath.Join(t.TempDir(), "pix.db"), true)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	keyring := newKeyring(t, 1)
+	receiver_repository_contract.Run(t, func(t *testing.T) entity.ReceiverRepositoryInterface {
+		repo := receiver_repository.NewSQLiteReceiverRepository(db)
+		repo.Keyring = keyring
+		return repo
+	})
+}
@@ -1,135 +1,436 @@
 package receiver_repository
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/snapshot"
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
 	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
 )
 
+// MemoryReceiverRepository keeps receivers in the process memory. Filters,
+// ordering, pagination, pix key uniqueness and not found errors behave as in
+// ReceiverRepository, and the receivers can be saved to and restored from a JSON
+// snapshot. The zero value is ready to use.
 type MemoryReceiverRepository struct {
-	Receivers []ReceiverEntity
+	mu        sync.RWMutex
+	receivers map[pkg_entity.ID]ReceiverEntity
 }
 
-func NewMemoryReceiverRepository() *ReceiverRepository {
-	return &ReceiverRepository{}
+func NewMemoryReceiverRepository() *MemoryReceiverRepository {
+	return &MemoryReceiverRepository{}
 }
 
 func (r *MemoryReceiverRepository) FindReceiver(ctx context.Context, id pkg_entity.ID) (*entity.Receiver, *internal_error.InternalError) {
-	var receiver ReceiverEntity
-	for _, r := range r.Receivers {
-		if r.ReceiverId == id {
-			receiver = r
-			break
-		}
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return nil, tenantErr
 	}
 
-	if receiver.ReceiverId.String() == "" {
+	r.mu.RLock()
+	receiverEntity, found := r.receivers[id]
+	r.mu.RUnlock()
+
+	if !found || receiverEntity.TenantId != tenantId {
 		return nil, internal_error.NewNotFoundError("receiver not found")
 	}
 
-	entity := mapReceiverEntityToReceiver(receiver)
+	receiver, mapErr := mapReceiverEntityToReceiver(ctx, nil, receiverEntity)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+	return &receiver, nil
+}
+
+func (r *MemoryReceiverRepository) FindReceiverByPixKey(ctx context.Context, canonicalPixKey string) (*entity.Receiver, *internal_error.InternalError) {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return nil, tenantErr
+	}
+
+	r.mu.RLock()
+	receiverEntity, found := r.findByPixKey(tenantId, canonicalPixKey)
+	r.mu.RUnlock()
+
+	if !found {
+		return nil, internal_error.NewNotFoundError("receiver not found")
+	}
 
-	return &entity, nil
+	receiver, mapErr := mapReceiverEntityToReceiver(ctx, nil, receiverEntity)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+	return &receiver, nil
 }
 
-func (r *MemoryReceiverRepository) FindReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, page int) ([]entity.Receiver, *internal_error.InternalError) {
-	var receivers []entity.Receiver
+func (r *MemoryReceiverRepository) CountReceiversByOwner(ctx context.Context, owner entity.PixKeyOwner) (int, *internal_error.InternalError) {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return 0, tenantErr
+	}
 
-	for _, receiver := range r.Receivers {
-		if status != -1 && entity.ReceiverStatus(receiver.Status) != status {
-			continue
-		}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-		if name != "" && receiver.Name != name {
-			continue
+	count := 0
+	for _, receiver := range r.receivers {
+		if receiver.TenantId == tenantId && documentPunctuation.Replace(receiver.Document) == owner.Document &&
+			receiver.Bank == owner.Bank && receiver.Office == owner.Office && receiver.AccountNumber == owner.AccountNumber {
+			count++
 		}
+	}
 
-		if pixKeyValue != "" && receiver.PixKey != pixKeyValue {
-			continue
-		}
+	return count, nil
+}
 
-		if pixKeyType != -1 && entity.PixKeyType(receiver.PixKeyType) != pixKeyType {
-			continue
-		}
+// LockPixKeyOwner does nothing: the units of work of the memory repositories
+// already run one at a time, see transaction.MemoryManager.
+func (r *MemoryReceiverRepository) LockPixKeyOwner(ctx context.Context, owner entity.PixKeyOwner) *internal_error.InternalError {
+	return nil
+}
+
+func (r *MemoryReceiverRepository) FindReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, page int) ([]entity.Receiver, *internal_error.InternalError) {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return nil, tenantErr
+	}
+
+	offset := (page - 1) * receiversPageSize
+	if offset < 0 {
+		return nil, internal_error.NewInternalServerError("error finding receivers", errors.New("page must be greater than zero"))
+	}
+
+	matching := r.findMatching(tenantId, status, name, pixKeyValue, pixKeyType)
+	if offset >= len(matching) {
+		return nil, nil
+	}
 
-		receivers = append(receivers, mapReceiverEntityToReceiver(receiver))
+	end := offset + receiversPageSize
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	var receivers []entity.Receiver
+	for _, receiverEntity := range matching[offset:end] {
+		receiver, mapErr := mapReceiverEntityToReceiver(ctx, nil, receiverEntity)
+		if mapErr != nil {
+			return nil, mapErr
+		}
+		receivers = append(receivers, receiver)
 	}
 
 	return receivers, nil
 }
 
+// StreamReceivers calls fn for the receivers matching the filters at the time of
+// the call, without holding the lock while fn runs.
+func (r *MemoryReceiverRepository) StreamReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, fn func(receiver *entity.Receiver) *internal_error.InternalError) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	for _, receiverEntity := range r.findMatching(tenantId, status, name, pixKeyValue, pixKeyType) {
+		receiver, mapErr := mapReceiverEntityToReceiver(ctx, nil, receiverEntity)
+		if mapErr != nil {
+			return mapErr
+		}
+		if err := fn(&receiver); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *MemoryReceiverRepository) CreateReceiver(ctx context.Context, receiver *entity.Receiver) *internal_error.InternalError {
-	receiverEntity := ReceiverEntity{
-		ReceiverId:    receiver.ReceiverId,
-		Name:          receiver.Name,
-		Document:      receiver.Document.String(),
-		Email:         receiver.Email.String(),
-		Status:        int(receiver.GetStatus()),
-		PixKey:        receiver.PixKey.KeyValue,
-		PixKeyType:    int(receiver.PixKey.KeyType.Value()),
-		Bank:          receiver.Bank,
-		Office:        receiver.Office,
-		AccountNumber: receiver.AccountNumber,
-		CreatedAt:     receiver.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     receiver.UpdatedAt.Format(time.RFC3339),
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
 	}
+	receiver.TenantId = tenantId
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	r.Receivers = append(r.Receivers, receiverEntity)
+	if _, found := r.receivers[receiver.ReceiverId]; found {
+		return internal_error.NewInternalServerError("error creating receiver", errors.New("duplicate receiver id"))
+	}
+
+	if _, taken := r.findByPixKey(tenantId, receiver.PixKey.Canonical()); taken {
+		return entity.NewPixKeyConflictError()
+	}
+
+	if r.receivers == nil {
+		r.receivers = make(map[pkg_entity.ID]ReceiverEntity)
+	}
+	r.receivers[receiver.ReceiverId] = mapReceiverToReceiverEntity(receiver)
 
 	return nil
 }
 
+// UpdateReceiver is a no-op for an unknown receiver, as with ReceiverRepository.
 func (r *MemoryReceiverRepository) UpdateReceiver(ctx context.Context, receiver *entity.Receiver) *internal_error.InternalError {
-	receiverIndex := -1
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
 
-	for i, r := range r.Receivers {
-		if r.ReceiverId == receiver.ReceiverId {
-			receiverIndex = i
-			break
-		}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, found := r.receivers[receiver.ReceiverId]
+	if !found || current.TenantId != tenantId {
+		return nil
 	}
 
-	if receiverIndex == -1 {
-		return internal_error.NewNotFoundError("receiver not found")
+	if holder, taken := r.findByPixKey(tenantId, receiver.PixKey.Canonical()); taken && holder.ReceiverId != receiver.ReceiverId {
+		return entity.NewPixKeyConflictError()
 	}
 
-	receiverEntity := ReceiverEntity{
-		ReceiverId:    receiver.ReceiverId,
-		Name:          receiver.Name,
-		Document:      receiver.Document.String(),
-		Email:         receiver.Email.String(),
-		Status:        int(receiver.GetStatus()),
-		PixKey:        receiver.PixKey.KeyValue,
-		PixKeyType:    int(receiver.PixKey.KeyType.Value()),
-		Bank:          receiver.Bank,
-		Office:        receiver.Office,
-		AccountNumber: receiver.AccountNumber,
-		CreatedAt:     receiver.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     receiver.UpdatedAt.Format(time.RFC3339),
+	updated := mapReceiverToReceiverEntity(receiver)
+	updated.TenantId = current.TenantId
+	updated.CreatedAt = current.CreatedAt
+	r.receivers[receiver.ReceiverId] = updated
+
+	return nil
+}
+
+func (r *MemoryReceiverRepository) TransferPixKey(ctx context.Context, donorId pkg_entity.ID, receiver *entity.Receiver) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.receivers[receiver.ReceiverId]; found {
+		return internal_error.NewInternalServerError("error transferring pix key", errors.New("duplicate receiver id"))
+	}
+
+	holder, taken := r.findByPixKey(tenantId, receiver.PixKey.Canonical())
+	if taken && holder.ReceiverId != donorId {
+		return entity.NewPixKeyConflictError()
 	}
 
-	r.Receivers[receiverIndex] = receiverEntity
+	if taken {
+		delete(r.receivers, donorId)
+	}
+
+	receiver.TenantId = tenantId
+	if r.receivers == nil {
+		r.receivers = make(map[pkg_entity.ID]ReceiverEntity)
+	}
+	r.receivers[receiver.ReceiverId] = mapReceiverToReceiverEntity(receiver)
 
 	return nil
 }
 
 func (r *MemoryReceiverRepository) DeleteManyReceivers(ctx context.Context, ids []pkg_entity.ID) *internal_error.InternalError {
-	receiverIndexes := make([]int, 0)
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	for i, receiver := range r.Receivers {
-		for _, id := range ids {
-			if receiver.ReceiverId == id {
-				receiverIndexes = append(receiverIndexes, i)
-			}
+	deleted := 0
+	for _, id := range ids {
+		if receiver, found := r.receivers[id]; found && receiver.TenantId == tenantId {
+			delete(r.receivers, id)
+			deleted++
 		}
 	}
 
-	for _, index := range receiverIndexes {
-		r.Receivers = append(r.Receivers[:index], r.Receivers[index+1:]...)
+	if deleted == 0 {
+		return internal_error.NewNotFoundError("receivers not found")
+	}
+
+	return nil
+}
+
+// SaveSnapshot writes every receiver to path as JSON.
+func (r *MemoryReceiverRepository) SaveSnapshot(path string) error {
+	r.mu.RLock()
+	receivers := make([]ReceiverEntity, 0, len(r.receivers))
+	for _, receiver := range r.receivers {
+		receivers = append(receivers, receiver)
+	}
+	r.mu.RUnlock()
+
+	sortByCreatedAtDesc(receivers)
+
+	return snapshot.Save(path, receivers)
+}
+
+// LoadSnapshot replaces the receivers with the ones saved at path, if any.
+func (r *MemoryReceiverRepository) LoadSnapshot(path string) error {
+	var receivers []ReceiverEntity
+	found, err := snapshot.Load(path, &receivers)
+	if err != nil || !found {
+		return err
+	}
+
+	loaded := make(map[pkg_entity.ID]ReceiverEntity, len(receivers))
+	for _, receiver := range receivers {
+		normalizePixKey(&receiver)
+		loaded[receiver.ReceiverId] = receiver
 	}
 
+	r.mu.Lock()
+	r.receivers = loaded
+	r.mu.Unlock()
+
 	return nil
 }
+
+// documentPunctuation strips the punctuation of documents, as
+// countReceiversByOwnerQuery does.
+var documentPunctuation = strings.NewReplacer(".", "", "-", "", "/", "")
+
+// findByPixKey returns the receiver of the tenant holding the canonical pix key.
+// The caller must hold the lock.
+func (r *MemoryReceiverRepository) findByPixKey(tenantId, canonicalPixKey string) (ReceiverEntity, bool) {
+	for _, receiver := range r.receivers {
+		if receiver.TenantId == tenantId && receiver.PixKeyCanonical == canonicalPixKey {
+			return receiver, true
+		}
+	}
+
+	return ReceiverEntity{}, false
+}
+
+// normalizePixKey upgrades receivers saved by versions that stored the pix key as
+// typed or did not store its canonical form.
+func normalizePixKey(receiver *ReceiverEntity) {
+	pixKeyType, err := entity.NewPixKeyType(entity.PixKeyType(receiver.PixKeyType))
+	if err != nil {
+		return
+	}
+
+	receiver.PixKey = pixKeyType.Normalize(receiver.PixKey)
+	if receiver.PixKeyCanonical == "" {
+		receiver.PixKeyCanonical = pixKeyType.Canonical(receiver.PixKey)
+	}
+}
+
+// findMatching returns the receivers matching the filters, newest first.
+func (r *MemoryReceiverRepository) findMatching(tenantId string, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType) []ReceiverEntity {
+	var namePattern *regexp.Regexp
+	if name != "" {
+		namePattern = likePattern(name)
+	}
+
+	var pixKeyCanonicals []string
+	if pixKeyValue != "" {
+		pixKeyCanonicals = entity.PixKeyFilterCanonicals(pixKeyValue, pixKeyType)
+	}
+
+	r.mu.RLock()
+	var matching []ReceiverEntity
+	for _, receiver := range r.receivers {
+		if matchesReceiverFilter(receiver, tenantId, status, namePattern, pixKeyCanonicals, pixKeyType) {
+			matching = append(matching, receiver)
+		}
+	}
+	r.mu.RUnlock()
+
+	sortByCreatedAtDesc(matching)
+
+	return matching
+}
+
+func matchesReceiverFilter(receiver ReceiverEntity, tenantId string, status entity.ReceiverStatus, namePattern *regexp.Regexp, pixKeyCanonicals []string, pixKeyType entity.PixKeyType) bool {
+	if receiver.TenantId != tenantId {
+		return false
+	}
+
+	if status != -1 && entity.ReceiverStatus(receiver.Status) != status {
+		return false
+	}
+
+	if namePattern != nil && !namePattern.MatchString(receiver.Name) {
+		return false
+	}
+
+	if pixKeyCanonicals != nil && !slices.Contains(pixKeyCanonicals, receiver.PixKeyCanonical) {
+		return false
+	}
+
+	if pixKeyType != -1 && entity.PixKeyType(receiver.PixKeyType) != pixKeyType {
+		return false
+	}
+
+	return true
+}
+
+// likePattern translates a SQL LIKE pattern, where % matches any sequence and _
+// any single character, into an anchored regular expression.
+func likePattern(pattern string) *regexp.Regexp {
+	var expression strings.Builder
+	expression.WriteString("^")
+	for _, char := range pattern {
+		switch char {
+		case '%':
+			expression.WriteString(".*")
+		case '_':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	expression.WriteString("$")
+
+	return regexp.MustCompile("(?s)" + expression.String())
+}
+
+func sortByCreatedAtDesc(receivers []ReceiverEntity) {
+	createdAt := make(map[pkg_entity.ID]time.Time, len(receivers))
+	for _, receiver := range receivers {
+		createdAt[receiver.ReceiverId], _ = time.Parse(time.RFC3339Nano, receiver.CreatedAt)
+	}
+
+	sort.Slice(receivers, func(i, j int) bool {
+		a, b := createdAt[receivers[i].ReceiverId], createdAt[receivers[j].ReceiverId]
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+
+		return bytes.Compare(receivers[i].ReceiverId[:], receivers[j].ReceiverId[:]) < 0
+	})
+}
+
+func mapReceiverToReceiverEntity(receiver *entity.Receiver) ReceiverEntity {
+	receiverEntity := ReceiverEntity{
+		ReceiverId:    receiver.ReceiverId,
+		TenantId:      receiver.TenantId,
+		Name:          receiver.Name,
+		Document:      receiver.Document.String(),
+		Email:         receiver.Email.String(),
+		Status:        int(receiver.GetStatus()),
+		Bank:          receiver.Bank,
+		Office:        receiver.Office,
+		AccountNumber: receiver.AccountNumber,
+		CreatedAt:     receiver.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:     receiver.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+
+	if receiver.PixKey != nil {
+		receiverEntity.PixKey = receiver.PixKey.KeyValue
+		receiverEntity.PixKeyType = int(receiver.PixKey.KeyType.Value())
+		receiverEntity.PixKeyCanonical = receiver.PixKey.Canonical()
+	}
+
+	return receiverEntity
+}
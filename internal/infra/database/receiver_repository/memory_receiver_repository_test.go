@@ -0,0 +1,40 @@
+package receiver_repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var memoryCtx = auth.WithTenant(context.Background(), "acme")
+
+func createMemoryReceiver(t *testing.T, repo *MemoryReceiverRepository, name string, createdAt time.Time) *entity.Receiver {
+	receiver, err := entity.NewReceiver("12345678909", "felipe@email.com", "email", name, "felipe@email.com")
+	assert.Nil(t, err)
+	receiver.CreatedAt = createdAt
+	receiver.UpdatedAt = createdAt
+
+	assert.Nil(t, repo.CreateReceiver(memoryCtx, receiver))
+	return receiver
+}
+
+func TestMemoryReceiverRepositorySnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "receivers.json")
+	repo := NewMemoryReceiverRepository()
+	receiver := createMemoryReceiver(t, repo, "Felipe", time.Now())
+	assert.Nil(t, repo.SaveSnapshot(path))
+
+	restored := NewMemoryReceiverRepository()
+	assert.Nil(t, restored.LoadSnapshot(filepath.Join(t.TempDir(), "missing.json")))
+	assert.Nil(t, restored.LoadSnapshot(path))
+
+	found, err := restored.FindReceiver(memoryCtx, receiver.ReceiverId)
+	assert.Nil(t, err)
+	assert.Equal(t, "Felipe", found.Name)
+	assert.Equal(t, receiver.CreatedAt.UnixNano(), found.CreatedAt.UnixNano())
+}
@@ -0,0 +1,197 @@
+package receiver_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/encryption"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Purposes of the blind indexes, see encryption.Keyring.BlindIndex.
+const (
+	pixKeyIndexPurpose          = "receivers.pix_key"
+	pixKeyCanonicalIndexPurpose = "receivers.pix_key_canonical"
+	documentIndexPurpose        = "receivers.document"
+	accountNumberIndexPurpose   = "receivers.account_number"
+)
+
+const reencryptBatchSize = 500
+
+// reencryptStartId sorts before every receiver id, as a uuid and as text.
+const reencryptStartId = "00000000-0000-0000-0000-000000000000"
+
+// sealReceiverEntity encrypts the document, pix key and account number of a
+// plaintext receiver entity with a new data key and fills their blind indexes.
+// The canonical pix key is replaced by its blind index, so the unique index on it
+// still rejects repeated keys. Without a keyring the entity is left in plaintext.
+func sealReceiverEntity(keyring *encryption.Keyring, receiverEntity *ReceiverEntity) error {
+	if keyring == nil {
+		return nil
+	}
+
+	dataKey, wrappedKey, err := keyring.NewDataKey()
+	if err != nil {
+		return err
+	}
+
+	receiverId := receiverEntity.ReceiverId.String()
+	plaintext := *receiverEntity
+
+	receiverEntity.DataKey = wrappedKey
+	receiverEntity.PixKeyIndex = keyring.BlindIndex(pixKeyIndexPurpose, plaintext.PixKey)
+	receiverEntity.PixKeyCanonical = keyring.BlindIndex(pixKeyCanonicalIndexPurpose, plaintext.PixKeyCanonical)
+	receiverEntity.DocumentIndex = keyring.BlindIndex(documentIndexPurpose, documentPunctuation.Replace(plaintext.Document))
+	receiverEntity.AccountNumberIndex = keyring.BlindIndex(accountNumberIndexPurpose, plaintext.AccountNumber)
+
+	if receiverEntity.Document, err = dataKey.Encrypt(plaintext.Document, receiverId+":document"); err != nil {
+		return err
+	}
+	if receiverEntity.PixKey, err = dataKey.Encrypt(plaintext.PixKey, receiverId+":pix_key"); err != nil {
+		return err
+	}
+	if receiverEntity.AccountNumber, err = dataKey.Encrypt(plaintext.AccountNumber, receiverId+":account_number"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// openReceiverEntity decrypts a receiver entity sealed by sealReceiverEntity and
+// recomputes its canonical pix key. Entities without a data key were written
+// before encryption was enabled and are already in plaintext.
+func openReceiverEntity(keyring *encryption.Keyring, receiverEntity *ReceiverEntity) error {
+	if receiverEntity.DataKey == "" {
+		return nil
+	}
+	if keyring == nil {
+		return errors.New("receiver is encrypted and encryption is not configured")
+	}
+
+	dataKey, err := keyring.UnwrapDataKey(receiverEntity.DataKey)
+	if err != nil {
+		return err
+	}
+
+	receiverId := receiverEntity.ReceiverId.String()
+	if receiverEntity.Document, err = dataKey.Decrypt(receiverEntity.Document, receiverId+":document"); err != nil {
+		return fmt.Errorf("decrypting document: %w", err)
+	}
+	if receiverEntity.PixKey, err = dataKey.Decrypt(receiverEntity.PixKey, receiverId+":pix_key"); err != nil {
+		return fmt.Errorf("decrypting pix key: %w", err)
+	}
+	if receiverEntity.AccountNumber, err = dataKey.Decrypt(receiverEntity.AccountNumber, receiverId+":account_number"); err != nil {
+		return fmt.Errorf("decrypting account number: %w", err)
+	}
+
+	receiverEntity.PixKeyCanonical = ""
+	if pixKeyType, typeErr := entity.NewPixKeyType(entity.PixKeyType(receiverEntity.PixKeyType)); typeErr == nil {
+		receiverEntity.PixKeyCanonical = pixKeyType.Canonical(receiverEntity.PixKey)
+	}
+
+	return nil
+}
+
+// pixKeyLookup returns the values pix_key_canonical may hold for a canonical pix
+// key: its blind index, and the key itself for receivers written before encryption
+// was enabled and not re-encrypted yet. Both are the key itself without a keyring.
+func pixKeyLookup(keyring *encryption.Keyring, canonicalPixKey string) (string, string) {
+	if keyring == nil {
+		return canonicalPixKey, canonicalPixKey
+	}
+
+	return keyring.BlindIndex(pixKeyCanonicalIndexPurpose, canonicalPixKey), canonicalPixKey
+}
+
+// sealReceiver maps a receiver to the entity written by the sql repositories.
+func sealReceiver(ctx context.Context, keyring *encryption.Keyring, receiver *entity.Receiver) (ReceiverEntity, *internal_error.InternalError) {
+	receiverEntity := mapReceiverToReceiverEntity(receiver)
+	if err := sealReceiverEntity(keyring, &receiverEntity); err != nil {
+		logger.FromContext(ctx).Error("error encrypting receiver", "error", err)
+		return ReceiverEntity{}, internal_error.NewInternalServerError("error encrypting receiver", err)
+	}
+
+	return receiverEntity, nil
+}
+
+// countReceiversByOwner builds the count of the receivers of an owner. With a
+// keyring encrypted receivers are compared by their blind indexes and those still
+// in plaintext by their values.
+func countReceiversByOwner(keyring *encryption.Keyring, tenantId string, owner entity.PixKeyOwner) (string, []interface{}) {
+	if keyring == nil {
+		return countReceiversByOwnerQuery, []interface{}{tenantId, owner.Document, owner.Bank, owner.Office, owner.AccountNumber}
+	}
+
+	return countEncryptedReceiversByOwnerQuery, []interface{}{
+		tenantId,
+		owner.Bank,
+		owner.Office,
+		keyring.BlindIndex(documentIndexPurpose, owner.Document),
+		keyring.BlindIndex(accountNumberIndexPurpose, owner.AccountNumber),
+		owner.Document,
+		owner.AccountNumber,
+	}
+}
+
+// reencryptReceivers rewrites, across every tenant, the receivers in plaintext or
+// with a data key wrapped by a previous master key, or every receiver when all is
+// set (to rebuild the blind indexes after changing the index key). A receiver
+// updated meanwhile is skipped, as it was already written with the current keys.
+func reencryptReceivers(ctx context.Context, db *sqlx.DB, keyring *encryption.Keyring, all bool, startSpan func(ctx context.Context, operation, statement string) (context.Context, trace.Span)) (int, *internal_error.InternalError) {
+	if keyring == nil {
+		return 0, internal_error.NewBadRequestError("encryption is not configured")
+	}
+
+	selectQuery := "SELECT * FROM receivers WHERE receiver_id > $1 ORDER BY receiver_id LIMIT $2"
+	updateQuery := "UPDATE receivers SET document = $1, pix_key = $2, pix_key_canonical = $3, account_number = $4, data_key = $5, pix_key_index = $6, document_index = $7, account_number_index = $8 WHERE receiver_id = $9 AND data_key = $10"
+
+	rewritten := 0
+	lastId := reencryptStartId
+	for {
+		var batch []ReceiverEntity
+		queryCtx, span := startSpan(ctx, "SELECT", selectQuery)
+		err := db.SelectContext(queryCtx, &batch, selectQuery, lastId, reencryptBatchSize)
+		endQuerySpan(span, err)
+		if err != nil {
+			logger.FromContext(ctx).Error("error reading receivers to re-encrypt", "error", err)
+			return rewritten, internal_error.NewInternalServerError("error re-encrypting receivers", err)
+		}
+
+		for _, receiverEntity := range batch {
+			lastId = receiverEntity.ReceiverId.String()
+			if !all && keyring.IsCurrent(receiverEntity.DataKey) {
+				continue
+			}
+
+			previousDataKey := receiverEntity.DataKey
+			if err := openReceiverEntity(keyring, &receiverEntity); err != nil {
+				logger.FromContext(ctx).Error("error decrypting receiver", "receiver_id", lastId, "error", err)
+				return rewritten, internal_error.NewInternalServerError("error re-encrypting receivers", err)
+			}
+			if err := sealReceiverEntity(keyring, &receiverEntity); err != nil {
+				return rewritten, internal_error.NewInternalServerError("error re-encrypting receivers", err)
+			}
+
+			updateCtx, span := startSpan(ctx, "UPDATE", updateQuery)
+			res, err := db.ExecContext(updateCtx, updateQuery, receiverEntity.Document, receiverEntity.PixKey, receiverEntity.PixKeyCanonical, receiverEntity.AccountNumber, receiverEntity.DataKey, receiverEntity.PixKeyIndex, receiverEntity.DocumentIndex, receiverEntity.AccountNumberIndex, receiverEntity.ReceiverId, previousDataKey)
+			endQuerySpan(span, err)
+			if err != nil {
+				logger.FromContext(ctx).Error("error re-encrypting receiver", "receiver_id", lastId, "error", err)
+				return rewritten, internal_error.NewInternalServerError("error re-encrypting receivers", err)
+			}
+
+			if rowsAffected, _ := res.RowsAffected(); rowsAffected > 0 {
+				rewritten++
+			}
+		}
+
+		if len(batch) < reencryptBatchSize {
+			return rewritten, nil
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package receiver_repository_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/configuration/database/sqlite"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/encryption"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newKeyring returns a keyring whose master keys are filled with the given bytes,
+// the first one current.
+func newKeyring(t *testing.T, masterKeys ...byte) *encryption.Keyring {
+	keys := make([][]byte, 0, len(masterKeys))
+	for _, b := range masterKeys {
+		keys = append(keys, bytes.Repeat([]byte{b}, encryption.KeySize))
+	}
+
+	keyring, err := encryption.NewKeyring(keys, bytes.Repeat([]byte{9}, encryption.KeySize))
+	require.NoError(t, err)
+	return keyring
+}
+
+type storedReceiver struct {
+	Document        string `db:"document"`
+	PixKey          string `db:"pix_key"`
+	PixKeyCanonical string `db:"pix_key_canonical"`
+	AccountNumber   string `db:"account_number"`
+	DataKey         string `db:"data_key"`
+}
+
+func openEncryptionTestDatabase(t *testing.T) *sqlx.DB {
+	db, err := sqlite.InitializeDatabase(context.Background(), filepath.Join(t.TempDir(), "pix.db"), true)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func createEncryptionTestReceiver(t *testing.T, ctx context.Context, repo entity.ReceiverRepositoryInterface) *entity.Receiver {
+	receiver, err := entity.NewReceiver("123.456.789-09", "joao@example.com", "email", "João", "joao@example.com")
+	require.Nil(t, err)
+	receiver.Bank = "Nubank"
+	receiver.Office = "0001"
+	receiver.AccountNumber = "123456"
+	require.Nil(t, repo.CreateReceiver(ctx, receiver))
+
+	return receiver
+}
+
+func findStoredReceiver(t *testing.T, db *sqlx.DB, receiver *entity.Receiver) storedReceiver {
+	var stored storedReceiver
+	require.NoError(t, db.Get(&stored, "SELECT document, pix_key, pix_key_canonical, account_number, data_key FROM receivers WHERE receiver_id = $1", receiver.ReceiverId))
+	return stored
+}
+
+func TestEncryptedReceiverIsStoredAsCiphertext(t *testing.T) {
+	db := openEncryptionTestDatabase(t)
+	ctx := auth.WithTenant(context.Background(), auth.DefaultTenant)
+
+	repo := receiver_repository.NewSQLiteReceiverRepository(db)
+	repo.Keyring = newKeyring(t, 1)
+	receiver := createEncryptionTestReceiver(t, ctx, repo)
+
+	stored := findStoredReceiver(t, db, receiver)
+	assert.NotEmpty(t, stored.DataKey)
+	for _, value := range []string{stored.Document, stored.PixKey, stored.PixKeyCanonical, stored.AccountNumber} {
+		assert.NotContains(t, value, "123")
+		assert.NotContains(t, value, "joao")
+	}
+
+	found, err := repo.FindReceiver(ctx, receiver.ReceiverId)
+	require.Nil(t, err)
+	assert.Equal(t, "123.456.789-09", found.Document.String())
+	assert.Equal(t, "joao@example.com", found.PixKey.KeyValue)
+	assert.Equal(t, "123456", found.AccountNumber)
+
+	byPixKey, err := repo.FindReceiverByPixKey(ctx, "joao@example.com")
+	require.Nil(t, err)
+	assert.Equal(t, receiver.ReceiverId, byPixKey.ReceiverId)
+
+	count, err := repo.CountReceiversByOwner(ctx, receiver.Owner())
+	require.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	_, err = receiver_repository.NewSQLiteReceiverRepository(db).FindReceiver(ctx, receiver.ReceiverId)
+	require.NotNil(t, err)
+	assert.Equal(t, "internal_server_error", err.Err)
+
+	_, err = receiver_repository.NewSQLiteReceiverRepository(db).ReencryptReceivers(ctx, false)
+	require.NotNil(t, err)
+}
+
+func TestReencryptReceivers(t *testing.T) {
+	db := openEncryptionTestDatabase(t)
+	ctx := auth.WithTenant(context.Background(), auth.DefaultTenant)
+
+	plaintextRepo := receiver_repository.NewSQLiteReceiverRepository(db)
+	receiver := createEncryptionTestReceiver(t, ctx, plaintextRepo)
+	assert.Equal(t, "123.456.789-09", findStoredReceiver(t, db, receiver).Document)
+
+	repo := receiver_repository.NewSQLiteReceiverRepository(db)
+	repo.Keyring = newKeyring(t, 1)
+
+	rewritten, err := repo.ReencryptReceivers(ctx, false)
+	require.Nil(t, err)
+	assert.Equal(t, 1, rewritten)
+	assert.NotEqual(t, "123.456.789-09", findStoredReceiver(t, db, receiver).Document)
+
+	receivers, err := repo.FindReceivers(ctx, -1, "", "joao@example.com", -1, 1)
+	require.Nil(t, err)
+	require.Len(t, receivers, 1)
+	assert.Equal(t, "123.456.789-09", receivers[0].Document.String())
+
+	rewritten, err = repo.ReencryptReceivers(ctx, false)
+	require.Nil(t, err)
+	assert.Equal(t, 0, rewritten)
+
+	rotatedRepo := receiver_repository.NewSQLiteReceiverRepository(db)
+	rotatedRepo.Keyring = newKeyring(t, 2, 1)
+	rewritten, err = rotatedRepo.ReencryptReceivers(ctx, false)
+	require.Nil(t, err)
+	assert.Equal(t, 1, rewritten)
+
+	withoutOldKey := receiver_repository.NewSQLiteReceiverRepository(db)
+	withoutOldKey.Keyring = newKeyring(t, 2)
+	found, err := withoutOldKey.FindReceiver(ctx, receiver.ReceiverId)
+	require.Nil(t, err)
+	assert.Equal(t, "123456", found.AccountNumber)
+
+	rewritten, err = withoutOldKey.ReencryptReceivers(ctx, true)
+	require.Nil(t, err)
+	assert.Equal(t, 1, rewritten)
+}
+
+func TestEncryptedRepositoryFindsPlaintextReceivers(t *testing.T) {
+	db := openEncryptionTestDatabase(t)
+	ctx := auth.WithTenant(context.Background(), auth.DefaultTenant)
+
+	receiver := createEncryptionTestReceiver(t, ctx, receiver_repository.NewSQLiteReceiverRepository(db))
+
+	repo := receiver_repository.NewSQLiteReceiverRepository(db)
+	repo.Keyring = newKeyring(t, 1)
+
+	byPixKey, err := repo.FindReceiverByPixKey(ctx, "joao@example.com")
+	require.Nil(t, err)
+	assert.Equal(t, receiver.ReceiverId, byPixKey.ReceiverId)
+
+	receivers, err := repo.FindReceivers(ctx, -1, "", "joao@example.com", -1, 1)
+	require.Nil(t, err)
+	assert.Len(t, receivers, 1)
+
+	other, newErr := entity.NewReceiver("123.456.789-09", "joao2@example.com", "email", "João", "joao@example.com")
+	require.Nil(t, newErr)
+	other.Bank = "Nubank"
+	other.Office = "0001"
+	other.AccountNumber = "123456"
+	require.Nil(t, repo.CreateReceiver(ctx, other))
+
+	count, err := repo.CountReceiversByOwner(ctx, receiver.Owner())
+	require.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	copied := other.CopyWithPixKey(byPixKey.PixKey)
+	require.Nil(t, repo.TransferPixKey(ctx, receiver.ReceiverId, copied))
+	_, err = repo.FindReceiver(ctx, receiver.ReceiverId)
+	assert.NotNil(t, err)
+
+	byPixKey, err = repo.FindReceiverByPixKey(ctx, "joao@example.com")
+	require.Nil(t, err)
+	assert.Equal(t, copied.ReceiverId, byPixKey.ReceiverId)
+}
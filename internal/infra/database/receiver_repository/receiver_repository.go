@@ -2,35 +2,83 @@ package receiver_repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"log/slog"
+	"hash/fnv"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/auth"
 	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/transaction"
+	"github.com/felipemagrassi/pix-api/internal/infra/encryption"
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
 	"github.com/felipemagrassi/pix-api/internal/value_object"
 	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ReceiverEntity is a receivers row. With encryption enabled Document, PixKey and
+// AccountNumber hold ciphertext, sealed with the wrapped DataKey, and
+// PixKeyCanonical holds a blind index; see sealReceiverEntity.
 type ReceiverEntity struct {
-	ReceiverId    pkg_entity.ID `db:"receiver_id"`
-	Name          string        `db:"name"`
-	Document      string        `db:"document"`
-	Email         string        `db:"email"`
-	Status        int           `db:"status"`
-	PixKey        string        `db:"pix_key"`
-	PixKeyType    int           `db:"pix_key_type"`
-	Bank          string        `db:"bank"`
-	Office        string        `db:"office"`
-	AccountNumber string        `db:"account_number"`
-	CreatedAt     string        `db:"created_at"`
-	UpdatedAt     string        `db:"updated_at"`
+	ReceiverId pkg_entity.ID `db:"receiver_id" json:"receiver_id"`
+	TenantId   string        `db:"tenant_id" json:"tenant_id"`
+	Name       string        `db:"name" json:"name"`
+	Document   string        `db:"document" json:"document"`
+	Email      string        `db:"email" json:"email"`
+	Status     int           `db:"status" json:"status"`
+	PixKey     string        `db:"pix_key" json:"pix_key"`
+	PixKeyType int           `db:"pix_key_type" json:"pix_key_type"`
+	// PixKeyCanonical is unique per tenant, see entity.PixKey.Canonical.
+	PixKeyCanonical    string `db:"pix_key_canonical" json:"pix_key_canonical"`
+	Bank               string `db:"bank" json:"bank"`
+	Office             string `db:"office" json:"office"`
+	AccountNumber      string `db:"account_number" json:"account_number"`
+	DataKey            string `db:"data_key" json:"-"`
+	PixKeyIndex        string `db:"pix_key_index" json:"-"`
+	DocumentIndex      string `db:"document_index" json:"-"`
+	AccountNumberIndex string `db:"account_number_index" json:"-"`
+	CreatedAt          string `db:"created_at" json:"created_at"`
+	UpdatedAt          string `db:"updated_at" json:"updated_at"`
 }
 
+const exportBatchSize = 500
+
+// pixKeyIndex is the unique index on the canonical pix key of each tenant.
+const pixKeyIndex = "receivers_tenant_id_pix_key_canonical_idx"
+
+// countReceiversByOwnerQuery counts the receivers of an owner in both backends.
+// Documents are stored as typed, so they are compared without punctuation.
+const countReceiversByOwnerQuery = "SELECT count(*) FROM receivers WHERE tenant_id = $1 AND replace(replace(replace(document, '.', ''), '-', ''), '/', '') = $2 AND COALESCE(bank, '') = $3 AND COALESCE(office, '') = $4 AND COALESCE(account_number, '') = $5"
+
+// countEncryptedReceiversByOwnerQuery compares the blind indexes of the document
+// digits and the account number of encrypted receivers, and the values of those
+// still in plaintext (data_key empty) until the reencrypt command rewrites them.
+// Placeholders appear in order, as sqlite numbers them by first appearance.
+const countEncryptedReceiversByOwnerQuery = "SELECT count(*) FROM receivers WHERE tenant_id = $1 AND COALESCE(bank, '') = $2 AND COALESCE(office, '') = $3 AND ((data_key <> '' AND document_index = $4 AND account_number_index = $5) OR (data_key = '' AND replace(replace(replace(document, '.', ''), '-', ''), '/', '') = $6 AND COALESCE(account_number, '') = $7))"
+
+// receiversPageSize is the number of receivers returned per page by every backend.
+const receiversPageSize = 10
+
+var tracer = otel.Tracer("github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository")
+
 type ReceiverRepository struct {
 	Db *sqlx.DB
+	// RowLevelSecurity sets app.tenant_id on every transaction, so the receivers
+	// policies are enforced when connecting with a role that is not the table owner.
+	RowLevelSecurity bool
+	// Keyring encrypts documents, pix keys and account numbers when set.
+	Keyring *encryption.Keyring
 }
 
 func NewReceiverRepository(db *sqlx.DB) *ReceiverRepository {
@@ -39,76 +87,270 @@ func NewReceiverRepository(db *sqlx.DB) *ReceiverRepository {
 
 func (r *ReceiverRepository) FindReceiver(ctx context.Context, id pkg_entity.ID) (*entity.Receiver, *internal_error.InternalError) {
 	var receiver ReceiverEntity
-	err := r.Db.GetContext(ctx, &receiver, "SELECT * FROM receivers WHERE receiver_id = $1", id)
-	if err != nil {
-		if err.Error() == "sql: no rows in result set" {
-			return nil, internal_error.NewNotFoundError("receiver not found")
+	findErr := r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		query := "SELECT * FROM receivers WHERE receiver_id = $1 AND tenant_id = $2"
+		queryCtx, span := startQuerySpan(ctx, "SELECT", query)
+		err := sqlx.GetContext(queryCtx, q, &receiver, query, id, tenantId)
+		endQuerySpan(span, err)
+		if err != nil {
+			if err.Error() == "sql: no rows in result set" {
+				return internal_error.NewNotFoundError("receiver not found")
+			}
+			logger.FromContext(ctx).Error("error finding receiver", "error", err)
+			return internal_error.NewNotFoundError("receiver not found")
 		}
-		slog.Error("error finding receiver", err)
-		return nil, internal_error.NewNotFoundError("receiver not found")
+
+		return nil
+	})
+	if findErr != nil {
+		return nil, findErr
 	}
 
-	entity := mapReceiverEntityToReceiver(receiver)
+	entity, mapErr := mapReceiverEntityToReceiver(ctx, r.Keyring, receiver)
+	if mapErr != nil {
+		return nil, mapErr
+	}
 	return &entity, nil
 }
 
-func (r *ReceiverRepository) FindReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, page int) ([]entity.Receiver, *internal_error.InternalError) {
-	var receivers []entity.Receiver
+func (r *ReceiverRepository) FindReceiverByPixKey(ctx context.Context, canonicalPixKey string) (*entity.Receiver, *internal_error.InternalError) {
+	var receiver ReceiverEntity
+	findErr := r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		blindIndex, plaintext := pixKeyLookup(r.Keyring, canonicalPixKey)
+		query := "SELECT * FROM receivers WHERE pix_key_canonical IN ($1, $2) AND tenant_id = $3"
+		queryCtx, span := startQuerySpan(ctx, "SELECT", query)
+		err := sqlx.GetContext(queryCtx, q, &receiver, query, blindIndex, plaintext, tenantId)
+		endQuerySpan(span, err)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				logger.FromContext(ctx).Error("error finding receiver by pix key", "error", err)
+				return internal_error.NewInternalServerError("error finding receiver", err)
+			}
+			return internal_error.NewNotFoundError("receiver not found")
+		}
 
-	baseQuery := "SELECT receiver_id, name, document, bank, office, account_number, status,pix_key, pix_key_type FROM receivers WHERE 1=1"
+		return nil
+	})
+	if findErr != nil {
+		return nil, findErr
+	}
+
+	entity, mapErr := mapReceiverEntityToReceiver(ctx, r.Keyring, receiver)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+	return &entity, nil
+}
 
-	args := []interface{}{}
+func (r *ReceiverRepository) CountReceiversByOwner(ctx context.Context, owner entity.PixKeyOwner) (int, *internal_error.InternalError) {
+	var count int
+	countErr := r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		query, args := countReceiversByOwner(r.Keyring, tenantId, owner)
+		queryCtx, span := startQuerySpan(ctx, "SELECT", query)
+		err := sqlx.GetContext(queryCtx, q, &count, query, args...)
+		endQuerySpan(span, err)
+		if err != nil {
+			logger.FromContext(ctx).Error("error counting receivers by owner", "error", err)
+			return internal_error.NewInternalServerError("error counting receivers", err)
+		}
 
-	if status != -1 {
-		args = append(args, status)
-		baseQuery += " AND status = $" + strconv.Itoa(len(args))
+		return nil
+	})
+	if countErr != nil {
+		return 0, countErr
 	}
 
-	if name != "" {
-		args = append(args, name)
-		baseQuery += " AND name LIKE $" + strconv.Itoa(len(args))
+	return count, nil
+}
+
+// LockPixKeyOwner takes a transaction level advisory lock on the owner in the
+// tenant, released when the unit of work commits or rolls back.
+func (r *ReceiverRepository) LockPixKeyOwner(ctx context.Context, owner entity.PixKeyOwner) *internal_error.InternalError {
+	if _, ok := transaction.FromContext(ctx); !ok {
+		return nil
 	}
 
-	if pixKeyValue != "" {
-		args = append(args, pixKeyValue)
-		baseQuery += " AND pix_key = $" + strconv.Itoa(len(args))
+	return r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		query := "SELECT pg_advisory_xact_lock($1)"
+		queryCtx, span := startQuerySpan(ctx, "SELECT", query)
+		_, err := q.ExecContext(queryCtx, query, pixKeyOwnerLockId(tenantId, owner))
+		endQuerySpan(span, err)
+		if err != nil {
+			logger.FromContext(ctx).Error("error locking pix key owner", "error", err)
+			return internal_error.NewInternalServerError("error locking pix key owner", err)
+		}
+
+		return nil
+	})
+}
+
+// pixKeyOwnerLockId hashes the owner in the tenant to an advisory lock id, so the
+// document and account are not sent to the database in plaintext. Owners sharing
+// an id only wait for each other.
+func pixKeyOwnerLockId(tenantId string, owner entity.PixKeyOwner) int64 {
+	hash := fnv.New64a()
+	for _, part := range []string{tenantId, owner.Document, owner.Bank, owner.Office, owner.AccountNumber} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
 	}
 
-	if pixKeyType != -1 {
-		args = append(args, pixKeyType)
-		baseQuery += " AND pix_key_type = $" + strconv.Itoa(len(args))
+	return int64(hash.Sum64())
+}
+
+func (r *ReceiverRepository) FindReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, page int) ([]entity.Receiver, *internal_error.InternalError) {
+	var receivers []entity.Receiver
+
+	findErr := r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		where, args := buildReceiversFilter(r.Keyring, tenantId, status, name, pixKeyValue, pixKeyType)
+		baseQuery := "SELECT receiver_id, tenant_id, name, document, bank, office, account_number, status,pix_key, pix_key_type, data_key FROM receivers" + where
+
+		limit := receiversPageSize
+		offset := (page - 1) * limit
+
+		baseQuery += " ORDER BY created_at DESC, receiver_id"
+		baseQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+		queryCtx, span := startQuerySpan(ctx, "SELECT", baseQuery)
+		rows, err := q.QueryxContext(queryCtx, baseQuery, args...)
+		if err != nil {
+			endQuerySpan(span, err)
+			logger.FromContext(ctx).Error("error finding receivers", "error", err)
+			return internal_error.NewInternalServerError("error finding receivers", err)
+		}
+		defer func() { endQuerySpan(span, rows.Err()) }()
+		defer rows.Close()
+
+		for rows.Next() {
+			var receiver ReceiverEntity
+			err := rows.StructScan(&receiver)
+			if err != nil {
+				logger.FromContext(ctx).Error("error scanning receiver", "error", err)
+				return internal_error.NewInternalServerError("error finding receivers", err)
+			}
+
+			mapped, mapErr := mapReceiverEntityToReceiver(ctx, r.Keyring, receiver)
+			if mapErr != nil {
+				return mapErr
+			}
+			receivers = append(receivers, mapped)
+		}
+
+		return nil
+	})
+	if findErr != nil {
+		return nil, findErr
 	}
 
-	limit := 10
-	offset := (page - 1) * limit
+	return receivers, nil
+}
+
+// StreamReceivers reads every receiver matching the filters through a server-side
+// cursor, calling fn for each one without loading the whole result set in memory.
+func (r *ReceiverRepository) StreamReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, fn func(receiver *entity.Receiver) *internal_error.InternalError) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	where, args := buildReceiversFilter(r.Keyring, tenantId, status, name, pixKeyValue, pixKeyType)
+	query := "DECLARE receivers_export NO SCROLL CURSOR FOR SELECT * FROM receivers" + where + " ORDER BY created_at DESC, receiver_id"
 
-	baseQuery += " ORDER BY created_at DESC"
-	baseQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+	tx, err := r.Db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.FromContext(ctx).Error("error starting export transaction", "error", err)
+		return internal_error.NewInternalServerError("error exporting receivers", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.setTenant(ctx, tx, tenantId); err != nil {
+		return err
+	}
 
-	rows, err := r.Db.QueryxContext(ctx, baseQuery, args...)
+	declareCtx, span := startQuerySpan(ctx, "DECLARE", query)
+	_, err = tx.ExecContext(declareCtx, query, args...)
+	endQuerySpan(span, err)
 	if err != nil {
-		fmt.Println(err)
-		return nil, internal_error.NewInternalServerError("error finding receivers", err)
+		logger.FromContext(ctx).Error("error declaring export cursor", "error", err)
+		return internal_error.NewInternalServerError("error exporting receivers", err)
 	}
 
-	for rows.Next() {
-		var receiver ReceiverEntity
-		err := rows.StructScan(&receiver)
+	fetchQuery := fmt.Sprintf("FETCH FORWARD %d FROM receivers_export", exportBatchSize)
+	for {
+		fetchCtx, span := startQuerySpan(ctx, "FETCH", fetchQuery)
+		rows, err := tx.QueryxContext(fetchCtx, fetchQuery)
+		endQuerySpan(span, err)
 		if err != nil {
-			fmt.Println(err)
-			return nil, internal_error.NewInternalServerError("error finding receivers", err)
+			logger.FromContext(ctx).Error("error fetching export cursor", "error", err)
+			return internal_error.NewInternalServerError("error exporting receivers", err)
 		}
 
-		receivers = append(receivers, mapReceiverEntityToReceiver(receiver))
+		fetched := 0
+		for rows.Next() {
+			var receiverEntity ReceiverEntity
+			if err := rows.StructScan(&receiverEntity); err != nil {
+				rows.Close()
+				return internal_error.NewInternalServerError("error exporting receivers", err)
+			}
+			fetched++
+
+			receiver, mapErr := mapReceiverEntityToReceiver(ctx, r.Keyring, receiverEntity)
+			if mapErr != nil {
+				rows.Close()
+				return mapErr
+			}
+			if err := fn(&receiver); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return internal_error.NewInternalServerError("error exporting receivers", err)
+		}
+		rows.Close()
+
+		if fetched < exportBatchSize {
+			break
+		}
 	}
 
-	return receivers, nil
+	closeCtx, span := startQuerySpan(ctx, "CLOSE", "CLOSE receivers_export")
+	_, err = tx.ExecContext(closeCtx, "CLOSE receivers_export")
+	endQuerySpan(span, err)
+	if err != nil {
+		return internal_error.NewInternalServerError("error exporting receivers", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return internal_error.NewInternalServerError("error exporting receivers", err)
+	}
+
+	return nil
 }
 
 func (r *ReceiverRepository) CreateReceiver(ctx context.Context, receiver *entity.Receiver) *internal_error.InternalError {
-	_, err := r.Db.ExecContext(ctx, "INSERT INTO receivers (receiver_id, name, document, email, status, pix_key, pix_key_type, bank, office, account_number, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)", receiver.ReceiverId, receiver.Name, receiver.Document.String(), receiver.Email.String(), receiver.GetStatus(), receiver.PixKey.KeyValue, receiver.PixKey.KeyType.Value(), receiver.Bank, receiver.Office, receiver.AccountNumber, receiver.CreatedAt, receiver.UpdatedAt)
+	return r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		return r.insertReceiver(ctx, q, tenantId, receiver)
+	})
+}
+
+func (r *ReceiverRepository) insertReceiver(ctx context.Context, q sqlx.ExtContext, tenantId string, receiver *entity.Receiver) *internal_error.InternalError {
+	receiver.TenantId = tenantId
+	sealed, sealErr := sealReceiver(ctx, r.Keyring, receiver)
+	if sealErr != nil {
+		return sealErr
+	}
+
+	query := "INSERT INTO receivers (receiver_id, tenant_id, name, document, email, status, pix_key, pix_key_type, pix_key_canonical, bank, office, account_number, data_key, pix_key_index, document_index, account_number_index, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)"
+	queryCtx, span := startQuerySpan(ctx, "INSERT", query)
+	_, err := q.ExecContext(queryCtx, query, receiver.ReceiverId, tenantId, receiver.Name, sealed.Document, receiver.Email.String(), receiver.GetStatus(), sealed.PixKey, receiver.PixKey.KeyType.Value(), sealed.PixKeyCanonical, receiver.Bank, receiver.Office, sealed.AccountNumber, sealed.DataKey, sealed.PixKeyIndex, sealed.DocumentIndex, sealed.AccountNumberIndex, receiver.CreatedAt, receiver.UpdatedAt)
+	endQuerySpan(span, err)
+	if isPixKeyViolation(err) {
+		return entity.NewPixKeyConflictError()
+	}
 	if err != nil {
-		slog.Error("error creating receiver", err)
+		logger.FromContext(ctx).Error("error creating receiver", "error", err)
 		return internal_error.NewInternalServerError("error creating receiver", err)
 	}
 
@@ -116,15 +358,55 @@ func (r *ReceiverRepository) CreateReceiver(ctx context.Context, receiver *entit
 }
 
 func (r *ReceiverRepository) UpdateReceiver(ctx context.Context, receiver *entity.Receiver) *internal_error.InternalError {
-	_, err := r.Db.ExecContext(ctx, "UPDATE receivers SET name = $1, document = $2, email = $3, status = $4, pix_key = $5, pix_key_type = $6, bank = $7, office = $8, account_number = $9, updated_at = $10 WHERE receiver_id = $11", receiver.Name, receiver.Document.String(), receiver.Email.String(), receiver.GetStatus(), receiver.PixKey.KeyValue, receiver.PixKey.KeyType.Value(), receiver.Bank, receiver.Office, receiver.AccountNumber, receiver.UpdatedAt, receiver.ReceiverId)
+	return r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		return r.updateReceiver(ctx, q, tenantId, receiver)
+	})
+}
+
+func (r *ReceiverRepository) TransferPixKey(ctx context.Context, donorId pkg_entity.ID, receiver *entity.Receiver) *internal_error.InternalError {
+	return r.runInTransaction(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		blindIndex, plaintext := pixKeyLookup(r.Keyring, receiver.PixKey.Canonical())
+		query := "DELETE FROM receivers WHERE receiver_id = $1 AND tenant_id = $2 AND pix_key_canonical IN ($3, $4)"
+		queryCtx, span := startQuerySpan(ctx, "DELETE", query)
+		_, err := q.ExecContext(queryCtx, query, donorId, tenantId, blindIndex, plaintext)
+		endQuerySpan(span, err)
+		if err != nil {
+			logger.FromContext(ctx).Error("error deleting pix key donor", "error", err)
+			return internal_error.NewInternalServerError("error transferring pix key", err)
+		}
+
+		return r.insertReceiver(ctx, q, tenantId, receiver)
+	})
+}
+
+func (r *ReceiverRepository) updateReceiver(ctx context.Context, q sqlx.ExtContext, tenantId string, receiver *entity.Receiver) *internal_error.InternalError {
+	sealed, sealErr := sealReceiver(ctx, r.Keyring, receiver)
+	if sealErr != nil {
+		return sealErr
+	}
+
+	query := "UPDATE receivers SET name = $1, document = $2, email = $3, status = $4, pix_key = $5, pix_key_type = $6, pix_key_canonical = $7, bank = $8, office = $9, account_number = $10, data_key = $11, pix_key_index = $12, document_index = $13, account_number_index = $14, updated_at = $15 WHERE receiver_id = $16 AND tenant_id = $17"
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", query)
+	_, err := q.ExecContext(queryCtx, query, receiver.Name, sealed.Document, receiver.Email.String(), receiver.GetStatus(), sealed.PixKey, receiver.PixKey.KeyType.Value(), sealed.PixKeyCanonical, receiver.Bank, receiver.Office, sealed.AccountNumber, sealed.DataKey, sealed.PixKeyIndex, sealed.DocumentIndex, sealed.AccountNumberIndex, receiver.UpdatedAt, receiver.ReceiverId, tenantId)
+	endQuerySpan(span, err)
+	if isPixKeyViolation(err) {
+		return entity.NewPixKeyConflictError()
+	}
 	if err != nil {
-		slog.Error("error updating receiver", err)
+		logger.FromContext(ctx).Error("error updating receiver", "error", err)
 		return internal_error.NewInternalServerError("error updating receiver", err)
 	}
 
 	return nil
 }
 
+// ReencryptReceivers encrypts the receivers in plaintext and re-encrypts those of a
+// previous master key, or every receiver when all is set. It reads the table across
+// tenants, so with row level security it must run as the table owner.
+func (r *ReceiverRepository) ReencryptReceivers(ctx context.Context, all bool) (int, *internal_error.InternalError) {
+	return reencryptReceivers(ctx, r.Db, r.Keyring, all, startQuerySpan)
+}
+
 func (r *ReceiverRepository) DeleteManyReceivers(ctx context.Context, ids []pkg_entity.ID) *internal_error.InternalError {
 	idsString := ""
 	for i, id := range ids {
@@ -135,21 +417,181 @@ func (r *ReceiverRepository) DeleteManyReceivers(ctx context.Context, ids []pkg_
 		}
 	}
 
-	query := fmt.Sprintf("DELETE FROM receivers WHERE receiver_id = ANY('{%s}')", idsString)
-	res, err := r.Db.ExecContext(ctx, query)
+	return r.runInTenant(ctx, func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError {
+		query := fmt.Sprintf("DELETE FROM receivers WHERE tenant_id = $1 AND receiver_id = ANY('{%s}')", idsString)
+		queryCtx, span := startQuerySpan(ctx, "DELETE", query)
+		res, err := q.ExecContext(queryCtx, query, tenantId)
+		endQuerySpan(span, err)
+		if err != nil {
+			logger.FromContext(ctx).Error("error deleting receivers", "error", err)
+			return internal_error.NewInternalServerError("error deleting receivers", err)
+		}
+
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			return internal_error.NewNotFoundError("receivers not found")
+		}
+
+		return nil
+	})
+}
+
+// runInTenant runs fn with the tenant resolved from the context. When row level
+// security is enabled, or inside a unit of work, it runs inside a transaction with
+// app.tenant_id set.
+func (r *ReceiverRepository) runInTenant(ctx context.Context, fn func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError) *internal_error.InternalError {
+	if _, ok := transaction.FromContext(ctx); ok || r.RowLevelSecurity {
+		return r.runInTransaction(ctx, fn)
+	}
+
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	return fn(r.Db, tenantId)
+}
+
+// runInTransaction runs fn inside a transaction, committed when fn succeeds, with
+// app.tenant_id set when row level security is enabled. Inside a unit of work fn
+// runs in its transaction, committed by the transaction manager.
+func (r *ReceiverRepository) runInTransaction(ctx context.Context, fn func(q sqlx.ExtContext, tenantId string) *internal_error.InternalError) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	if tx, ok := transaction.FromContext(ctx); ok {
+		if err := r.setTenant(ctx, tx, tenantId); err != nil {
+			return err
+		}
+
+		return fn(tx, tenantId)
+	}
+
+	tx, err := r.Db.BeginTxx(ctx, nil)
 	if err != nil {
-		slog.Error("error deleting receivers", err)
-		return internal_error.NewInternalServerError("error deleting receivers", err)
+		return internal_error.NewInternalServerError("error starting transaction", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.setTenant(ctx, tx, tenantId); err != nil {
+		return err
+	}
+
+	if err := fn(tx, tenantId); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return internal_error.NewInternalServerError("error committing transaction", err)
+	}
+
+	return nil
+}
+
+func (r *ReceiverRepository) setTenant(ctx context.Context, tx *sqlx.Tx, tenantId string) *internal_error.InternalError {
+	if !r.RowLevelSecurity {
+		return nil
 	}
 
-	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
-		return internal_error.NewNotFoundError("receivers not found")
+	query := "SELECT set_config('app.tenant_id', $1, true)"
+	queryCtx, span := startQuerySpan(ctx, "SELECT", query)
+	_, err := tx.ExecContext(queryCtx, query, tenantId)
+	endQuerySpan(span, err)
+	if err != nil {
+		return internal_error.NewInternalServerError("error setting tenant", err)
 	}
 
 	return nil
 }
 
-func mapReceiverEntityToReceiver(receiverEntity ReceiverEntity) entity.Receiver {
+// startQuerySpan starts a client span for one SQL statement on the receivers table.
+func startQuerySpan(ctx context.Context, operation, statement string) (context.Context, trace.Span) {
+	return startDBQuerySpan(ctx, semconv.DBSystemPostgreSQL, operation, statement)
+}
+
+func startDBQuerySpan(ctx context.Context, system attribute.KeyValue, operation, statement string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, operation+" receivers",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			system,
+			semconv.DBOperation(operation),
+			semconv.DBSQLTable("receivers"),
+			semconv.DBStatement(statement),
+		),
+	)
+}
+
+// endQuerySpan ends a statement span, recording err unless it only means no rows.
+func endQuerySpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// isPixKeyViolation reports whether err is the unique index on the canonical pix
+// key rejecting a write, which happens when a concurrent request registers the
+// same key after the use case checked it was free.
+func isPixKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == pixKeyIndex
+}
+
+func requireTenant(ctx context.Context) (string, *internal_error.InternalError) {
+	tenantId, ok := auth.TenantFromContext(ctx)
+	if !ok {
+		return "", internal_error.NewInternalServerError("tenant not resolved", nil)
+	}
+
+	return tenantId, nil
+}
+
+// buildReceiversFilter builds the where clause of the list filters. The pix key is
+// matched in canonical form, by its blind index with a keyring or by its value for
+// the receivers still in plaintext.
+func buildReceiversFilter(keyring *encryption.Keyring, tenantId string, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType) (string, []interface{}) {
+	where := " WHERE tenant_id = $1"
+	args := []interface{}{tenantId}
+
+	if status != -1 {
+		args = append(args, status)
+		where += " AND status = $" + strconv.Itoa(len(args))
+	}
+
+	if name != "" {
+		args = append(args, name)
+		where += " AND name LIKE $" + strconv.Itoa(len(args))
+	}
+
+	if pixKeyValue != "" {
+		var placeholders []string
+		for _, canonical := range entity.PixKeyFilterCanonicals(pixKeyValue, pixKeyType) {
+			blindIndex, plaintext := pixKeyLookup(keyring, canonical)
+			args = append(args, blindIndex, plaintext)
+			placeholders = append(placeholders, "$"+strconv.Itoa(len(args)-1), "$"+strconv.Itoa(len(args)))
+		}
+		where += " AND pix_key_canonical IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	if pixKeyType != -1 {
+		args = append(args, pixKeyType)
+		where += " AND pix_key_type = $" + strconv.Itoa(len(args))
+	}
+
+	return where, args
+}
+
+// mapReceiverEntityToReceiver decrypts the entity when it is encrypted, so the
+// receiver always holds plaintext.
+func mapReceiverEntityToReceiver(ctx context.Context, keyring *encryption.Keyring, receiverEntity ReceiverEntity) (entity.Receiver, *internal_error.InternalError) {
+	if err := openReceiverEntity(keyring, &receiverEntity); err != nil {
+		logger.FromContext(ctx).Error("error decrypting receiver", "receiver_id", receiverEntity.ReceiverId.String(), "error", err)
+		return entity.Receiver{}, internal_error.NewInternalServerError("error decrypting receiver", err)
+	}
+
 	document, _ := value_object.NewDocument(receiverEntity.Document)
 	email, _ := value_object.NewEmail(receiverEntity.Email)
 
@@ -159,6 +601,7 @@ func mapReceiverEntityToReceiver(receiverEntity ReceiverEntity) entity.Receiver
 
 	receiver := entity.Receiver{
 		ReceiverId:    receiverEntity.ReceiverId,
+		TenantId:      receiverEntity.TenantId,
 		Name:          receiverEntity.Name,
 		Document:      document,
 		Email:         email,
@@ -182,5 +625,5 @@ func mapReceiverEntityToReceiver(receiverEntity ReceiverEntity) entity.Receiver
 		receiver.PixKey = &pixKey
 	}
 
-	return receiver
+	return receiver, nil
 }
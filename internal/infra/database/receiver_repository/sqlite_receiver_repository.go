@@ -0,0 +1,372 @@
+package receiver_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/transaction"
+	"github.com/felipemagrassi/pix-api/internal/infra/encryption"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sqliteTimeLayout has a fixed number of fractional digits and is always written
+// in UTC, so created_at sorts chronologically as text.
+const sqliteTimeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// SQLiteReceiverRepository stores receivers in a sqlite database, for single node
+// deployments and local development. Filters and pagination behave as in
+// ReceiverRepository.
+type SQLiteReceiverRepository struct {
+	Db *sqlx.DB
+	// Keyring encrypts documents, pix keys and account numbers when set.
+	Keyring *encryption.Keyring
+}
+
+func NewSQLiteReceiverRepository(db *sqlx.DB) *SQLiteReceiverRepository {
+	return &SQLiteReceiverRepository{Db: db}
+}
+
+func (r *SQLiteReceiverRepository) FindReceiver(ctx context.Context, id pkg_entity.ID) (*entity.Receiver, *internal_error.InternalError) {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return nil, tenantErr
+	}
+
+	var receiver ReceiverEntity
+	query := "SELECT * FROM receivers WHERE receiver_id = $1 AND tenant_id = $2"
+	queryCtx, span := startSQLiteQuerySpan(ctx, "SELECT", query)
+	err := r.Db.GetContext(queryCtx, &receiver, query, id, tenantId)
+	endQuerySpan(span, err)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.FromContext(ctx).Error("error finding receiver", "error", err)
+		}
+		return nil, internal_error.NewNotFoundError("receiver not found")
+	}
+
+	entity, mapErr := mapReceiverEntityToReceiver(ctx, r.Keyring, receiver)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+	return &entity, nil
+}
+
+func (r *SQLiteReceiverRepository) FindReceiverByPixKey(ctx context.Context, canonicalPixKey string) (*entity.Receiver, *internal_error.InternalError) {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return nil, tenantErr
+	}
+
+	var receiver ReceiverEntity
+	blindIndex, plaintext := pixKeyLookup(r.Keyring, canonicalPixKey)
+	query := "SELECT * FROM receivers WHERE pix_key_canonical IN ($1, $2) AND tenant_id = $3"
+	queryCtx, span := startSQLiteQuerySpan(ctx, "SELECT", query)
+	err := r.Db.GetContext(queryCtx, &receiver, query, blindIndex, plaintext, tenantId)
+	endQuerySpan(span, err)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.FromContext(ctx).Error("error finding receiver by pix key", "error", err)
+			return nil, internal_error.NewInternalServerError("error finding receiver", err)
+		}
+		return nil, internal_error.NewNotFoundError("receiver not found")
+	}
+
+	entity, mapErr := mapReceiverEntityToReceiver(ctx, r.Keyring, receiver)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+	return &entity, nil
+}
+
+func (r *SQLiteReceiverRepository) CountReceiversByOwner(ctx context.Context, owner entity.PixKeyOwner) (int, *internal_error.InternalError) {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return 0, tenantErr
+	}
+
+	var count int
+	query, args := countReceiversByOwner(r.Keyring, tenantId, owner)
+	queryCtx, span := startSQLiteQuerySpan(ctx, "SELECT", query)
+	err := sqlx.GetContext(queryCtx, transaction.Querier(ctx, r.Db), &count, query, args...)
+	endQuerySpan(span, err)
+	if err != nil {
+		logger.FromContext(ctx).Error("error counting receivers by owner", "error", err)
+		return 0, internal_error.NewInternalServerError("error counting receivers", err)
+	}
+
+	return count, nil
+}
+
+// LockPixKeyOwner starts writing in the unit of work of ctx. Sqlite allows a single
+// writer, so the unit of work then runs alone until it ends, whatever the owner.
+func (r *SQLiteReceiverRepository) LockPixKeyOwner(ctx context.Context, owner entity.PixKeyOwner) *internal_error.InternalError {
+	tx, ok := transaction.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	query := "UPDATE receivers SET tenant_id = tenant_id WHERE 0"
+	queryCtx, span := startSQLiteQuerySpan(ctx, "UPDATE", query)
+	_, err := tx.ExecContext(queryCtx, query)
+	endQuerySpan(span, err)
+	if err != nil {
+		logger.FromContext(ctx).Error("error locking pix key owner", "error", err)
+		return internal_error.NewInternalServerError("error locking pix key owner", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteReceiverRepository) FindReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, page int) ([]entity.Receiver, *internal_error.InternalError) {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return nil, tenantErr
+	}
+
+	where, args := buildReceiversFilter(r.Keyring, tenantId, status, name, pixKeyValue, pixKeyType)
+	query := "SELECT receiver_id, tenant_id, name, document, bank, office, account_number, status, pix_key, pix_key_type, data_key FROM receivers" + where + " ORDER BY created_at DESC, receiver_id LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
+
+	args = append(args, receiversPageSize, (page-1)*receiversPageSize)
+
+	queryCtx, span := startSQLiteQuerySpan(ctx, "SELECT", query)
+	rows, err := r.Db.QueryxContext(queryCtx, query, args...)
+	if err != nil {
+		endQuerySpan(span, err)
+		logger.FromContext(ctx).Error("error finding receivers", "error", err)
+		return nil, internal_error.NewInternalServerError("error finding receivers", err)
+	}
+	defer func() { endQuerySpan(span, rows.Err()) }()
+	defer rows.Close()
+
+	var receivers []entity.Receiver
+	for rows.Next() {
+		var receiver ReceiverEntity
+		if err := rows.StructScan(&receiver); err != nil {
+			logger.FromContext(ctx).Error("error scanning receiver", "error", err)
+			return nil, internal_error.NewInternalServerError("error finding receivers", err)
+		}
+
+		mapped, mapErr := mapReceiverEntityToReceiver(ctx, r.Keyring, receiver)
+		if mapErr != nil {
+			return nil, mapErr
+		}
+		receivers = append(receivers, mapped)
+	}
+
+	return receivers, nil
+}
+
+// StreamReceivers iterates over the rows of a single query; sqlite steps through
+// the result set lazily, so receivers are not all loaded in memory.
+func (r *SQLiteReceiverRepository) StreamReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, fn func(receiver *entity.Receiver) *internal_error.InternalError) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	where, args := buildReceiversFilter(r.Keyring, tenantId, status, name, pixKeyValue, pixKeyType)
+	query := "SELECT * FROM receivers" + where + " ORDER BY created_at DESC, receiver_id"
+
+	queryCtx, span := startSQLiteQuerySpan(ctx, "SELECT", query)
+	rows, err := r.Db.QueryxContext(queryCtx, query, args...)
+	if err != nil {
+		endQuerySpan(span, err)
+		logger.FromContext(ctx).Error("error exporting receivers", "error", err)
+		return internal_error.NewInternalServerError("error exporting receivers", err)
+	}
+	defer func() { endQuerySpan(span, rows.Err()) }()
+	defer rows.Close()
+
+	for rows.Next() {
+		var receiverEntity ReceiverEntity
+		if err := rows.StructScan(&receiverEntity); err != nil {
+			return internal_error.NewInternalServerError("error exporting receivers", err)
+		}
+
+		receiver, mapErr := mapReceiverEntityToReceiver(ctx, r.Keyring, receiverEntity)
+		if mapErr != nil {
+			return mapErr
+		}
+		if err := fn(&receiver); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return internal_error.NewInternalServerError("error exporting receivers", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteReceiverRepository) CreateReceiver(ctx context.Context, receiver *entity.Receiver) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	return r.insertReceiver(ctx, transaction.Querier(ctx, r.Db), tenantId, receiver)
+}
+
+func (r *SQLiteReceiverRepository) insertReceiver(ctx context.Context, q sqlx.ExecerContext, tenantId string, receiver *entity.Receiver) *internal_error.InternalError {
+	receiver.TenantId = tenantId
+	sealed, sealErr := sealReceiver(ctx, r.Keyring, receiver)
+	if sealErr != nil {
+		return sealErr
+	}
+
+	query := "INSERT INTO receivers (receiver_id, tenant_id, name, document, email, status, pix_key, pix_key_type, pix_key_canonical, bank, office, account_number, data_key, pix_key_index, document_index, account_number_index, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)"
+	queryCtx, span := startSQLiteQuerySpan(ctx, "INSERT", query)
+	_, err := q.ExecContext(queryCtx, query, receiver.ReceiverId, tenantId, receiver.Name, sealed.Document, receiver.Email.String(), receiver.GetStatus(), sealed.PixKey, receiver.PixKey.KeyType.Value(), sealed.PixKeyCanonical, receiver.Bank, receiver.Office, sealed.AccountNumber, sealed.DataKey, sealed.PixKeyIndex, sealed.DocumentIndex, sealed.AccountNumberIndex, formatSQLiteTime(receiver.CreatedAt), formatSQLiteTime(receiver.UpdatedAt))
+	endQuerySpan(span, err)
+	if isSQLitePixKeyViolation(err) {
+		return entity.NewPixKeyConflictError()
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("error creating receiver", "error", err)
+		return internal_error.NewInternalServerError("error creating receiver", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteReceiverRepository) UpdateReceiver(ctx context.Context, receiver *entity.Receiver) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	return r.updateReceiver(ctx, transaction.Querier(ctx, r.Db), tenantId, receiver)
+}
+
+func (r *SQLiteReceiverRepository) TransferPixKey(ctx context.Context, donorId pkg_entity.ID, receiver *entity.Receiver) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	return r.runInTransaction(ctx, func(tx *sqlx.Tx) *internal_error.InternalError {
+		blindIndex, plaintext := pixKeyLookup(r.Keyring, receiver.PixKey.Canonical())
+		query := "DELETE FROM receivers WHERE receiver_id = $1 AND tenant_id = $2 AND pix_key_canonical IN ($3, $4)"
+		queryCtx, span := startSQLiteQuerySpan(ctx, "DELETE", query)
+		_, err := tx.ExecContext(queryCtx, query, donorId, tenantId, blindIndex, plaintext)
+		endQuerySpan(span, err)
+		if err != nil {
+			logger.FromContext(ctx).Error("error deleting pix key donor", "error", err)
+			return internal_error.NewInternalServerError("error transferring pix key", err)
+		}
+
+		return r.insertReceiver(ctx, tx, tenantId, receiver)
+	})
+}
+
+// runInTransaction runs fn inside a transaction committed when fn succeeds, or
+// inside the transaction of the unit of work running with ctx.
+func (r *SQLiteReceiverRepository) runInTransaction(ctx context.Context, fn func(tx *sqlx.Tx) *internal_error.InternalError) *internal_error.InternalError {
+	if tx, ok := transaction.FromContext(ctx); ok {
+		return fn(tx)
+	}
+
+	tx, err := r.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return internal_error.NewInternalServerError("error starting transaction", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return internal_error.NewInternalServerError("error committing transaction", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteReceiverRepository) updateReceiver(ctx context.Context, q sqlx.ExecerContext, tenantId string, receiver *entity.Receiver) *internal_error.InternalError {
+	sealed, sealErr := sealReceiver(ctx, r.Keyring, receiver)
+	if sealErr != nil {
+		return sealErr
+	}
+
+	query := "UPDATE receivers SET name = $1, document = $2, email = $3, status = $4, pix_key = $5, pix_key_type = $6, pix_key_canonical = $7, bank = $8, office = $9, account_number = $10, data_key = $11, pix_key_index = $12, document_index = $13, account_number_index = $14, updated_at = $15 WHERE receiver_id = $16 AND tenant_id = $17"
+	queryCtx, span := startSQLiteQuerySpan(ctx, "UPDATE", query)
+	_, err := q.ExecContext(queryCtx, query, receiver.Name, sealed.Document, receiver.Email.String(), receiver.GetStatus(), sealed.PixKey, receiver.PixKey.KeyType.Value(), sealed.PixKeyCanonical, receiver.Bank, receiver.Office, sealed.AccountNumber, sealed.DataKey, sealed.PixKeyIndex, sealed.DocumentIndex, sealed.AccountNumberIndex, formatSQLiteTime(receiver.UpdatedAt), receiver.ReceiverId, tenantId)
+	endQuerySpan(span, err)
+	if isSQLitePixKeyViolation(err) {
+		return entity.NewPixKeyConflictError()
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("error updating receiver", "error", err)
+		return internal_error.NewInternalServerError("error updating receiver", err)
+	}
+
+	return nil
+}
+
+// ReencryptReceivers encrypts the receivers in plaintext and re-encrypts those of a
+// previous master key, or every receiver when all is set, across tenants.
+func (r *SQLiteReceiverRepository) ReencryptReceivers(ctx context.Context, all bool) (int, *internal_error.InternalError) {
+	return reencryptReceivers(ctx, r.Db, r.Keyring, all, startSQLiteQuerySpan)
+}
+
+func (r *SQLiteReceiverRepository) DeleteManyReceivers(ctx context.Context, ids []pkg_entity.ID) *internal_error.InternalError {
+	tenantId, tenantErr := requireTenant(ctx)
+	if tenantErr != nil {
+		return tenantErr
+	}
+
+	if len(ids) == 0 {
+		return internal_error.NewNotFoundError("receivers not found")
+	}
+
+	args := []interface{}{tenantId}
+	placeholders := make([]string, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+		placeholders = append(placeholders, "$"+strconv.Itoa(len(args)))
+	}
+
+	query := "DELETE FROM receivers WHERE tenant_id = $1 AND receiver_id IN (" + strings.Join(placeholders, ", ") + ")"
+	queryCtx, span := startSQLiteQuerySpan(ctx, "DELETE", query)
+	res, err := r.Db.ExecContext(queryCtx, query, args...)
+	endQuerySpan(span, err)
+	if err != nil {
+		logger.FromContext(ctx).Error("error deleting receivers", "error", err)
+		return internal_error.NewInternalServerError("error deleting receivers", err)
+	}
+
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		return internal_error.NewNotFoundError("receivers not found")
+	}
+
+	return nil
+}
+
+func startSQLiteQuerySpan(ctx context.Context, operation, statement string) (context.Context, trace.Span) {
+	return startDBQuerySpan(ctx, semconv.DBSystemSqlite, operation, statement)
+}
+
+// isSQLitePixKeyViolation reports whether err is the unique index on the canonical
+// pix key rejecting a write, the only unique constraint on receivers besides the
+// primary key.
+func isSQLitePixKeyViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
+
+func formatSQLiteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeLayout)
+}
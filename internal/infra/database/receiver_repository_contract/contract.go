@@ -0,0 +1,483 @@
+// Package receiver_repository_contract is the conformance suite every
+// entity.ReceiverRepositoryInterface backend must pass, so filters, ordering,
+// pagination and errors behave the same whatever DB_DRIVER is used.
+package receiver_repository_contract
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/felipemagrassi/pix-api/internal/value_object"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PageSize is the number of receivers every backend returns per page.
+const PageSize = 10
+
+// Factory returns the repository under test. Every test runs in a tenant of its
+// own, so a factory may return the same repository, or one sharing a database,
+// across tests.
+type Factory func(t *testing.T) entity.ReceiverRepositoryInterface
+
+// Run runs the conformance suite against the repositories returned by newRepository.
+func Run(t *testing.T, newRepository Factory) {
+	tests := map[string]func(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context){
+		"CreateAndFind":           testCreateAndFind,
+		"FindUnknownIsNotFound":   testFindUnknownIsNotFound,
+		"FindByPixKey":            testFindByPixKey,
+		"PixKeyConflict":          testPixKeyConflict,
+		"TransferPixKey":          testTransferPixKey,
+		"CountByOwner":            testCountByOwner,
+		"TenantIsolation":         testTenantIsolation,
+		"MissingTenant":           testMissingTenant,
+		"FilterByStatus":          testFilterByStatus,
+		"FilterByName":            testFilterByName,
+		"FilterByPixKey":          testFilterByPixKey,
+		"OrderAndPagination":      testOrderAndPagination,
+		"OrderTieBreaker":         testOrderTieBreaker,
+		"Stream":                  testStream,
+		"Update":                  testUpdate,
+		"UpdateUnknownIsNoop":     testUpdateUnknownIsNoop,
+		"DeleteMany":              testDeleteMany,
+		"DeleteUnknownIsNotFound": testDeleteUnknownIsNotFound,
+		"ConcurrentCreates":       testConcurrentCreates,
+		"ConcurrentUpdates":       testConcurrentUpdates,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := auth.WithTenant(context.Background(), "contract-"+pkg_entity.NewID().String())
+			test(t, newRepository(t), ctx)
+		})
+	}
+}
+
+// baseTime has no sub-microsecond part, which postgres timestamps would drop.
+var baseTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newReceiver(t *testing.T, name, pixKeyValue, pixKeyType string, createdAt time.Time) *entity.Receiver {
+	receiver, err := entity.NewReceiver("12345678909", pixKeyValue, pixKeyType, name, "contract@email.com")
+	require.Nil(t, err)
+
+	receiver.Bank = "Nubank"
+	receiver.Office = "0001"
+	receiver.AccountNumber = "123456"
+	receiver.CreatedAt = createdAt
+	receiver.UpdatedAt = createdAt
+
+	return receiver
+}
+
+// create creates a receiver with a random pix key, as keys are unique per tenant.
+func create(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context, name string, createdAt time.Time) *entity.Receiver {
+	receiver := newReceiver(t, name, pkg_entity.NewID().String(), "random", createdAt)
+	require.Nil(t, repo.CreateReceiver(ctx, receiver))
+
+	return receiver
+}
+
+func ids(receivers []entity.Receiver) []pkg_entity.ID {
+	result := make([]pkg_entity.ID, 0, len(receivers))
+	for _, receiver := range receivers {
+		result = append(result, receiver.ReceiverId)
+	}
+
+	return result
+}
+
+func names(receivers []entity.Receiver) []string {
+	result := make([]string, 0, len(receivers))
+	for _, receiver := range receivers {
+		result = append(result, receiver.Name)
+	}
+
+	return result
+}
+
+func assertErrKind(t *testing.T, kind string, err *internal_error.InternalError) {
+	t.Helper()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, kind, err.Err)
+	}
+}
+
+func testCreateAndFind(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	created := newReceiver(t, "Felipe", "contract@email.com", "email", baseTime)
+	require.Nil(t, repo.CreateReceiver(ctx, created))
+	tenantId, _ := auth.TenantFromContext(ctx)
+	assert.Equal(t, tenantId, created.TenantId)
+
+	found, err := repo.FindReceiver(ctx, created.ReceiverId)
+	require.Nil(t, err)
+	assert.Equal(t, created.ReceiverId, found.ReceiverId)
+	assert.Equal(t, tenantId, found.TenantId)
+	assert.Equal(t, "Felipe", found.Name)
+	assert.Equal(t, "12345678909", found.Document.String())
+	assert.Equal(t, "contract@email.com", found.Email.String())
+	assert.Equal(t, entity.Draft, found.Status)
+	assert.Equal(t, "Nubank", found.Bank)
+	assert.Equal(t, "0001", found.Office)
+	assert.Equal(t, "123456", found.AccountNumber)
+	assert.True(t, baseTime.Equal(found.CreatedAt), "created_at %s", found.CreatedAt)
+	require.NotNil(t, found.PixKey)
+	assert.Equal(t, "contract@email.com", found.PixKey.KeyValue)
+	assert.Equal(t, entity.EmailKeyType, found.PixKey.KeyType.Value())
+}
+
+func testFindUnknownIsNotFound(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	_, err := repo.FindReceiver(ctx, pkg_entity.NewID())
+	assertErrKind(t, "not_found", err)
+}
+
+func testFindByPixKey(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	cpf := newReceiver(t, "Felipe", "123.456.789-09", "cpf", baseTime)
+	require.Nil(t, repo.CreateReceiver(ctx, cpf))
+	create(t, repo, ctx, "Maria", baseTime.Add(time.Minute))
+
+	found, err := repo.FindReceiverByPixKey(ctx, "12345678909")
+	require.Nil(t, err)
+	assert.Equal(t, cpf.ReceiverId, found.ReceiverId)
+	assert.Equal(t, "123.456.789-09", found.PixKey.KeyValue, "the key is kept as written")
+
+	_, err = repo.FindReceiverByPixKey(ctx, "123.456.789-09")
+	assertErrKind(t, "not_found", err)
+
+	otherCtx := auth.WithTenant(context.Background(), "contract-"+pkg_entity.NewID().String())
+	_, err = repo.FindReceiverByPixKey(otherCtx, "12345678909")
+	assertErrKind(t, "not_found", err)
+}
+
+func testPixKeyConflict(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	holder := newReceiver(t, "Felipe", "12345678909", "cpf", baseTime)
+	require.Nil(t, repo.CreateReceiver(ctx, holder))
+
+	duplicate := newReceiver(t, "Maria", "123.456.789-09", "cpf", baseTime.Add(time.Minute))
+	assertErrKind(t, "conflict", repo.CreateReceiver(ctx, duplicate))
+
+	otherCtx := auth.WithTenant(context.Background(), "contract-"+pkg_entity.NewID().String())
+	assert.Nil(t, repo.CreateReceiver(otherCtx, newReceiver(t, "Maria", "12345678909", "cpf", baseTime)), "keys are unique per tenant")
+
+	other := create(t, repo, ctx, "Maria", baseTime.Add(time.Minute))
+	require.Nil(t, other.UpdateReceiver("", "12345678909", "cpf", "", ""))
+	assertErrKind(t, "conflict", repo.UpdateReceiver(ctx, other))
+
+	require.Nil(t, holder.UpdateReceiver("", "", "", "Felipe Magrassi", ""))
+	assert.Nil(t, repo.UpdateReceiver(ctx, holder), "a receiver keeps its own key")
+
+	receivers, err := repo.FindReceivers(ctx, -1, "", "", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Maria", "Felipe Magrassi"}, names(receivers))
+}
+
+func testTransferPixKey(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	donor := newReceiver(t, "Felipe", "felipe@email.com", "email", baseTime)
+	require.Nil(t, repo.CreateReceiver(ctx, donor))
+	claimer := create(t, repo, ctx, "Maria", baseTime.Add(time.Minute))
+
+	copied := claimer.CopyWithPixKey(donor.PixKey)
+	require.Nil(t, repo.TransferPixKey(ctx, donor.ReceiverId, copied))
+
+	_, err := repo.FindReceiver(ctx, donor.ReceiverId)
+	assertErrKind(t, "not_found", err)
+
+	holder, err := repo.FindReceiverByPixKey(ctx, "felipe@email.com")
+	require.Nil(t, err)
+	assert.Equal(t, copied.ReceiverId, holder.ReceiverId)
+	assert.Equal(t, "Maria", holder.Name)
+
+	found, err := repo.FindReceiver(ctx, claimer.ReceiverId)
+	require.Nil(t, err)
+	assert.Equal(t, claimer.PixKey.KeyValue, found.PixKey.KeyValue, "the claimer keeps its key")
+
+	other := create(t, repo, ctx, "Joana", baseTime.Add(2*time.Minute))
+	assertErrKind(t, "conflict", repo.TransferPixKey(ctx, donor.ReceiverId, other.CopyWithPixKey(donor.PixKey)))
+
+	receivers, err := repo.FindReceivers(ctx, -1, "", "", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Maria", "Joana", "Maria"}, names(receivers), "a failed transfer deletes nobody")
+}
+
+func testCountByOwner(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	first := create(t, repo, ctx, "Felipe", baseTime)
+	second := newReceiver(t, "Felipe", "felipe@email.com", "email", baseTime)
+	second.Document = value_object.CPF("123.456.789-09")
+	require.Nil(t, repo.CreateReceiver(ctx, second))
+	otherAccount := newReceiver(t, "Felipe", pkg_entity.NewID().String(), "random", baseTime)
+	otherAccount.AccountNumber = "654321"
+	require.Nil(t, repo.CreateReceiver(ctx, otherAccount))
+
+	count, err := repo.CountReceiversByOwner(ctx, first.Owner())
+	require.Nil(t, err)
+	assert.Equal(t, 2, count, "documents are compared without punctuation")
+
+	count, err = repo.CountReceiversByOwner(auth.WithTenant(context.Background(), "contract-"+pkg_entity.NewID().String()), first.Owner())
+	require.Nil(t, err)
+	assert.Zero(t, count)
+}
+
+func testTenantIsolation(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	created := create(t, repo, ctx, "Felipe", baseTime)
+	otherCtx := auth.WithTenant(context.Background(), "contract-"+pkg_entity.NewID().String())
+
+	_, err := repo.FindReceiver(otherCtx, created.ReceiverId)
+	assertErrKind(t, "not_found", err)
+
+	receivers, err := repo.FindReceivers(otherCtx, -1, "", "", -1, 1)
+	require.Nil(t, err)
+	assert.Empty(t, receivers)
+
+	assertErrKind(t, "not_found", repo.DeleteManyReceivers(otherCtx, []pkg_entity.ID{created.ReceiverId}))
+
+	_, err = repo.FindReceiver(ctx, created.ReceiverId)
+	assert.Nil(t, err)
+}
+
+func testMissingTenant(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	_, err := repo.FindReceivers(context.Background(), -1, "", "", -1, 1)
+	assertErrKind(t, "internal_server_error", err)
+
+	assertErrKind(t, "internal_server_error", repo.CreateReceiver(context.Background(), newReceiver(t, "Felipe", "contract@email.com", "email", baseTime)))
+}
+
+func testFilterByStatus(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	draft := create(t, repo, ctx, "Draft", baseTime)
+
+	valid := newReceiver(t, "Valid", "contract@email.com", "email", baseTime.Add(time.Minute))
+	valid.ValidateReceiverStatus()
+	require.Nil(t, repo.CreateReceiver(ctx, valid))
+
+	all, err := repo.FindReceivers(ctx, -1, "", "", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []pkg_entity.ID{valid.ReceiverId, draft.ReceiverId}, ids(all))
+
+	onlyValid, err := repo.FindReceivers(ctx, entity.Valid, "", "", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []pkg_entity.ID{valid.ReceiverId}, ids(onlyValid))
+
+	onlyDraft, err := repo.FindReceivers(ctx, entity.Draft, "", "", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []pkg_entity.ID{draft.ReceiverId}, ids(onlyDraft))
+}
+
+func testFilterByName(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	create(t, repo, ctx, "Felipe", baseTime)
+	create(t, repo, ctx, "felipe", baseTime.Add(time.Minute))
+	create(t, repo, ctx, "Maria", baseTime.Add(2*time.Minute))
+
+	exact, err := repo.FindReceivers(ctx, -1, "Felipe", "", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Felipe"}, names(exact), "name matching is case sensitive")
+
+	prefix, err := repo.FindReceivers(ctx, -1, "Fel%", "", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Felipe"}, names(prefix))
+
+	wildcard, err := repo.FindReceivers(ctx, -1, "_elipe", "", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"felipe", "Felipe"}, names(wildcard))
+
+	none, err := repo.FindReceivers(ctx, -1, "Feli", "", -1, 1)
+	require.Nil(t, err)
+	assert.Empty(t, none)
+}
+
+func testFilterByPixKey(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	email := newReceiver(t, "Felipe", "contract@email.com", "email", baseTime)
+	require.Nil(t, repo.CreateReceiver(ctx, email))
+
+	cpf := newReceiver(t, "Felipe", "12345678909", "cpf", baseTime.Add(time.Minute))
+	require.Nil(t, repo.CreateReceiver(ctx, cpf))
+
+	byType, err := repo.FindReceivers(ctx, -1, "", "", entity.CpfKeyType, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []pkg_entity.ID{cpf.ReceiverId}, ids(byType))
+
+	byValue, err := repo.FindReceivers(ctx, -1, "", "contract@email.com", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []pkg_entity.ID{email.ReceiverId}, ids(byValue))
+
+	mismatch, err := repo.FindReceivers(ctx, -1, "", "contract@email.com", entity.CpfKeyType, 1)
+	require.Nil(t, err)
+	assert.Empty(t, mismatch)
+
+	phone := newReceiver(t, "Felipe", "+5511999999999", "phone", baseTime.Add(2*time.Minute))
+	require.Nil(t, repo.CreateReceiver(ctx, phone))
+
+	byUnformattedPhone, err := repo.FindReceivers(ctx, -1, "", "11999999999", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []pkg_entity.ID{phone.ReceiverId}, ids(byUnformattedPhone))
+
+	byTypedPhone, err := repo.FindReceivers(ctx, -1, "", "5511999999999", entity.PhoneKeyType, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []pkg_entity.ID{phone.ReceiverId}, ids(byTypedPhone))
+
+	byMixedCaseEmail, err := repo.FindReceivers(ctx, -1, "", "Contract@Email.com", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []pkg_entity.ID{email.ReceiverId}, ids(byMixedCaseEmail))
+
+	byFormattedCpf, err := repo.FindReceivers(ctx, -1, "", "123.456.789-09", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []pkg_entity.ID{cpf.ReceiverId}, ids(byFormattedCpf))
+}
+
+func testOrderAndPagination(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	total := PageSize + 3
+	for i := 0; i < total; i++ {
+		create(t, repo, ctx, fmt.Sprintf("Receiver %02d", i), baseTime.Add(time.Duration(i)*time.Minute))
+	}
+
+	first, err := repo.FindReceivers(ctx, -1, "", "", -1, 1)
+	require.Nil(t, err)
+	require.Len(t, first, PageSize)
+	assert.Equal(t, "Receiver 12", first[0].Name, "newest first")
+	assert.Equal(t, "Receiver 03", first[PageSize-1].Name)
+
+	second, err := repo.FindReceivers(ctx, -1, "", "", -1, 2)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Receiver 02", "Receiver 01", "Receiver 00"}, names(second))
+
+	beyond, err := repo.FindReceivers(ctx, -1, "", "", -1, 3)
+	require.Nil(t, err)
+	assert.Empty(t, beyond)
+}
+
+func testOrderTieBreaker(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	total := PageSize + 2
+	var expected []pkg_entity.ID
+	for i := 0; i < total; i++ {
+		expected = append(expected, create(t, repo, ctx, fmt.Sprintf("Receiver %02d", i), baseTime).ReceiverId)
+	}
+	slices.SortFunc(expected, func(a, b pkg_entity.ID) int { return bytes.Compare(a[:], b[:]) })
+
+	first, err := repo.FindReceivers(ctx, -1, "", "", -1, 1)
+	require.Nil(t, err)
+	second, err := repo.FindReceivers(ctx, -1, "", "", -1, 2)
+	require.Nil(t, err)
+	assert.Equal(t, expected, append(ids(first), ids(second)...), "receivers created at the same time are ordered by id")
+
+	var streamed []pkg_entity.ID
+	err = repo.StreamReceivers(ctx, -1, "", "", -1, func(receiver *entity.Receiver) *internal_error.InternalError {
+		streamed = append(streamed, receiver.ReceiverId)
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, expected, streamed)
+}
+
+func testStream(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	total := PageSize + 2
+	for i := 0; i < total; i++ {
+		create(t, repo, ctx, fmt.Sprintf("Receiver %02d", i), baseTime.Add(time.Duration(i)*time.Minute))
+	}
+
+	var streamed []string
+	err := repo.StreamReceivers(ctx, -1, "", "", -1, func(receiver *entity.Receiver) *internal_error.InternalError {
+		streamed = append(streamed, receiver.Name)
+		return nil
+	})
+	require.Nil(t, err)
+	require.Len(t, streamed, total, "streams every page")
+	assert.Equal(t, "Receiver 11", streamed[0])
+	assert.Equal(t, "Receiver 00", streamed[total-1])
+
+	stop := internal_error.NewInternalServerError("stop", nil)
+	calls := 0
+	err = repo.StreamReceivers(ctx, -1, "", "", -1, func(receiver *entity.Receiver) *internal_error.InternalError {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err, "the callback error is returned as is")
+	assert.Equal(t, 1, calls)
+}
+
+func testUpdate(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	receiver := create(t, repo, ctx, "Felipe", baseTime)
+
+	require.Nil(t, receiver.UpdateReceiver("", "12345678909", "cpf", "Felipe Magrassi", "new@email.com"))
+	require.Nil(t, repo.UpdateReceiver(ctx, receiver))
+
+	found, err := repo.FindReceiver(ctx, receiver.ReceiverId)
+	require.Nil(t, err)
+	assert.Equal(t, "Felipe Magrassi", found.Name)
+	assert.Equal(t, "new@email.com", found.Email.String())
+	assert.Equal(t, "12345678909", found.PixKey.KeyValue)
+	assert.Equal(t, entity.CpfKeyType, found.PixKey.KeyType.Value())
+	assert.True(t, baseTime.Equal(found.CreatedAt), "created_at is kept")
+}
+
+func testUpdateUnknownIsNoop(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	receiver := newReceiver(t, "Felipe", "contract@email.com", "email", baseTime)
+	assert.Nil(t, repo.UpdateReceiver(ctx, receiver))
+
+	_, err := repo.FindReceiver(ctx, receiver.ReceiverId)
+	assertErrKind(t, "not_found", err)
+}
+
+func testDeleteMany(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	first := create(t, repo, ctx, "First", baseTime)
+	second := create(t, repo, ctx, "Second", baseTime.Add(time.Minute))
+	kept := create(t, repo, ctx, "Kept", baseTime.Add(2*time.Minute))
+
+	require.Nil(t, repo.DeleteManyReceivers(ctx, []pkg_entity.ID{first.ReceiverId, second.ReceiverId, pkg_entity.NewID()}))
+
+	receivers, err := repo.FindReceivers(ctx, -1, "", "", -1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []pkg_entity.ID{kept.ReceiverId}, ids(receivers))
+}
+
+func testDeleteUnknownIsNotFound(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	assertErrKind(t, "not_found", repo.DeleteManyReceivers(ctx, []pkg_entity.ID{pkg_entity.NewID()}))
+}
+
+func testConcurrentCreates(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	workers := 8
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			receiver, err := entity.NewReceiver("12345678909", pkg_entity.NewID().String(), "random", fmt.Sprintf("Receiver %d", i), "contract@email.com")
+			if assert.Nil(t, err) {
+				receiver.CreatedAt = baseTime.Add(time.Duration(i) * time.Minute)
+				assert.Nil(t, repo.CreateReceiver(ctx, receiver))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	receivers, err := repo.FindReceivers(ctx, -1, "", "", -1, 1)
+	require.Nil(t, err)
+	assert.Len(t, receivers, workers)
+}
+
+func testConcurrentUpdates(t *testing.T, repo entity.ReceiverRepositoryInterface, ctx context.Context) {
+	receiver := create(t, repo, ctx, "Felipe", baseTime)
+	workers := 8
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			update := *receiver
+			update.Name = fmt.Sprintf("Name %d", i)
+			assert.Nil(t, repo.UpdateReceiver(ctx, &update))
+		}(i)
+	}
+	wg.Wait()
+
+	found, err := repo.FindReceiver(ctx, receiver.ReceiverId)
+	require.Nil(t, err)
+	assert.Regexp(t, `^Name \d$`, found.Name, "one of the updates wins as a whole")
+}
@@ -0,0 +1,54 @@
+// Package snapshot persists the in-memory repositories as JSON files.
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Save writes v to path through a temporary file renamed over it, so a crash
+// while saving leaves the previous snapshot intact.
+func Save(path string, v any) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err := json.NewEncoder(file).Encode(v); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+// Load reads the snapshot at path into v. It returns false without error when
+// there is no snapshot yet.
+func Load(path string, v any) (bool, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(content, v); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/database/postgres"
+	"github.com/felipemagrassi/pix-api/configuration/database/sqlite"
+	"github.com/felipemagrassi/pix-api/configuration/env"
+	"github.com/felipemagrassi/pix-api/db/migrations"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/api_key_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/claim_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/transaction"
+	"github.com/felipemagrassi/pix-api/internal/infra/encryption"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/jmoiron/sqlx"
+)
+
+// Migrator is implemented by the postgres and sqlite migrators.
+type Migrator interface {
+	Up(ctx context.Context) error
+	Down(ctx context.Context, steps int) error
+	Goto(ctx context.Context, version uint) error
+	Force(ctx context.Context, version int) error
+	Version(ctx context.Context) (version uint, dirty bool, err error)
+}
+
+// Reencrypter rewrites the receivers and claims of the postgres and sqlite
+// repositories with the current encryption keys.
+type Reencrypter interface {
+	ReencryptReceivers(ctx context.Context, all bool) (int, *internal_error.InternalError)
+	ReencryptClaims(ctx context.Context, all bool) (int, *internal_error.InternalError)
+}
+
+// reencrypter combines the receiver and claim repositories of a sql database.
+type reencrypter struct {
+	receivers interface {
+		ReencryptReceivers(ctx context.Context, all bool) (int, *internal_error.InternalError)
+	}
+	claims *claim_repository.ClaimRepository
+}
+
+func (r reencrypter) ReencryptReceivers(ctx context.Context, all bool) (int, *internal_error.InternalError) {
+	return r.receivers.ReencryptReceivers(ctx, all)
+}
+
+func (r reencrypter) ReencryptClaims(ctx context.Context, all bool) (int, *internal_error.InternalError) {
+	return r.claims.ReencryptClaims(ctx, all)
+}
+
+// Storage holds the repositories of DB_DRIVER. DB, Migrator and Reencrypter are
+// nil, and SchemaVersion zero, with the memory driver.
+type Storage struct {
+	DB          *sqlx.DB
+	Migrator    Migrator
+	Reencrypter Reencrypter
+	// SchemaVersion is the newest migration embedded in the binary, which the
+	// database must be at.
+	SchemaVersion uint
+	Receivers     entity.ReceiverRepositoryInterface
+	ApiKeys       entity.ApiKeyRepositoryInterface
+	Claims        entity.ClaimRepositoryInterface
+	// Transactions runs writes to several repositories as one unit of work.
+	Transactions entity.TransactionManagerInterface
+	close        func() error
+}
+
+func (s *Storage) Close() error {
+	return s.close()
+}
+
+// Open opens the storage of DB_DRIVER, applying the pending migrations of a sql
+// database when autoMigrate is set.
+func Open(ctx context.Context, config *env.Config, autoMigrate bool) (*Storage, error) {
+	if config.DBDriver == "memory" {
+		return openMemory(config.DBSnapshotDir, config.DBSnapshotInterval)
+	}
+
+	keyring, err := encryption.Load(config.EncryptionMasterKey, config.EncryptionMasterKeyFile, config.EncryptionIndexKey)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Storage{}
+	if config.DBDriver == "sqlite" {
+		db, err := sqlite.InitializeDatabase(ctx, config.DBUrl, autoMigrate)
+		if err != nil {
+			return nil, err
+		}
+
+		receiverRepo := receiver_repository.NewSQLiteReceiverRepository(db)
+		receiverRepo.Keyring = keyring
+		claimRepo := claim_repository.NewSQLiteClaimRepository(db)
+		claimRepo.Keyring = keyring
+
+		store.DB = db
+		store.Migrator = sqlite.NewMigrator(db.DB)
+		if store.SchemaVersion, err = migrations.LatestVersion(migrations.SQLiteFS, "sqlite"); err != nil {
+			db.Close()
+			return nil, err
+		}
+		store.Reencrypter = reencrypter{receivers: receiverRepo, claims: claimRepo}
+		store.Receivers = receiverRepo
+		store.Claims = claimRepo
+	} else {
+		db, err := postgres.InitializeDatabase(ctx, config.DBUrl, postgres.MigrateConfig{
+			AutoMigrate: autoMigrate,
+			LockTimeout: config.DBMigrateLockTimeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		receiverRepo := receiver_repository.NewReceiverRepository(db)
+		receiverRepo.RowLevelSecurity = config.DBRowLevelSecurity
+		receiverRepo.Keyring = keyring
+		claimRepo := claim_repository.NewClaimRepository(db)
+		claimRepo.RowLevelSecurity = config.DBRowLevelSecurity
+		claimRepo.Keyring = keyring
+
+		store.DB = db
+		store.Migrator = postgres.NewMigrator(db.DB, config.DBMigrateLockTimeout)
+		if store.SchemaVersion, err = migrations.LatestVersion(migrations.FS, "."); err != nil {
+			db.Close()
+			return nil, err
+		}
+		store.Reencrypter = reencrypter{receivers: receiverRepo, claims: claimRepo}
+		store.Receivers = receiverRepo
+		store.Claims = claimRepo
+	}
+
+	postgres.ConfigurePool(store.DB, postgres.PoolConfig{
+		MaxOpenConns:    config.DBMaxOpenConns,
+		MaxIdleConns:    config.DBMaxIdleConns,
+		ConnMaxLifetime: config.DBConnMaxLifetime,
+		ConnMaxIdleTime: config.DBConnMaxIdleTime,
+	})
+
+	store.ApiKeys = api_key_repository.NewApiKeyRepository(store.DB)
+	store.Transactions = transaction.NewManager(store.DB)
+	store.close = store.DB.Close
+
+	return store, nil
+}
+
+// openMemory keeps the repositories in memory. With a snapshot directory they are
+// restored from it on start, saved every interval and saved on close.
+func openMemory(snapshotDir string, interval time.Duration) (*Storage, error) {
+	receiverRepo := receiver_repository.NewMemoryReceiverRepository()
+	apiKeyRepo := api_key_repository.NewMemoryApiKeyRepository()
+	claimRepo := claim_repository.NewMemoryClaimRepository()
+
+	store := &Storage{
+		Receivers:    receiverRepo,
+		ApiKeys:      apiKeyRepo,
+		Claims:       claimRepo,
+		Transactions: transaction.NewMemoryManager(),
+		close:        func() error { return nil },
+	}
+
+	if snapshotDir == "" {
+		slog.Warn("memory storage without DB_SNAPSHOT_DIR, data is lost on exit")
+		return store, nil
+	}
+
+	if err := os.MkdirAll(snapshotDir, 0o700); err != nil {
+		return nil, err
+	}
+
+	receiversPath := filepath.Join(snapshotDir, "receivers.json")
+	apiKeysPath := filepath.Join(snapshotDir, "api_keys.json")
+	claimsPath := filepath.Join(snapshotDir, "claims.json")
+
+	if err := receiverRepo.LoadSnapshot(receiversPath); err != nil {
+		return nil, fmt.Errorf("restoring %s: %w", receiversPath, err)
+	}
+	if err := apiKeyRepo.LoadSnapshot(apiKeysPath); err != nil {
+		return nil, fmt.Errorf("restoring %s: %w", apiKeysPath, err)
+	}
+	if err := claimRepo.LoadSnapshot(claimsPath); err != nil {
+		return nil, fmt.Errorf("restoring %s: %w", claimsPath, err)
+	}
+
+	save := func() error {
+		return errors.Join(receiverRepo.SaveSnapshot(receiversPath), apiKeyRepo.SaveSnapshot(apiKeysPath), claimRepo.SaveSnapshot(claimsPath))
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := save(); err != nil {
+					slog.Error("error saving memory snapshot", "error", err)
+				}
+			}
+		}
+	}()
+
+	store.close = func() error {
+		close(stop)
+		<-stopped
+		return save()
+	}
+
+	return store, nil
+}
@@ -0,0 +1,86 @@
+// Package transaction shares a database transaction between repositories through
+// the context, so a use case can commit writes to several tables together.
+package transaction
+
+import (
+	"context"
+	"sync"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/jmoiron/sqlx"
+)
+
+type txKey struct{}
+
+// Manager runs units of work in a transaction of a postgres or sqlite database.
+type Manager struct {
+	Db *sqlx.DB
+}
+
+func NewManager(db *sqlx.DB) *Manager {
+	return &Manager{Db: db}
+}
+
+// RunInTransaction begins a transaction, stores it in the context given to fn and
+// commits it when fn succeeds. Called inside another unit of work, fn joins the
+// transaction already in the context.
+func (m *Manager) RunInTransaction(ctx context.Context, fn func(ctx context.Context) *internal_error.InternalError) *internal_error.InternalError {
+	if _, ok := FromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return internal_error.NewInternalServerError("error starting transaction", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return internal_error.NewInternalServerError("error committing transaction", err)
+	}
+
+	return nil
+}
+
+// FromContext returns the transaction of the unit of work running with ctx.
+func FromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok
+}
+
+// Querier returns the transaction of ctx, or db outside a unit of work.
+func Querier(ctx context.Context, db *sqlx.DB) sqlx.ExtContext {
+	if tx, ok := FromContext(ctx); ok {
+		return tx
+	}
+
+	return db
+}
+
+type memoryUnitKey struct{}
+
+// MemoryManager runs units of work on the memory repositories one at a time. The
+// repositories apply each write at once, so a failure does not undo the writes
+// before it.
+type MemoryManager struct {
+	mu sync.Mutex
+}
+
+func NewMemoryManager() *MemoryManager {
+	return &MemoryManager{}
+}
+
+func (m *MemoryManager) RunInTransaction(ctx context.Context, fn func(ctx context.Context) *internal_error.InternalError) *internal_error.InternalError {
+	if ctx.Value(memoryUnitKey{}) != nil {
+		return fn(ctx)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return fn(context.WithValue(ctx, memoryUnitKey{}, true))
+}
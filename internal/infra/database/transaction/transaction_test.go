@@ -0,0 +1,73 @@
+package transaction
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestDatabase(t *testing.T) *sqlx.DB {
+	db, err := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "tx.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec("CREATE TABLE items (name TEXT PRIMARY KEY)")
+	require.NoError(t, err)
+
+	return db
+}
+
+func insertItem(ctx context.Context, db *sqlx.DB, name string) *internal_error.InternalError {
+	if _, err := Querier(ctx, db).ExecContext(ctx, "INSERT INTO items (name) VALUES ($1)", name); err != nil {
+		return internal_error.NewInternalServerError("error inserting item", err)
+	}
+	return nil
+}
+
+func countItems(t *testing.T, db *sqlx.DB) int {
+	var count int
+	require.NoError(t, db.Get(&count, "SELECT COUNT(*) FROM items"))
+	return count
+}
+
+func TestRunInTransactionCommitsWhenFnSucceeds(t *testing.T) {
+	db := openTestDatabase(t)
+	manager := NewManager(db)
+
+	err := manager.RunInTransaction(context.Background(), func(ctx context.Context) *internal_error.InternalError {
+		if err := insertItem(ctx, db, "a"); err != nil {
+			return err
+		}
+
+		return manager.RunInTransaction(ctx, func(ctx context.Context) *internal_error.InternalError {
+			_, ok := FromContext(ctx)
+			assert.True(t, ok)
+			return insertItem(ctx, db, "b")
+		})
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, 2, countItems(t, db))
+}
+
+func TestRunInTransactionRollsBackWhenFnFails(t *testing.T) {
+	db := openTestDatabase(t)
+	manager := NewManager(db)
+
+	err := manager.RunInTransaction(context.Background(), func(ctx context.Context) *internal_error.InternalError {
+		if err := insertItem(ctx, db, "a"); err != nil {
+			return err
+		}
+
+		return insertItem(ctx, db, "a")
+	})
+
+	require.NotNil(t, err)
+	assert.Equal(t, 0, countItems(t, db))
+}
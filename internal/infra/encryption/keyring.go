@@ -0,0 +1,303 @@
+package encryption
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeySize is the size of master, data and index keys: AES-256 and HMAC-SHA256.
+const KeySize = 32
+
+// wrappedKeyVersion prefixes wrapped data keys, so the format can change later.
+const wrappedKeyVersion = "v1"
+
+// Keyring holds the master keys that wrap data keys and the key of the blind
+// indexes. New data keys are wrapped with the first (current) master key; the
+// others are only used to unwrap data keys written before a rotation.
+type Keyring struct {
+	current    *masterKey
+	masterKeys map[string]*masterKey
+	indexKey   []byte
+}
+
+type masterKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// DataKey encrypts the fields of one record. It is generated for every write and
+// stored wrapped by a master key next to the ciphertext.
+type DataKey struct {
+	aead cipher.AEAD
+}
+
+// Load builds the keyring from the settings. Master keys come from a comma
+// separated list or from a file with one key per line, blank lines and # comments
+// ignored, the current key first; keys are base64 encoded. Without master keys
+// encryption is disabled and Load returns a nil keyring.
+func Load(masterKeys, masterKeyFile, indexKey string) (*Keyring, error) {
+	if masterKeys != "" && masterKeyFile != "" {
+		return nil, errors.New("ENCRYPTION_MASTER_KEY and ENCRYPTION_MASTER_KEY_FILE are mutually exclusive")
+	}
+
+	encodedKeys := splitKeys(masterKeys)
+	if masterKeyFile != "" {
+		fileKeys, err := readKeyFile(masterKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		encodedKeys = fileKeys
+	}
+
+	if len(encodedKeys) == 0 {
+		if indexKey != "" {
+			return nil, errors.New("ENCRYPTION_INDEX_KEY requires a master key")
+		}
+		return nil, nil
+	}
+
+	if indexKey == "" {
+		return nil, errors.New("ENCRYPTION_INDEX_KEY is required when encryption is enabled")
+	}
+
+	keys := make([][]byte, 0, len(encodedKeys))
+	for i, encodedKey := range encodedKeys {
+		key, err := decodeKey(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("master key %d: %w", i+1, err)
+		}
+		keys = append(keys, key)
+	}
+
+	decodedIndexKey, err := decodeKey(indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("ENCRYPTION_INDEX_KEY: %w", err)
+	}
+
+	return NewKeyring(keys, decodedIndexKey)
+}
+
+// NewKeyring builds a keyring from raw keys, the current master key first.
+func NewKeyring(masterKeys [][]byte, indexKey []byte) (*Keyring, error) {
+	if len(masterKeys) == 0 {
+		return nil, errors.New("at least one master key is required")
+	}
+	if len(indexKey) != KeySize {
+		return nil, fmt.Errorf("index key must have %d bytes", KeySize)
+	}
+
+	keyring := &Keyring{
+		masterKeys: make(map[string]*masterKey, len(masterKeys)),
+		indexKey:   indexKey,
+	}
+
+	for _, key := range masterKeys {
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("master key must have %d bytes", KeySize)
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+
+		// The id only tells which key wrapped a data key, it does not reveal the key.
+		sum := sha256.Sum256(key)
+		id := hex.EncodeToString(sum[:4])
+		if _, found := keyring.masterKeys[id]; found {
+			return nil, fmt.Errorf("master key %s is repeated", id)
+		}
+
+		master := &masterKey{id: id, aead: aead}
+		keyring.masterKeys[id] = master
+		if keyring.current == nil {
+			keyring.current = master
+		}
+	}
+
+	return keyring, nil
+}
+
+// CurrentKeyId is the id of the master key wrapping new data keys.
+func (k *Keyring) CurrentKeyId() string {
+	return k.current.id
+}
+
+// NewDataKey generates a data key and returns it with its wrapped form, which is
+// "v1:<master key id>:<base64 nonce and ciphertext>".
+func (k *Keyring) NewDataKey() (*DataKey, string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, "", err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sealed, err := seal(k.current.aead, key, []byte(k.current.id))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &DataKey{aead: aead}, wrappedKeyVersion + ":" + k.current.id + ":" + sealed, nil
+}
+
+// UnwrapDataKey decrypts a data key wrapped by any master key of the keyring.
+func (k *Keyring) UnwrapDataKey(wrapped string) (*DataKey, error) {
+	id, sealed, err := parseWrappedKey(wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	master, found := k.masterKeys[id]
+	if !found {
+		return nil, fmt.Errorf("master key %s is not in the keyring", id)
+	}
+
+	key, err := open(master.aead, sealed, []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DataKey{aead: aead}, nil
+}
+
+// IsCurrent reports whether a wrapped data key was wrapped by the current master
+// key, so the re-encrypt command can skip it.
+func (k *Keyring) IsCurrent(wrapped string) bool {
+	id, _, err := parseWrappedKey(wrapped)
+	return err == nil && id == k.current.id
+}
+
+// BlindIndex is a keyed hash of value, equal for equal values, that lets encrypted
+// fields be looked up without decrypting them. The purpose keeps the indexes of
+// different fields apart, so equal values of two fields do not match.
+func (k *Keyring) BlindIndex(purpose, value string) string {
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(purpose))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Encrypt encrypts plaintext bound to associatedData, which must be given again to
+// decrypt it, so a ciphertext cannot be moved to another record or field.
+func (d *DataKey) Encrypt(plaintext, associatedData string) (string, error) {
+	return seal(d.aead, []byte(plaintext), []byte(associatedData))
+}
+
+func (d *DataKey) Decrypt(ciphertext, associatedData string) (string, error) {
+	plaintext, err := open(d.aead, ciphertext, []byte(associatedData))
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal returns the base64 encoded random nonce followed by the ciphertext.
+func seal(aead cipher.AEAD, plaintext, associatedData []byte) (string, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, plaintext, associatedData)), nil
+}
+
+func open(aead cipher.AEAD, sealed string, associatedData []byte) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], associatedData)
+}
+
+func parseWrappedKey(wrapped string) (id string, sealed string, err error) {
+	parts := strings.Split(wrapped, ":")
+	if len(parts) != 3 || parts[0] != wrappedKeyVersion {
+		return "", "", errors.New("invalid wrapped data key")
+	}
+
+	return parts[1], parts[2], nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.New("key must be base64 encoded")
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must have %d bytes, got %d", KeySize, len(key))
+	}
+
+	return key, nil
+}
+
+func splitKeys(list string) []string {
+	keys := make([]string, 0)
+	for _, key := range strings.Split(list, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func readKeyFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading master key file: %w", err)
+	}
+	defer file.Close()
+
+	keys := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys = append(keys, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading master key file: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("master key file %s has no keys", path)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,98 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func TestDataKeyEncryptsAndDecrypts(t *testing.T) {
+	keyring, err := NewKeyring([][]byte{testKey(1)}, testKey(9))
+	assert.NoError(t, err)
+
+	dataKey, wrapped, err := keyring.NewDataKey()
+	assert.NoError(t, err)
+	assert.True(t, keyring.IsCurrent(wrapped))
+
+	ciphertext, err := dataKey.Encrypt("123.456.789-09", "receiver:document")
+	assert.NoError(t, err)
+	assert.NotContains(t, ciphertext, "123")
+
+	unwrapped, err := keyring.UnwrapDataKey(wrapped)
+	assert.NoError(t, err)
+
+	plaintext, err := unwrapped.Decrypt(ciphertext, "receiver:document")
+	assert.NoError(t, err)
+	assert.Equal(t, "123.456.789-09", plaintext)
+
+	_, err = unwrapped.Decrypt(ciphertext, "receiver:pix_key")
+	assert.Error(t, err)
+}
+
+func TestUnwrapDataKeyAfterRotation(t *testing.T) {
+	oldKeyring, err := NewKeyring([][]byte{testKey(1)}, testKey(9))
+	assert.NoError(t, err)
+	_, wrapped, err := oldKeyring.NewDataKey()
+	assert.NoError(t, err)
+
+	rotated, err := NewKeyring([][]byte{testKey(2), testKey(1)}, testKey(9))
+	assert.NoError(t, err)
+	assert.False(t, rotated.IsCurrent(wrapped))
+	_, err = rotated.UnwrapDataKey(wrapped)
+	assert.NoError(t, err)
+
+	withoutOldKey, err := NewKeyring([][]byte{testKey(2)}, testKey(9))
+	assert.NoError(t, err)
+	_, err = withoutOldKey.UnwrapDataKey(wrapped)
+	assert.Error(t, err)
+}
+
+func TestBlindIndex(t *testing.T) {
+	keyring, err := NewKeyring([][]byte{testKey(1)}, testKey(9))
+	assert.NoError(t, err)
+	otherIndexKey, err := NewKeyring([][]byte{testKey(1)}, testKey(8))
+	assert.NoError(t, err)
+
+	assert.Equal(t, keyring.BlindIndex("document", "12345678909"), keyring.BlindIndex("document", "12345678909"))
+	assert.NotEqual(t, keyring.BlindIndex("document", "12345678909"), keyring.BlindIndex("pix_key", "12345678909"))
+	assert.NotEqual(t, keyring.BlindIndex("document", "12345678909"), otherIndexKey.BlindIndex("document", "12345678909"))
+}
+
+func TestLoad(t *testing.T) {
+	masterKey := base64.StdEncoding.EncodeToString(testKey(1))
+	previousKey := base64.StdEncoding.EncodeToString(testKey(2))
+	indexKey := base64.StdEncoding.EncodeToString(testKey(9))
+
+	keyring, err := Load("", "", "")
+	assert.NoError(t, err)
+	assert.Nil(t, keyring)
+
+	keyring, err = Load(masterKey+","+previousKey, "", indexKey)
+	assert.NoError(t, err)
+	assert.Len(t, keyring.masterKeys, 2)
+
+	path := filepath.Join(t.TempDir(), "master.keys")
+	assert.NoError(t, os.WriteFile(path, []byte("# rotated 2026-10\n"+previousKey+"\n\n"+masterKey+"\n"), 0o600))
+	fromFile, err := Load("", path, indexKey)
+	assert.NoError(t, err)
+	assert.NotEqual(t, keyring.CurrentKeyId(), fromFile.CurrentKeyId())
+
+	_, err = Load(masterKey, "", "")
+	assert.Error(t, err)
+	_, err = Load("", "", indexKey)
+	assert.Error(t, err)
+	_, err = Load(masterKey, path, indexKey)
+	assert.Error(t, err)
+	_, err = Load("c2hvcnQ=", "", indexKey)
+	assert.Error(t, err)
+	_, err = Load(masterKey+","+masterKey, "", indexKey)
+	assert.Error(t, err)
+}
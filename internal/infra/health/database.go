@@ -0,0 +1,60 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DatabaseChecker pings the database and reads the golang-migrate version, so a
+// dirty or missing schema, or one older than the binary expects, is reported as
+// down. A newer schema, left by a newer release during a rollout or a rollback, is
+// only reported in the details.
+type DatabaseChecker struct {
+	Db              *sqlx.DB
+	ExpectedVersion uint
+}
+
+func NewDatabaseChecker(db *sqlx.DB, expectedVersion uint) *DatabaseChecker {
+	return &DatabaseChecker{Db: db, ExpectedVersion: expectedVersion}
+}
+
+func (c *DatabaseChecker) Check(ctx context.Context) (map[string]any, error) {
+	if err := c.Db.PingContext(ctx); err != nil {
+		return nil, err
+	}
+
+	stats := c.Db.Stats()
+	details := map[string]any{
+		"open_connections": stats.OpenConnections,
+		"in_use":           stats.InUse,
+		"idle":             stats.Idle,
+	}
+
+	var version int64
+	var dirty bool
+	err := c.Db.QueryRowxContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return details, errors.New("no migration applied")
+	}
+	if err != nil {
+		return details, err
+	}
+
+	details["migration_version"] = version
+	details["migration_dirty"] = dirty
+	details["migration_expected_version"] = c.ExpectedVersion
+
+	if dirty {
+		return details, fmt.Errorf("migration %d is dirty", version)
+	}
+	if version < int64(c.ExpectedVersion) {
+		return details, fmt.Errorf("migration version is %d, expected at least %d", version, c.ExpectedVersion)
+	}
+	details["migration_ahead"] = version > int64(c.ExpectedVersion)
+
+	return details, nil
+}
@@ -0,0 +1,34 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/configuration/database/sqlite"
+	"github.com/felipemagrassi/pix-api/db/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseCheckerMigrationVersion(t *testing.T) {
+	db, err := sqlite.InitializeDatabase(context.Background(), filepath.Join(t.TempDir(), "pix.db"), true)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	expectedVersion, err := migrations.LatestVersion(migrations.SQLiteFS, "sqlite")
+	require.NoError(t, err)
+
+	details, err := NewDatabaseChecker(db, expectedVersion).Check(context.Background())
+	assert.NoError(t, err)
+	assert.EqualValues(t, expectedVersion, details["migration_version"])
+	assert.Equal(t, false, details["migration_ahead"])
+
+	_, err = NewDatabaseChecker(db, expectedVersion+1).Check(context.Background())
+	assert.EqualError(t, err, fmt.Sprintf("migration version is %d, expected at least %d", expectedVersion, expectedVersion+1))
+
+	details, err = NewDatabaseChecker(db, expectedVersion-1).Check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, true, details["migration_ahead"])
+}
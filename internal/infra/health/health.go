@@ -0,0 +1,145 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+)
+
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+// Checker reports the status of one dependency. Details are shown to operators in
+// the detailed report; a non nil error marks the dependency as down.
+type Checker interface {
+	Check(ctx context.Context) (map[string]any, error)
+}
+
+type CheckerFunc func(ctx context.Context) (map[string]any, error)
+
+func (f CheckerFunc) Check(ctx context.Context) (map[string]any, error) {
+	return f(ctx)
+}
+
+type ComponentReport struct {
+	Status     Status         `json:"status"`
+	Critical   bool           `json:"critical"`
+	Details    map[string]any `json:"details,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	DurationMs float64        `json:"duration_ms"`
+}
+
+type Report struct {
+	Status     Status                     `json:"status"`
+	CheckedAt  time.Time                  `json:"checked_at"`
+	Components map[string]ComponentReport `json:"components,omitempty"`
+}
+
+type registeredChecker struct {
+	name     string
+	checker  Checker
+	critical bool
+}
+
+// Registry runs the registered checkers. A failing critical checker makes the
+// service down (not ready); a failing non critical one only degrades it.
+type Registry struct {
+	mu       sync.RWMutex
+	checkers []registeredChecker
+	timeout  time.Duration
+}
+
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+func (r *Registry) Register(name string, checker Checker, critical bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checkers = append(r.checkers, registeredChecker{name: name, checker: checker, critical: critical})
+}
+
+// Check runs every checker concurrently, each bounded by the registry timeout.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	checkers := append([]registeredChecker(nil), r.checkers...)
+	r.mu.RUnlock()
+
+	components := make([]ComponentReport, len(checkers))
+
+	var wg sync.WaitGroup
+	for i, registered := range checkers {
+		wg.Add(1)
+		go func(i int, registered registeredChecker) {
+			defer wg.Done()
+			components[i] = r.runChecker(ctx, registered)
+		}(i, registered)
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:     StatusUp,
+		CheckedAt:  time.Now().UTC(),
+		Components: make(map[string]ComponentReport, len(checkers)),
+	}
+
+	for i, registered := range checkers {
+		component := components[i]
+		report.Components[registered.name] = component
+
+		if component.Status == StatusDown {
+			if registered.critical {
+				report.Status = StatusDown
+			} else if report.Status == StatusUp {
+				report.Status = StatusDegraded
+			}
+		}
+	}
+
+	return report
+}
+
+func (r *Registry) runChecker(ctx context.Context, registered registeredChecker) ComponentReport {
+	checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	type result struct {
+		details map[string]any
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		details, err := registered.checker.Check(checkCtx)
+		done <- result{details: details, err: err}
+	}()
+
+	component := ComponentReport{Status: StatusUp, Critical: registered.critical}
+
+	select {
+	case res := <-done:
+		component.Details = res.details
+		if res.err != nil {
+			// The report is public, so the error, which may name hosts or schema
+			// details, is only logged.
+			logger.FromContext(ctx).Error("health check failed", "component", registered.name, "error", res.err)
+			component.Status = StatusDown
+			component.Error = "check failed"
+		}
+	case <-checkCtx.Done():
+		component.Status = StatusDown
+		component.Error = "check timed out"
+	}
+
+	component.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+	return component
+}
@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func up(details map[string]any) CheckerFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		return details, nil
+	}
+}
+
+func down(message string) CheckerFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		return nil, errors.New(message)
+	}
+}
+
+func TestRegistryUp(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	registry.Register("database", up(map[string]any{"migration_version": 4}), true)
+
+	report := registry.Check(context.Background())
+
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Equal(t, StatusUp, report.Components["database"].Status)
+	assert.Equal(t, 4, report.Components["database"].Details["migration_version"])
+}
+
+func TestRegistryCriticalDown(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	registry.Register("database", down("connection refused"), true)
+	registry.Register("webhooks", up(nil), false)
+
+	report := registry.Check(context.Background())
+
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, "check failed", report.Components["database"].Error)
+	assert.Equal(t, StatusUp, report.Components["webhooks"].Status)
+}
+
+func TestRegistryNonCriticalDownDegrades(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	registry.Register("database", up(nil), true)
+	registry.Register("webhooks", down("relay stopped"), false)
+
+	report := registry.Check(context.Background())
+
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, StatusDown, report.Components["webhooks"].Status)
+}
+
+func TestRegistryTimeout(t *testing.T) {
+	registry := NewRegistry(20 * time.Millisecond)
+	registry.Register("slow", CheckerFunc(func(ctx context.Context) (map[string]any, error) {
+		time.Sleep(time.Second)
+		return nil, nil
+	}), true)
+
+	start := time.Now()
+	report := registry.Check(context.Background())
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, "check timed out", report.Components["slow"].Error)
+}
@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pix_api"
+
+// Metrics holds the collectors exposed on /metrics. Each instance has its own
+// registry so tests can create as many as they need.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	queryDuration       *prometheus.HistogramVec
+	receiversCreated    *prometheus.CounterVec
+	receiversValidated  *prometheus.CounterVec
+	receiversDeleted    *prometheus.CounterVec
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_query_duration_seconds",
+			Help:      "Repository method latency by repository, method and outcome.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, []string{"repository", "method", "outcome"}),
+		receiversCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "receivers_created_total",
+			Help:      "Receivers created by pix key type.",
+		}, []string{"pix_key_type"}),
+		receiversValidated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "receivers_validated_total",
+			Help:      "Receivers moved to the valid status by pix key type.",
+		}, []string{"pix_key_type"}),
+		receiversDeleted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "receivers_deleted_total",
+			Help:      "Receivers deleted by pix key type.",
+		}, []string{"pix_key_type"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.queryDuration,
+		m.receiversCreated,
+		m.receiversValidated,
+		m.receiversDeleted,
+	)
+
+	return m
+}
+
+// Handler serves the registry in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDB exposes the connection pool stats of db, labeled with dbName.
+func (m *Metrics) RegisterDB(db *sql.DB, dbName string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+
+	m.httpRequests.WithLabelValues(method, route, statusLabel).Inc()
+	m.httpRequestDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+// ObserveQuery records the latency of a repository method; the outcome is "ok" or
+// the kind of the returned error.
+func (m *Metrics) ObserveQuery(repository, method string, duration time.Duration, err *internal_error.InternalError) {
+	outcome := "ok"
+	if err != nil {
+		outcome = err.Err
+	}
+
+	m.queryDuration.WithLabelValues(repository, method, outcome).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ReceiverCreated(ctx context.Context, receiver *entity.Receiver) {
+	m.receiversCreated.WithLabelValues(pixKeyTypeLabel(receiver)).Inc()
+}
+
+func (m *Metrics) ReceiverValidated(ctx context.Context, receiver *entity.Receiver) {
+	m.receiversValidated.WithLabelValues(pixKeyTypeLabel(receiver)).Inc()
+}
+
+func (m *Metrics) ReceiverDeleted(ctx context.Context, receiver *entity.Receiver) {
+	m.receiversDeleted.WithLabelValues(pixKeyTypeLabel(receiver)).Inc()
+}
+
+func pixKeyTypeLabel(receiver *entity.Receiver) string {
+	if receiver.PixKey == nil {
+		return "none"
+	}
+
+	return strings.ToLower(receiver.PixKey.KeyType.GetTypeName())
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerExposesHTTPMetrics(t *testing.T) {
+	m := NewMetrics()
+	m.ObserveHTTPRequest(http.MethodGet, "/receiver", http.StatusOK, 20*time.Millisecond)
+	m.ObserveHTTPRequest(http.MethodGet, "/receiver", http.StatusOK, 30*time.Millisecond)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.httpRequests.WithLabelValues(http.MethodGet, "/receiver", "200")))
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `pix_api_http_requests_total{method="GET",route="/receiver",status="200"} 2`)
+	assert.Contains(t, body, "pix_api_http_request_duration_seconds_bucket")
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestInstrumentedRepositoryAndEvents(t *testing.T) {
+	ctx := auth.WithTenant(context.Background(), "acme")
+	m := NewMetrics()
+
+	repo := NewInstrumentedReceiverRepository(&receiver_repository.MemoryReceiverRepository{}, m)
+	uc := receiver_usecase.NewReceiverUseCase(repo)
+	uc.Events = m
+
+	_, err := uc.CreateReceiver(ctx, receiver_usecase.CreateReceiverInput{
+		Name:        "Felipe",
+		Document:    "12345678909",
+		Email:       "felipe@email.com",
+		PixKeyValue: "felipe@email.com",
+		PixKeyType:  "email",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.receiversCreated.WithLabelValues("email")))
+
+	receivers, err := uc.FindReceivers(ctx, receiver_usecase.FindReceiversInput{Status: -1, PixKeyType: -1, Page: 1})
+	assert.Nil(t, err)
+	assert.Len(t, receivers.Receivers, 1)
+
+	receiverId, parseErr := pkg_entity.ParseID(receivers.Receivers[0].ReceiverId)
+	assert.Nil(t, parseErr)
+
+	err = uc.DeleteReceivers(ctx, receiver_usecase.DeleteReceiversInput{ReceiverIds: []pkg_entity.ID{receiverId}})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.receiversDeleted.WithLabelValues("email")))
+
+	series := scrape(t, m)
+	assert.Contains(t, series, `pix_api_repository_query_duration_seconds_count{method="CreateReceiver",outcome="ok",repository="receiver"} 1`)
+	assert.Contains(t, series, `pix_api_repository_query_duration_seconds_count{method="DeleteManyReceivers",outcome="ok",repository="receiver"} 1`)
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	server := httptest.NewServer(m.Handler())
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	assert.Nil(t, err)
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	return string(body)
+}
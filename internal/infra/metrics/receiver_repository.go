@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+)
+
+const receiverRepositoryLabel = "receiver"
+
+// InstrumentedReceiverRepository records the latency of every call to the wrapped
+// repository.
+type InstrumentedReceiverRepository struct {
+	repository entity.ReceiverRepositoryInterface
+	metrics    *Metrics
+}
+
+func NewInstrumentedReceiverRepository(repository entity.ReceiverRepositoryInterface, metrics *Metrics) *InstrumentedReceiverRepository {
+	return &InstrumentedReceiverRepository{repository: repository, metrics: metrics}
+}
+
+func (r *InstrumentedReceiverRepository) FindReceiver(ctx context.Context, id pkg_entity.ID) (*entity.Receiver, *internal_error.InternalError) {
+	start := time.Now()
+	receiver, err := r.repository.FindReceiver(ctx, id)
+	r.metrics.ObserveQuery(receiverRepositoryLabel, "FindReceiver", time.Since(start), err)
+
+	return receiver, err
+}
+
+func (r *InstrumentedReceiverRepository) FindReceiverByPixKey(ctx context.Context, canonicalPixKey string) (*entity.Receiver, *internal_error.InternalError) {
+	start := time.Now()
+	receiver, err := r.repository.FindReceiverByPixKey(ctx, canonicalPixKey)
+	r.metrics.ObserveQuery(receiverRepositoryLabel, "FindReceiverByPixKey", time.Since(start), err)
+
+	return receiver, err
+}
+
+func (r *InstrumentedReceiverRepository) CountReceiversByOwner(ctx context.Context, owner entity.PixKeyOwner) (int, *internal_error.InternalError) {
+	start := time.Now()
+	count, err := r.repository.CountReceiversByOwner(ctx, owner)
+	r.metrics.ObserveQuery(receiverRepositoryLabel, "CountReceiversByOwner", time.Since(start), err)
+
+	return count, err
+}
+
+func (r *InstrumentedReceiverRepository) FindReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, page int) ([]entity.Receiver, *internal_error.InternalError) {
+	start := time.Now()
+	receivers, err := r.repository.FindReceivers(ctx, status, name, pixKeyValue, pixKeyType, page)
+	r.metrics.ObserveQuery(receiverRepositoryLabel, "FindReceivers", time.Since(start), err)
+
+	return receivers, err
+}
+
+func (r *InstrumentedReceiverRepository) StreamReceivers(ctx context.Context, status entity.ReceiverStatus, name, pixKeyValue string, pixKeyType entity.PixKeyType, fn func(receiver *entity.Receiver) *internal_error.InternalError) *internal_error.InternalError {
+	start := time.Now()
+	err := r.repository.StreamReceivers(ctx, status, name, pixKeyValue, pixKeyType, fn)
+	r.metrics.ObserveQuery(receiverRepositoryLabel, "StreamReceivers", time.Since(start), err)
+
+	return err
+}
+
+func (r *InstrumentedReceiverRepository) CreateReceiver(ctx context.Context, receiver *entity.Receiver) *internal_error.InternalError {
+	start := time.Now()
+	err := r.repository.CreateReceiver(ctx, receiver)
+	r.metrics.ObserveQuery(receiverRepositoryLabel, "CreateReceiver", time.Since(start), err)
+
+	return err
+}
+
+func (r *InstrumentedReceiverRepository) UpdateReceiver(ctx context.Context, receiver *entity.Receiver) *internal_error.InternalError {
+	start := time.Now()
+	err := r.repository.UpdateReceiver(ctx, receiver)
+	r.metrics.ObserveQuery(receiverRepositoryLabel, "UpdateReceiver", time.Since(start), err)
+
+	return err
+}
+
+func (r *InstrumentedReceiverRepository) DeleteManyReceivers(ctx context.Context, ids []pkg_entity.ID) *internal_error.InternalError {
+	start := time.Now()
+	err := r.repository.DeleteManyReceivers(ctx, ids)
+	r.metrics.ObserveQuery(receiverRepositoryLabel, "DeleteManyReceivers", time.Since(start), err)
+
+	return err
+}
+
+func (r *InstrumentedReceiverRepository) LockPixKeyOwner(ctx context.Context, owner entity.PixKeyOwner) *internal_error.InternalError {
+	start := time.Now()
+	err := r.repository.LockPixKeyOwner(ctx, owner)
+	r.metrics.ObserveQuery(receiverRepositoryLabel, "LockPixKeyOwner", time.Since(start), err)
+
+	return err
+}
+
+func (r *InstrumentedReceiverRepository) TransferPixKey(ctx context.Context, donorId pkg_entity.ID, receiver *entity.Receiver) *internal_error.InternalError {
+	start := time.Now()
+	err := r.repository.TransferPixKey(ctx, donorId, receiver)
+	r.metrics.ObserveQuery(receiverRepositoryLabel, "TransferPixKey", time.Since(start), err)
+
+	return err
+}
@@ -0,0 +1,55 @@
+package rate_limiter
+
+import (
+	"math"
+	"time"
+)
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+func newBucket(limit Limit, now time.Time) bucket {
+	return bucket{Tokens: float64(limit.Burst), UpdatedAt: now}
+}
+
+// take refills the bucket for the time elapsed since its last update and
+// consumes one token when available.
+func (b bucket) take(limit Limit, now time.Time) (bucket, Result) {
+	rate := limit.ratePerSecond()
+
+	elapsed := now.Sub(b.UpdatedAt).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	b.Tokens = math.Min(float64(limit.Burst), b.Tokens+elapsed*rate)
+	b.UpdatedAt = now
+
+	result := Result{Limit: limit.Burst}
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.Tokens) / rate)
+	}
+
+	result.Remaining = int(math.Floor(b.Tokens))
+	result.ResetAfter = secondsToDuration((float64(limit.Burst) - b.Tokens) / rate)
+
+	return b, result
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
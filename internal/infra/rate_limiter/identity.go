@@ -0,0 +1,24 @@
+package rate_limiter
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+)
+
+// Identity returns the bucket identity of a request: the principal or tenant
+// stored by authentication when keying by them, or else the client IP.
+func Identity(ctx context.Context, keyBy, clientIP string) string {
+	switch keyBy {
+	case KeyByApiKey:
+		if principal, ok := auth.PrincipalFromContext(ctx); ok {
+			return principal.Method + ":" + principal.Subject
+		}
+	case KeyByTenant:
+		if tenantId, ok := auth.TenantFromContext(ctx); ok {
+			return "tenant:" + tenantId
+		}
+	}
+
+	return "ip:" + clientIP
+}
@@ -0,0 +1,89 @@
+package rate_limiter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit describes a token bucket: it holds up to Burst tokens and refills
+// Requests tokens every Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+func (l Limit) ratePerSecond() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+var periods = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+}
+
+// ParseLimit parses limits written as "<requests>/<period>[:<burst>]", where the
+// period is one of s, m, h or d, e.g. "10/s:20" or "10000/d". Burst defaults to
+// the number of requests.
+func ParseLimit(value string) (Limit, error) {
+	rate, burst, hasBurst := strings.Cut(strings.TrimSpace(value), ":")
+
+	requests, period, found := strings.Cut(rate, "/")
+	if !found {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<period>", value)
+	}
+
+	limit := Limit{}
+
+	parsedRequests, err := strconv.Atoi(requests)
+	if err != nil || parsedRequests <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", value)
+	}
+	limit.Requests = parsedRequests
+
+	parsedPeriod, ok := periods[period]
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: period must be one of s, m, h, d", value)
+	}
+	limit.Period = parsedPeriod
+
+	limit.Burst = limit.Requests
+	if hasBurst {
+		parsedBurst, err := strconv.Atoi(burst)
+		if err != nil || parsedBurst <= 0 {
+			return Limit{}, fmt.Errorf("invalid rate limit %q: burst must be a positive integer", value)
+		}
+		limit.Burst = parsedBurst
+	}
+
+	return limit, nil
+}
+
+// ParseRouteLimits parses per-route limits written as
+// "METHOD /route=<limit>,METHOD /route=<limit>", using gin route paths.
+func ParseRouteLimits(value string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+	if strings.TrimSpace(value) == "" {
+		return limits, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		route, rawLimit, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid route rate limit %q: expected METHOD /route=<limit>", entry)
+		}
+
+		limit, err := ParseLimit(rawLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		limits[strings.Join(strings.Fields(route), " ")] = limit
+	}
+
+	return limits, nil
+}
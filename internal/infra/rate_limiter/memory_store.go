@@ -0,0 +1,60 @@
+package rate_limiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps buckets in the process memory, so limits are per instance.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]bucket
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]bucket)}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, found := s.buckets[key]
+	if !found {
+		current = newBucket(limit, now)
+	}
+
+	updated, result := current.take(limit, now)
+	s.buckets[key] = updated
+
+	return result, nil
+}
+
+// Cleanup drops buckets that have not been used for longer than idle. A bucket
+// idle for a whole period is full again, so forgetting it changes nothing.
+func (s *MemoryStore) Cleanup(now time.Time, idle time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, b := range s.buckets {
+		if now.Sub(b.UpdatedAt) > idle {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// Run periodically removes idle buckets until the context is canceled.
+func (s *MemoryStore) Run(ctx context.Context, interval, idle time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Cleanup(now, idle)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package rate_limiter
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresStore shares buckets between instances through the rate_limit_buckets
+// table, locking the bucket row for the duration of each take.
+type PostgresStore struct {
+	Db *sqlx.DB
+}
+
+func NewPostgresStore(db *sqlx.DB) *PostgresStore {
+	return &PostgresStore{Db: db}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	tenantId, _ := auth.TenantFromContext(ctx)
+
+	tx, err := s.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return Result{}, err
+	}
+	defer tx.Rollback()
+
+	initial := newBucket(limit, now)
+	_, err = tx.ExecContext(ctx, "INSERT INTO rate_limit_buckets (bucket_key, tenant_id, tokens, updated_at) VALUES ($1, $2, $3, $4) ON CONFLICT (bucket_key) DO NOTHING", key, tenantId, initial.Tokens, initial.UpdatedAt)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var current bucket
+	err = tx.QueryRowxContext(ctx, "SELECT tokens, updated_at FROM rate_limit_buckets WHERE bucket_key = $1 FOR UPDATE", key).Scan(&current.Tokens, &current.UpdatedAt)
+	if err != nil {
+		return Result{}, err
+	}
+
+	updated, result := current.take(limit, now)
+
+	_, err = tx.ExecContext(ctx, "UPDATE rate_limit_buckets SET tokens = $1, updated_at = $2 WHERE bucket_key = $3", updated.Tokens, updated.UpdatedAt, key)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Result{}, err
+	}
+
+	return result, nil
+}
+
+// Cleanup deletes buckets that have not been used for longer than idle.
+func (s *PostgresStore) Cleanup(ctx context.Context, now time.Time, idle time.Duration) error {
+	_, err := s.Db.ExecContext(ctx, "DELETE FROM rate_limit_buckets WHERE updated_at < $1", now.Add(-idle))
+	return err
+}
+
+// Run periodically removes idle buckets until the context is canceled.
+func (s *PostgresStore) Run(ctx context.Context, interval, idle time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.Cleanup(ctx, now, idle); err != nil {
+				slog.Error("error cleaning up rate limit buckets", "error", err)
+			}
+		}
+	}
+}
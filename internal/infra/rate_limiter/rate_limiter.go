@@ -0,0 +1,60 @@
+package rate_limiter
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	KeyByApiKey = "api_key"
+	KeyByIP     = "ip"
+	KeyByTenant = "tenant"
+)
+
+// RateLimiter resolves the limit of each route and takes tokens from the store.
+type RateLimiter struct {
+	store        Store
+	defaultLimit Limit
+	routeLimits  map[string]Limit
+	keyBy        string
+	now          func() time.Time
+}
+
+func NewRateLimiter(store Store, defaultLimit Limit, routeLimits map[string]Limit, keyBy string) (*RateLimiter, error) {
+	switch keyBy {
+	case KeyByApiKey, KeyByIP, KeyByTenant:
+	default:
+		return nil, fmt.Errorf("invalid rate limit key %q: must be one of api_key, ip, tenant", keyBy)
+	}
+
+	return &RateLimiter{
+		store:        store,
+		defaultLimit: defaultLimit,
+		routeLimits:  routeLimits,
+		keyBy:        keyBy,
+		now:          time.Now,
+	}, nil
+}
+
+func (l *RateLimiter) KeyBy() string {
+	return l.keyBy
+}
+
+// LimitFor returns the limit configured for the route, falling back to the default.
+func (l *RateLimiter) LimitFor(method, route string) Limit {
+	if limit, ok := l.routeLimits[method+" "+route]; ok {
+		return limit
+	}
+
+	return l.defaultLimit
+}
+
+// Take consumes a token of the identity's bucket for the route. Each route has
+// its own bucket, so a burst on one route does not starve the others.
+func (l *RateLimiter) Take(ctx context.Context, method, route, identity string) (Result, error) {
+	limit := l.LimitFor(method, route)
+	key := fmt.Sprintf("%s:%s:%s %s", l.keyBy, identity, method, route)
+
+	return l.store.Take(ctx, key, limit, l.now())
+}
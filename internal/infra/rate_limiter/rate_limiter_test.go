@@ -0,0 +1,90 @@
+package rate_limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit("10/s:20")
+	assert.NoError(t, err)
+	assert.Equal(t, Limit{Requests: 10, Period: time.Second, Burst: 20}, limit)
+
+	limit, err = ParseLimit("10000/d")
+	assert.NoError(t, err)
+	assert.Equal(t, Limit{Requests: 10000, Period: 24 * time.Hour, Burst: 10000}, limit)
+
+	for _, invalid := range []string{"", "10", "10/w", "0/s", "abc/s", "10/s:0"} {
+		_, err := ParseLimit(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseRouteLimits(t *testing.T) {
+	limits, err := ParseRouteLimits("GET /receiver=10/s, GET  /receiver/export=1/m:2")
+	assert.NoError(t, err)
+	assert.Equal(t, Limit{Requests: 10, Period: time.Second, Burst: 10}, limits["GET /receiver"])
+	assert.Equal(t, Limit{Requests: 1, Period: time.Minute, Burst: 2}, limits["GET /receiver/export"])
+
+	_, err = ParseRouteLimits("GET /receiver")
+	assert.Error(t, err)
+}
+
+func TestMemoryStoreRefillsTokens(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Period: time.Second, Burst: 2}
+	now := time.Now()
+
+	result, _ := store.Take(context.Background(), "key", limit, now)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+
+	result, _ = store.Take(context.Background(), "key", limit, now)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, 2*time.Second, result.ResetAfter)
+
+	result, _ = store.Take(context.Background(), "key", limit, now.Add(500*time.Millisecond))
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+	result, _ = store.Take(context.Background(), "other", limit, now)
+	assert.True(t, result.Allowed)
+
+	result, _ = store.Take(context.Background(), "key", limit, now.Add(1500*time.Millisecond))
+	assert.True(t, result.Allowed)
+}
+
+func TestMemoryStoreCleanup(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+
+	_, _ = store.Take(context.Background(), "key", Limit{Requests: 1, Period: time.Second, Burst: 1}, now)
+	store.Cleanup(now.Add(time.Minute), 30*time.Second)
+
+	assert.Empty(t, store.buckets)
+}
+
+func TestRateLimiterUsesRouteLimits(t *testing.T) {
+	defaultLimit := Limit{Requests: 100, Period: time.Minute, Burst: 100}
+	exportLimit := Limit{Requests: 1, Period: time.Minute, Burst: 1}
+
+	limiter, err := NewRateLimiter(NewMemoryStore(), defaultLimit, map[string]Limit{"GET /receiver/export": exportLimit}, KeyByApiKey)
+	assert.NoError(t, err)
+
+	assert.Equal(t, exportLimit, limiter.LimitFor("GET", "/receiver/export"))
+	assert.Equal(t, defaultLimit, limiter.LimitFor("GET", "/receiver"))
+
+	result, _ := limiter.Take(context.Background(), "GET", "/receiver/export", "api_key:1")
+	assert.True(t, result.Allowed)
+	result, _ = limiter.Take(context.Background(), "GET", "/receiver/export", "api_key:1")
+	assert.False(t, result.Allowed)
+	result, _ = limiter.Take(context.Background(), "GET", "/receiver", "api_key:1")
+	assert.True(t, result.Allowed)
+
+	_, err = NewRateLimiter(NewMemoryStore(), defaultLimit, nil, "user")
+	assert.Error(t, err)
+}
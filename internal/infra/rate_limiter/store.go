@@ -0,0 +1,12 @@
+package rate_limiter
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps the token buckets. Implementations must make Take atomic per key,
+// since several requests, possibly from several instances, share a bucket.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
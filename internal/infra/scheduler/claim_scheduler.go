@@ -0,0 +1,37 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+)
+
+// ClaimResolver is implemented by claim_usecase.ClaimUseCase.
+type ClaimResolver interface {
+	ResolveClaims(ctx context.Context, now time.Time) (int, *internal_error.InternalError)
+}
+
+// RunClaims resolves the due claims every interval until ctx is canceled. Several
+// instances can run at once, since a claim changed by another instance is skipped.
+func RunClaims(ctx context.Context, resolver ClaimResolver, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			resolved, err := resolver.ResolveClaims(ctx, now)
+			if err != nil {
+				slog.Error("error resolving claims", "error", err.Error())
+				continue
+			}
+			if resolved > 0 {
+				slog.Info("claims resolved", "claims", resolved)
+			}
+		}
+	}
+}
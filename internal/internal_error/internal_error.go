@@ -31,6 +31,32 @@ func NewNotFoundError(message string) *InternalError {
 	}
 }
 
+func NewUnauthorizedError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "unauthorized",
+	}
+}
+
+func NewForbiddenError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "forbidden",
+	}
+}
+
+// NewConflictError is returned when the request clashes with data owned by another
+// record, such as a pix key already registered, or with the current state of the
+// record, such as completing a claim that is not confirmed. The causes name the
+// conflicting fields, never the record holding them.
+func NewConflictError(message string, causes ...Causes) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "conflict",
+		Causes:  causes,
+	}
+}
+
 func NewInternalServerError(message string, err error) *InternalError {
 	return &InternalError{
 		Message:       message,
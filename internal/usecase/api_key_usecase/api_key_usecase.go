@@ -0,0 +1,40 @@
+package api_key_usecase
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+)
+
+type ApiKeyUseCaseInterface interface {
+	CreateApiKey(
+		ctx context.Context,
+		input CreateApiKeyInput,
+	) (*CreateApiKeyOutput, *internal_error.InternalError)
+
+	FindApiKeys(
+		ctx context.Context,
+	) ([]ApiKeyOutput, *internal_error.InternalError)
+
+	RevokeApiKey(
+		ctx context.Context,
+		apiKeyId pkg_entity.ID,
+	) *internal_error.InternalError
+
+	Authenticate(
+		ctx context.Context,
+		credentials auth.Credentials,
+	) (*auth.Principal, *internal_error.InternalError)
+}
+
+type ApiKeyUseCase struct {
+	apiKeyRepository entity.ApiKeyRepositoryInterface
+	pepper           []byte
+}
+
+func NewApiKeyUseCase(apiKeyRepository entity.ApiKeyRepositoryInterface, pepper []byte) *ApiKeyUseCase {
+	return &ApiKeyUseCase{apiKeyRepository: apiKeyRepository, pepper: pepper}
+}
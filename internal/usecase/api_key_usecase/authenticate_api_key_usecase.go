@@ -0,0 +1,35 @@
+package api_key_usecase
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+)
+
+// Authenticate implements auth.Authenticator for the X-API-Key credential.
+func (uc *ApiKeyUseCase) Authenticate(ctx context.Context, credentials auth.Credentials) (*auth.Principal, *internal_error.InternalError) {
+	if credentials.ApiKey == "" {
+		return nil, nil
+	}
+
+	apiKey, err := uc.apiKeyRepository.FindApiKeyByHash(ctx, entity.HashApiKey(credentials.ApiKey, uc.pepper))
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil, internal_error.NewUnauthorizedError("Invalid api key")
+		}
+		return nil, err
+	}
+
+	if apiKey.IsRevoked() {
+		return nil, internal_error.NewUnauthorizedError("Api key has been revoked")
+	}
+
+	return &auth.Principal{
+		Subject:  apiKey.ApiKeyId.String(),
+		Method:   auth.MethodApiKey,
+		TenantId: apiKey.TenantId,
+		Scopes:   apiKey.Scopes,
+	}, nil
+}
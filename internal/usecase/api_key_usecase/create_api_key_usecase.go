@@ -0,0 +1,35 @@
+package api_key_usecase
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+)
+
+type CreateApiKeyInput struct {
+	Name     string   `json:"name"`
+	TenantId string   `json:"tenant_id"`
+	Scopes   []string `json:"scopes"`
+}
+
+type CreateApiKeyOutput struct {
+	ApiKeyOutput
+	Key string `json:"key"`
+}
+
+func (uc *ApiKeyUseCase) CreateApiKey(ctx context.Context, input CreateApiKeyInput) (*CreateApiKeyOutput, *internal_error.InternalError) {
+	apiKey, plaintext, err := entity.NewApiKey(input.Name, input.TenantId, input.Scopes, uc.pepper)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.apiKeyRepository.CreateApiKey(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	return &CreateApiKeyOutput{
+		ApiKeyOutput: mapApiKeyToOutput(apiKey),
+		Key:          plaintext,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package api_key_usecase
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+)
+
+type ApiKeyOutput struct {
+	ApiKeyId  string   `json:"api_key_id"`
+	TenantId  string   `json:"tenant_id"`
+	Name      string   `json:"name"`
+	Prefix    string   `json:"prefix"`
+	Scopes    []string `json:"scopes"`
+	CreatedAt string   `json:"created_at"`
+	RevokedAt string   `json:"revoked_at,omitempty"`
+}
+
+func (uc *ApiKeyUseCase) FindApiKeys(ctx context.Context) ([]ApiKeyOutput, *internal_error.InternalError) {
+	apiKeys, err := uc.apiKeyRepository.FindApiKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]ApiKeyOutput, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		output = append(output, mapApiKeyToOutput(&apiKey))
+	}
+
+	return output, nil
+}
+
+func mapApiKeyToOutput(apiKey *entity.ApiKey) ApiKeyOutput {
+	output := ApiKeyOutput{
+		ApiKeyId:  apiKey.ApiKeyId.String(),
+		TenantId:  apiKey.TenantId,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    apiKey.Scopes,
+		CreatedAt: apiKey.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if apiKey.RevokedAt != nil {
+		output.RevokedAt = apiKey.RevokedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return output
+}
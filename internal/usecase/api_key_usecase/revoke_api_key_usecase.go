@@ -0,0 +1,12 @@
+package api_key_usecase
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+)
+
+func (uc *ApiKeyUseCase) RevokeApiKey(ctx context.Context, apiKeyId pkg_entity.ID) *internal_error.InternalError {
+	return uc.apiKeyRepository.RevokeApiKey(ctx, apiKeyId)
+}
@@ -0,0 +1,117 @@
+package claim_usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type ClaimUseCaseInterface interface {
+	OpenClaim(
+		ctx context.Context,
+		input OpenClaimInput,
+	) (*ClaimOutput, *internal_error.InternalError)
+
+	FindClaims(
+		ctx context.Context,
+		input FindClaimsInput,
+	) (*FindClaimsOutput, *internal_error.InternalError)
+
+	FindClaimById(
+		ctx context.Context,
+		claimId pkg_entity.ID,
+	) (*ClaimOutput, *internal_error.InternalError)
+
+	ConfirmClaim(
+		ctx context.Context,
+		claimId pkg_entity.ID,
+	) (*ClaimOutput, *internal_error.InternalError)
+
+	CancelClaim(
+		ctx context.Context,
+		claimId pkg_entity.ID,
+	) (*ClaimOutput, *internal_error.InternalError)
+
+	CompleteClaim(
+		ctx context.Context,
+		claimId pkg_entity.ID,
+	) (*ClaimOutput, *internal_error.InternalError)
+}
+
+var tracer = otel.Tracer("github.com/felipemagrassi/pix-api/internal/usecase/claim_usecase")
+
+type ClaimUseCase struct {
+	claimRepository    entity.ClaimRepositoryInterface
+	receiverRepository entity.ReceiverRepositoryInterface
+	transactionManager entity.TransactionManagerInterface
+	// PixKeyLimits caps the keys of the claimer's owner, who gets one more key when
+	// a claim is completed.
+	PixKeyLimits entity.PixKeyLimits
+	// ResolutionPeriod is how long the donor has to confirm or cancel a claim.
+	ResolutionPeriod time.Duration
+	// CompletionPeriod is how long the claimer has to complete a confirmed claim.
+	CompletionPeriod time.Duration
+}
+
+func NewClaimUseCase(claimRepository entity.ClaimRepositoryInterface, receiverRepository entity.ReceiverRepositoryInterface, transactionManager entity.TransactionManagerInterface, resolutionPeriod, completionPeriod time.Duration) *ClaimUseCase {
+	return &ClaimUseCase{
+		claimRepository:    claimRepository,
+		receiverRepository: receiverRepository,
+		transactionManager: transactionManager,
+		PixKeyLimits:       entity.DefaultPixKeyLimits,
+		ResolutionPeriod:   resolutionPeriod,
+		CompletionPeriod:   completionPeriod,
+	}
+}
+
+type ClaimOutput struct {
+	ClaimId            string        `json:"claim_id"`
+	Type               string        `json:"type"`
+	Status             string        `json:"status"`
+	PixKey             *PixKeyOutput `json:"pix_key"`
+	ClaimerReceiverId  string        `json:"claimer_receiver_id"`
+	DonorReceiverId    string        `json:"donor_receiver_id"`
+	CancelReason       string        `json:"cancel_reason,omitempty"`
+	ResolutionDeadline string        `json:"resolution_deadline" time_format:"2006-01-02T15:04:05Z07:00"`
+	CompletionDeadline string        `json:"completion_deadline,omitempty" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedAt          string        `json:"created_at" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedAt          string        `json:"updated_at" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type PixKeyOutput struct {
+	KeyValue string `json:"value"`
+	KeyType  string `json:"type"`
+}
+
+// mapClaimToClaimOutput maps claim with its pix key masked, unless reveal is set.
+func mapClaimToClaimOutput(claim *entity.Claim, reveal bool) *ClaimOutput {
+	output := &ClaimOutput{
+		ClaimId: claim.ClaimId.String(),
+		Type:    claim.Type.String(),
+		Status:  claim.Status.String(),
+		PixKey: &PixKeyOutput{
+			KeyValue: claim.PixKey.KeyValue,
+			KeyType:  claim.PixKey.KeyType.GetTypeName(),
+		},
+		ClaimerReceiverId:  claim.ClaimerReceiverId.String(),
+		DonorReceiverId:    claim.DonorReceiverId.String(),
+		CancelReason:       claim.CancelReason,
+		ResolutionDeadline: claim.ResolutionDeadline.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedAt:          claim.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:          claim.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if claim.CompletionDeadline != nil {
+		output.CompletionDeadline = claim.CompletionDeadline.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	if !reveal {
+		output.PixKey.KeyValue = claim.PixKey.Mask()
+	}
+
+	return output
+}
@@ -0,0 +1,173 @@
+package claim_usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/claim_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/transaction"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var claimCtx = auth.WithTenant(context.Background(), "acme")
+
+func newClaimUseCase(t *testing.T) (*ClaimUseCase, *receiver_repository.MemoryReceiverRepository) {
+	receiverRepo := receiver_repository.NewMemoryReceiverRepository()
+	return NewClaimUseCase(claim_repository.NewMemoryClaimRepository(), receiverRepo, transaction.NewMemoryManager(), time.Hour, 2*time.Hour), receiverRepo
+}
+
+func createReceiver(t *testing.T, repo *receiver_repository.MemoryReceiverRepository, document, pixKeyValue, pixKeyType string) *entity.Receiver {
+	receiver, err := entity.NewReceiver(document, pixKeyValue, pixKeyType, "Receiver", "")
+	require.Nil(t, err)
+	require.Nil(t, repo.CreateReceiver(claimCtx, receiver))
+
+	return receiver
+}
+
+func parseID(t *testing.T, id string) pkg_entity.ID {
+	parsed, err := pkg_entity.ParseID(id)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func TestOwnershipClaimIsConfirmedWhenResolutionPeriodExpires(t *testing.T) {
+	uc, receiverRepo := newClaimUseCase(t)
+	donor := createReceiver(t, receiverRepo, "12345678909", "felipe@email.com", "email")
+	claimer := createReceiver(t, receiverRepo, "98765432100", "maria@email.com", "email")
+
+	output, err := uc.OpenClaim(claimCtx, OpenClaimInput{Type: "ownership", ClaimerReceiverId: claimer.ReceiverId.String(), PixKeyValue: "felipe@email.com", PixKeyType: "email"})
+	require.Nil(t, err)
+	assert.Equal(t, "OPEN", output.Status)
+	assert.Equal(t, donor.ReceiverId.String(), output.DonorReceiverId)
+
+	_, err = uc.OpenClaim(claimCtx, OpenClaimInput{Type: "ownership", ClaimerReceiverId: claimer.ReceiverId.String(), PixKeyValue: "felipe@email.com", PixKeyType: "email"})
+	assert.Equal(t, "conflict", err.Err, "a key has one active claim")
+
+	claimId := parseID(t, output.ClaimId)
+	_, err = uc.CompleteClaim(claimCtx, claimId)
+	assert.Equal(t, "conflict", err.Err, "an open claim cannot be completed")
+
+	now := time.Now()
+	resolved, err := uc.ResolveClaims(context.Background(), now)
+	require.Nil(t, err)
+	assert.Equal(t, 1, resolved)
+
+	output, err = uc.FindClaimById(claimCtx, claimId)
+	require.Nil(t, err)
+	assert.Equal(t, "WAITING_RESOLUTION", output.Status)
+
+	resolved, err = uc.ResolveClaims(context.Background(), now.Add(time.Hour))
+	require.Nil(t, err)
+	assert.Equal(t, 1, resolved)
+
+	output, err = uc.CompleteClaim(claimCtx, claimId)
+	require.Nil(t, err)
+	assert.Equal(t, "COMPLETED", output.Status)
+
+	_, err = receiverRepo.FindReceiver(claimCtx, donor.ReceiverId)
+	assert.Equal(t, "not_found", err.Err, "the donor is deleted")
+
+	holder, err := receiverRepo.FindReceiverByPixKey(claimCtx, "felipe@email.com")
+	require.Nil(t, err)
+	assert.NotEqual(t, claimer.ReceiverId, holder.ReceiverId)
+	assert.Equal(t, claimer.Document, holder.Document)
+	assert.Equal(t, entity.Draft, holder.GetStatus())
+
+	found, err := receiverRepo.FindReceiver(claimCtx, claimer.ReceiverId)
+	require.Nil(t, err)
+	assert.Equal(t, "maria@email.com", found.PixKey.KeyValue, "the claimer keeps its key")
+}
+
+func TestPortabilityClaimIsCanceledWhenResolutionPeriodExpires(t *testing.T) {
+	uc, receiverRepo := newClaimUseCase(t)
+	createReceiver(t, receiverRepo, "12345678909", "+5511999999999", "phone")
+	claimer := createReceiver(t, receiverRepo, "123.456.789-09", "maria@email.com", "email")
+	stranger := createReceiver(t, receiverRepo, "98765432100", "joana@email.com", "email")
+
+	_, err := uc.OpenClaim(claimCtx, OpenClaimInput{Type: "portability", ClaimerReceiverId: stranger.ReceiverId.String(), PixKeyValue: "11999999999", PixKeyType: "phone"})
+	assert.Equal(t, "bad_request", err.Err, "portability requires the same document")
+
+	output, err := uc.OpenClaim(claimCtx, OpenClaimInput{Type: "portability", ClaimerReceiverId: claimer.ReceiverId.String(), PixKeyValue: "11999999999", PixKeyType: "phone"})
+	require.Nil(t, err)
+
+	resolved, err := uc.ResolveClaims(context.Background(), time.Now().Add(time.Hour))
+	require.Nil(t, err)
+	assert.Equal(t, 1, resolved)
+
+	output, err = uc.FindClaimById(claimCtx, parseID(t, output.ClaimId))
+	require.Nil(t, err)
+	assert.Equal(t, "CANCELED", output.Status)
+	assert.Equal(t, entity.ClaimResolutionPeriodExpired, output.CancelReason)
+}
+
+func TestConfirmedClaimIsCanceledWhenCompletionPeriodExpires(t *testing.T) {
+	uc, receiverRepo := newClaimUseCase(t)
+	createReceiver(t, receiverRepo, "12345678909", "felipe@email.com", "email")
+	claimer := createReceiver(t, receiverRepo, "98765432100", "maria@email.com", "email")
+
+	output, err := uc.OpenClaim(claimCtx, OpenClaimInput{Type: "ownership", ClaimerReceiverId: claimer.ReceiverId.String(), PixKeyValue: "felipe@email.com", PixKeyType: "email"})
+	require.Nil(t, err)
+	claimId := parseID(t, output.ClaimId)
+
+	output, err = uc.ConfirmClaim(claimCtx, claimId)
+	require.Nil(t, err)
+	assert.Equal(t, "CONFIRMED", output.Status)
+	assert.NotEmpty(t, output.CompletionDeadline)
+
+	_, err = uc.ConfirmClaim(claimCtx, claimId)
+	assert.Equal(t, "conflict", err.Err)
+
+	resolved, err := uc.ResolveClaims(context.Background(), time.Now().Add(2*time.Hour))
+	require.Nil(t, err)
+	assert.Equal(t, 1, resolved)
+
+	output, err = uc.FindClaimById(claimCtx, claimId)
+	require.Nil(t, err)
+	assert.Equal(t, "CANCELED", output.Status)
+	assert.Equal(t, entity.ClaimCompletionPeriodExpired, output.CancelReason)
+
+	_, err = uc.CancelClaim(claimCtx, claimId)
+	assert.Equal(t, "conflict", err.Err)
+}
+
+func TestOpenClaimForUnregisteredPixKey(t *testing.T) {
+	uc, receiverRepo := newClaimUseCase(t)
+	claimer := createReceiver(t, receiverRepo, "98765432100", "maria@email.com", "email")
+
+	_, err := uc.OpenClaim(claimCtx, OpenClaimInput{Type: "ownership", ClaimerReceiverId: claimer.ReceiverId.String(), PixKeyValue: "felipe@email.com", PixKeyType: "email"})
+	assert.Equal(t, "bad_request", err.Err)
+	assert.Equal(t, "pix_key_value", err.Causes[0].Field)
+
+	_, err = uc.OpenClaim(claimCtx, OpenClaimInput{Type: "ownership", ClaimerReceiverId: claimer.ReceiverId.String(), PixKeyValue: "maria@email.com", PixKeyType: "email"})
+	assert.Equal(t, "bad_request", err.Err, "a receiver cannot claim its own key")
+}
+
+func TestCompleteClaimUsesConfiguredPixKeyLimits(t *testing.T) {
+	uc, receiverRepo := newClaimUseCase(t)
+	uc.PixKeyLimits = entity.PixKeyLimits{Cpf: 1, Cnpj: 20}
+	createReceiver(t, receiverRepo, "12345678909", "felipe@email.com", "email")
+	claimer := createReceiver(t, receiverRepo, "98765432100", "maria@email.com", "email")
+
+	output, err := uc.OpenClaim(claimCtx, OpenClaimInput{Type: "ownership", ClaimerReceiverId: claimer.ReceiverId.String(), PixKeyValue: "felipe@email.com", PixKeyType: "email"})
+	require.Nil(t, err)
+	claimId := parseID(t, output.ClaimId)
+
+	_, err = uc.ConfirmClaim(claimCtx, claimId)
+	require.Nil(t, err)
+
+	_, err = uc.CompleteClaim(claimCtx, claimId)
+	require.NotNil(t, err)
+	assert.Equal(t, "bad_request", err.Err, "the claimer already holds its only key")
+
+	uc.PixKeyLimits = entity.PixKeyLimits{Cpf: 0, Cnpj: 20}
+	output, err = uc.CompleteClaim(claimCtx, claimId)
+	require.Nil(t, err, "a zero limit is disabled")
+	assert.Equal(t, "COMPLETED", output.Status)
+}
@@ -0,0 +1,60 @@
+package claim_usecase
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type FindClaimsInput struct {
+	// Status is zero to list claims of every status.
+	Status entity.ClaimStatus `json:"status"`
+	// ReceiverId, when set, lists the claims where the receiver is the claimer or
+	// the donor.
+	ReceiverId *pkg_entity.ID `json:"receiver_id"`
+	Page       int            `json:"page"`
+}
+
+type FindClaimsOutput struct {
+	CurrentPage int           `json:"current_page"`
+	Claims      []ClaimOutput `json:"claims"`
+}
+
+func (uc *ClaimUseCase) FindClaims(ctx context.Context, input FindClaimsInput) (output *FindClaimsOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ClaimUseCase.FindClaims", trace.WithAttributes(attribute.Int("page", input.Page)))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	claims, err := uc.claimRepository.FindClaims(ctx, input.Status, input.ReceiverId, input.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	reveal := auth.CanReadPII(ctx)
+	claimsOutput := make([]ClaimOutput, 0, len(claims))
+	for i := range claims {
+		claimsOutput = append(claimsOutput, *mapClaimToClaimOutput(&claims[i], reveal))
+	}
+
+	return &FindClaimsOutput{
+		CurrentPage: input.Page,
+		Claims:      claimsOutput,
+	}, nil
+}
+
+func (uc *ClaimUseCase) FindClaimById(ctx context.Context, claimId pkg_entity.ID) (output *ClaimOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ClaimUseCase.FindClaimById", trace.WithAttributes(attribute.String("claim_id", claimId.String())))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	claim, err := uc.claimRepository.FindClaim(ctx, claimId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapClaimToClaimOutput(claim, auth.CanReadPII(ctx)), nil
+}
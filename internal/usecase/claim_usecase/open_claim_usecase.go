@@ -0,0 +1,62 @@
+package claim_usecase
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type OpenClaimInput struct {
+	Type              string `json:"type"`
+	ClaimerReceiverId string `json:"claimer_receiver_id"`
+	PixKeyValue       string `json:"pix_key_value"`
+	PixKeyType        string `json:"pix_key_type"`
+}
+
+// OpenClaim asks the receiver holding the pix key to hand it over to the claimer.
+func (uc *ClaimUseCase) OpenClaim(ctx context.Context, input OpenClaimInput) (output *ClaimOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ClaimUseCase.OpenClaim", trace.WithAttributes(attribute.String("type", input.Type), attribute.String("pix_key_type", input.PixKeyType)))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	claimerId, parseErr := pkg_entity.ParseID(input.ClaimerReceiverId)
+	if parseErr != nil {
+		return nil, internal_error.NewBadRequestError("Invalid claim", internal_error.Causes{Field: "claimer_receiver_id", Message: "Invalid ID"})
+	}
+
+	pixKey, err := entity.NewPixKey(input.PixKeyValue, input.PixKeyType)
+	if err != nil {
+		return nil, err
+	}
+
+	claimer, err := uc.receiverRepository.FindReceiver(ctx, claimerId)
+	if err != nil {
+		return nil, err
+	}
+
+	donor, err := uc.receiverRepository.FindReceiverByPixKey(ctx, pixKey.Canonical())
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil, internal_error.NewBadRequestError("Invalid claim", internal_error.Causes{Field: "pix_key_value", Message: "Pix key is not registered, register it instead"})
+		}
+		return nil, err
+	}
+
+	claim, err := entity.NewClaim(input.Type, pixKey, claimer, donor, uc.ResolutionPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.claimRepository.CreateClaim(ctx, claim); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("claim opened", "claim_id", claim.ClaimId.String(), "type", claim.Type.String(), "claimer_id", claimer.ReceiverId.String(), "donor_id", donor.ReceiverId.String(), "actor", auth.ActorFromContext(ctx))
+	return mapClaimToClaimOutput(claim, auth.CanReadPII(ctx)), nil
+}
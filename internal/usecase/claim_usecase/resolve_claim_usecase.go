@@ -0,0 +1,109 @@
+package claim_usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ConfirmClaim records that the donor agreed to hand over the pix key.
+func (uc *ClaimUseCase) ConfirmClaim(ctx context.Context, claimId pkg_entity.ID) (output *ClaimOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ClaimUseCase.ConfirmClaim", trace.WithAttributes(attribute.String("claim_id", claimId.String())))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return uc.transition(ctx, claimId, func(claim *entity.Claim, now time.Time) *internal_error.InternalError {
+		return claim.Confirm(now, uc.CompletionPeriod)
+	})
+}
+
+// CancelClaim cancels an active claim, leaving the pix key with the donor.
+func (uc *ClaimUseCase) CancelClaim(ctx context.Context, claimId pkg_entity.ID) (output *ClaimOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ClaimUseCase.CancelClaim", trace.WithAttributes(attribute.String("claim_id", claimId.String())))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return uc.transition(ctx, claimId, func(claim *entity.Claim, now time.Time) *internal_error.InternalError {
+		return claim.Cancel(now, entity.ClaimCanceledByRequest)
+	})
+}
+
+// CompleteClaim transfers the pix key of a confirmed claim to the claimer. As a
+// receiver holds a single key, the key is registered to a new draft receiver with
+// the owner and account of the claimer, which keeps its own key, and the donor,
+// left without a key, is deleted. The claim and the receivers are saved in one
+// transaction.
+func (uc *ClaimUseCase) CompleteClaim(ctx context.Context, claimId pkg_entity.ID) (output *ClaimOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ClaimUseCase.CompleteClaim", trace.WithAttributes(attribute.String("claim_id", claimId.String())))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	claim, err := uc.claimRepository.FindClaim(ctx, claimId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := claim.Complete(time.Now()); err != nil {
+		return nil, err
+	}
+
+	claimer, err := uc.receiverRepository.FindReceiver(ctx, claim.ClaimerReceiverId)
+	if err != nil {
+		return nil, err
+	}
+
+	receiver := claimer.CopyWithPixKey(claim.PixKey)
+
+	// The status check of UpdateClaim keeps a concurrent cancel or completion from
+	// applying too, and the transfer fails if another receiver registered the key
+	// after the donor deleted it; either failure rolls back both.
+	err = uc.transactionManager.RunInTransaction(ctx, func(ctx context.Context) *internal_error.InternalError {
+		if err := uc.receiverRepository.LockPixKeyOwner(ctx, claimer.Owner()); err != nil {
+			return err
+		}
+
+		registered, err := uc.receiverRepository.CountReceiversByOwner(ctx, claimer.Owner())
+		if err != nil {
+			return err
+		}
+		if err := uc.PixKeyLimits.CheckNewPixKey(claimer.Document, registered); err != nil {
+			return err
+		}
+
+		if err := uc.claimRepository.UpdateClaim(ctx, claim, entity.ClaimConfirmed); err != nil {
+			return err
+		}
+
+		return uc.receiverRepository.TransferPixKey(ctx, claim.DonorReceiverId, receiver)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("claim completed", "claim_id", claim.ClaimId.String(), "claimer_id", claimer.ReceiverId.String(), "receiver_id", receiver.ReceiverId.String(), "donor_id", claim.DonorReceiverId.String(), "actor", auth.ActorFromContext(ctx))
+	return mapClaimToClaimOutput(claim, auth.CanReadPII(ctx)), nil
+}
+
+func (uc *ClaimUseCase) transition(ctx context.Context, claimId pkg_entity.ID, apply func(claim *entity.Claim, now time.Time) *internal_error.InternalError) (*ClaimOutput, *internal_error.InternalError) {
+	claim, err := uc.claimRepository.FindClaim(ctx, claimId)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := claim.Status
+	if err := apply(claim, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.claimRepository.UpdateClaim(ctx, claim, previous); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("claim updated", "claim_id", claim.ClaimId.String(), "from", previous.String(), "to", claim.Status.String(), "actor", auth.ActorFromContext(ctx))
+	return mapClaimToClaimOutput(claim, auth.CanReadPII(ctx)), nil
+}
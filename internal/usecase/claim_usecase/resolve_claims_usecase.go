@@ -0,0 +1,50 @@
+package claim_usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+)
+
+// resolveBatchSize is the number of claims resolved per call of ResolveClaims.
+const resolveBatchSize = 100
+
+// ResolveClaims applies the transitions due at now to the claims of every tenant:
+// open claims wait for resolution, claims past the resolution deadline are
+// confirmed (ownership) or canceled (portability) and confirmed claims past the
+// completion deadline are canceled. It returns the number of claims changed.
+func (uc *ClaimUseCase) ResolveClaims(ctx context.Context, now time.Time) (resolved int, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ClaimUseCase.ResolveClaims")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	claims, err := uc.claimRepository.FindClaimsToResolve(ctx, now, resolveBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range claims {
+		claim := &claims[i]
+		previous := claim.Status
+		if !claim.Resolve(now, uc.CompletionPeriod) {
+			continue
+		}
+
+		tenantCtx := auth.WithTenant(ctx, claim.TenantId)
+		if err := uc.claimRepository.UpdateClaim(tenantCtx, claim, previous); err != nil {
+			// A conflict means the claim was confirmed, canceled or completed meanwhile.
+			if err.Err != "conflict" {
+				logger.FromContext(ctx).Error("error resolving claim", "claim_id", claim.ClaimId.String(), "error", err.Error())
+			}
+			continue
+		}
+
+		logger.FromContext(ctx).Info("claim resolved", "claim_id", claim.ClaimId.String(), "from", previous.String(), "to", claim.Status.String(), "cancel_reason", claim.CancelReason)
+		resolved++
+	}
+
+	return resolved, nil
+}
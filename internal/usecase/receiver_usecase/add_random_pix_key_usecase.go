@@ -0,0 +1,46 @@
+package receiver_usecase
+
+import (
+	"context"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AddRandomPixKey generates another random (EVP) key for the owner and account of
+// a receiver. A receiver holds a single key, so the key is registered to a new
+// draft receiver copied from it, which is returned.
+func (uc *ReceiverUseCase) AddRandomPixKey(ctx context.Context, receiverId pkg_entity.ID) (output *CreateReceiverOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ReceiverUseCase.AddRandomPixKey", trace.WithAttributes(attribute.String("receiver_id", receiverId.String())))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	receiver, err := uc.receiverRepository.FindReceiver(ctx, receiverId)
+	if err != nil {
+		return nil, err
+	}
+
+	pixKey, err := uc.generateRandomPixKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newReceiver := receiver.CopyWithPixKey(pixKey)
+	err = uc.saveWithinPixKeyLimit(ctx, newReceiver, func(ctx context.Context) *internal_error.InternalError {
+		return uc.receiverRepository.CreateReceiver(ctx, newReceiver)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.Events != nil {
+		uc.Events.ReceiverCreated(ctx, newReceiver)
+	}
+
+	logger.FromContext(ctx).Info("random pix key added", "receiver_id", receiver.ReceiverId.String(), "new_receiver_id", newReceiver.ReceiverId.String(), "actor", auth.ActorFromContext(ctx))
+	return mapReceiverToCreateReceiverOutput(newReceiver), nil
+}
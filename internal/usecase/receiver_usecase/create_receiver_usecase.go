@@ -2,21 +2,41 @@ package receiver_usecase
 
 import (
 	"context"
-	"log/slog"
 
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
 	"github.com/felipemagrassi/pix-api/internal/entity"
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type CreateReceiverInput struct {
-	Name        string `json:"name"`
-	Document    string `json:"document"`
-	Email       string `json:"email"`
+	Name     string `json:"name"`
+	Document string `json:"document"`
+	Email    string `json:"email"`
+	// PixKeyValue may be empty for random keys, which the server then generates.
 	PixKeyValue string `json:"pix_key_value"`
 	PixKeyType  string `json:"pix_key_type"`
 }
 
-func (uc *ReceiverUseCase) CreateReceiver(ctx context.Context, input CreateReceiverInput) *internal_error.InternalError {
+type CreateReceiverOutput struct {
+	ReceiverId string        `json:"receiver_id"`
+	PixKey     *PixKeyOutput `json:"pix_key"`
+}
+
+func (uc *ReceiverUseCase) CreateReceiver(ctx context.Context, input CreateReceiverInput) (output *CreateReceiverOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ReceiverUseCase.CreateReceiver", trace.WithAttributes(attribute.String("pix_key_type", input.PixKeyType)))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if entity.IsGeneratedPixKey(input.PixKeyValue, input.PixKeyType) {
+		pixKey, err := uc.generateRandomPixKey(ctx)
+		if err != nil {
+			return nil, err
+		}
+		input.PixKeyValue = pixKey.KeyValue
+	}
+
 	entity, err := entity.NewReceiver(
 		input.Document,
 		input.PixKeyValue,
@@ -25,9 +45,34 @@ func (uc *ReceiverUseCase) CreateReceiver(ctx context.Context, input CreateRecei
 		input.Email,
 	)
 	if err != nil {
-		slog.Error("error creating receiver entity")
-		return err
+		logger.FromContext(ctx).Warn("error creating receiver entity", "error", err.Error())
+		return nil, err
+	}
+
+	if err := uc.ensurePixKeyAvailable(ctx, entity); err != nil {
+		return nil, err
 	}
 
-	return uc.receiverRepository.CreateReceiver(ctx, entity)
+	err = uc.saveWithinPixKeyLimit(ctx, entity, func(ctx context.Context) *internal_error.InternalError {
+		return uc.receiverRepository.CreateReceiver(ctx, entity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.Events != nil {
+		uc.Events.ReceiverCreated(ctx, entity)
+	}
+
+	return mapReceiverToCreateReceiverOutput(entity), nil
+}
+
+func mapReceiverToCreateReceiverOutput(receiver *entity.Receiver) *CreateReceiverOutput {
+	return &CreateReceiverOutput{
+		ReceiverId: receiver.ReceiverId.String(),
+		PixKey: &PixKeyOutput{
+			KeyValue: receiver.PixKey.KeyValue,
+			KeyType:  receiver.PixKey.KeyType.GetTypeName(),
+		},
+	}
 }
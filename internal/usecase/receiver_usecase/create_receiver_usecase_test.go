@@ -0,0 +1,167 @@
+package receiver_usecase
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/transaction"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateReceiverWithRegisteredPixKey(t *testing.T) {
+	uc := newExportUseCase(t)
+
+	_, err := uc.CreateReceiver(exportCtx, CreateReceiverInput{
+		Name:        "Maria",
+		Document:    "98765432100",
+		PixKeyValue: "govrada@gmail.com",
+		PixKeyType:  "email",
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "conflict", err.Err)
+	assert.Equal(t, "pix_key_value", err.Causes[0].Field)
+	assert.NotContains(t, err.Causes[0].Message, "Felipe", "the holder is not disclosed")
+}
+
+func TestUpdateReceiverToRegisteredPixKey(t *testing.T) {
+	uc := newExportUseCase(t)
+
+	created, err := uc.CreateReceiver(exportCtx, CreateReceiverInput{
+		Name:        "Maria",
+		Document:    "98765432100",
+		PixKeyValue: "maria@email.com",
+		PixKeyType:  "email",
+	})
+	assert.Nil(t, err)
+	receiverId, parseErr := pkg_entity.ParseID(created.ReceiverId)
+	assert.Nil(t, parseErr)
+
+	err = uc.UpdateReceiver(exportCtx, receiverId, UpdateReceiverInput{PixKeyValue: "govrada@gmail.com", PixKeyType: "email"})
+	assert.Equal(t, "conflict", err.Err)
+
+	assert.Nil(t, uc.UpdateReceiver(exportCtx, receiverId, UpdateReceiverInput{Name: "Maria Silva", PixKeyValue: "maria@email.com", PixKeyType: "email"}))
+}
+
+func TestCreateReceiverWithGeneratedRandomPixKey(t *testing.T) {
+	uc := newExportUseCase(t)
+
+	created, err := uc.CreateReceiver(exportCtx, CreateReceiverInput{
+		Name:       "Maria",
+		Document:   "98765432100",
+		PixKeyType: "random",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Random", created.PixKey.KeyType)
+
+	parsedKey, parseErr := uuid.Parse(created.PixKey.KeyValue)
+	assert.Nil(t, parseErr)
+	assert.Equal(t, uuid.Version(4), parsedKey.Version())
+
+	receiverId, parseErr := pkg_entity.ParseID(created.ReceiverId)
+	assert.Nil(t, parseErr)
+	receiver, err := uc.FindReceiverById(exportCtx, receiverId)
+	assert.Nil(t, err)
+	assert.Equal(t, created.PixKey.KeyValue, receiver.PixKey.KeyValue)
+}
+
+func TestAddRandomPixKey(t *testing.T) {
+	uc := newExportUseCase(t)
+
+	created, err := uc.CreateReceiver(exportCtx, CreateReceiverInput{
+		Name:        "Maria",
+		Document:    "98765432100",
+		Email:       "maria@email.com",
+		PixKeyValue: "maria@email.com",
+		PixKeyType:  "email",
+	})
+	assert.Nil(t, err)
+	receiverId, parseErr := pkg_entity.ParseID(created.ReceiverId)
+	assert.Nil(t, parseErr)
+
+	added, err := uc.AddRandomPixKey(exportCtx, receiverId)
+	assert.Nil(t, err)
+	assert.NotEqual(t, created.ReceiverId, added.ReceiverId)
+	assert.Equal(t, "Random", added.PixKey.KeyType)
+
+	addedId, parseErr := pkg_entity.ParseID(added.ReceiverId)
+	assert.Nil(t, parseErr)
+	receiver, err := uc.FindReceiverById(exportCtx, addedId)
+	assert.Nil(t, err)
+	assert.Equal(t, "Maria", receiver.Name)
+	assert.Equal(t, "98765432100", receiver.Document)
+	assert.Equal(t, entity.Draft, receiver.Status)
+
+	_, err = uc.AddRandomPixKey(exportCtx, pkg_entity.NewID())
+	assert.Equal(t, "not_found", err.Err)
+}
+
+func TestPixKeyLimitPerOwner(t *testing.T) {
+	uc := NewReceiverUseCase(receiver_repository.NewMemoryReceiverRepository())
+	uc.PixKeyLimits = entity.PixKeyLimits{Cpf: 2, Cnpj: 20}
+
+	first, err := uc.CreateReceiver(exportCtx, CreateReceiverInput{Name: "Maria", Document: "98765432100", PixKeyType: "random"})
+	assert.Nil(t, err)
+	_, err = uc.CreateReceiver(exportCtx, CreateReceiverInput{Name: "Maria", Document: "987.654.321-00", PixKeyValue: "maria@email.com", PixKeyType: "email"})
+	assert.Nil(t, err)
+
+	_, err = uc.CreateReceiver(exportCtx, CreateReceiverInput{Name: "Maria", Document: "98765432100", PixKeyType: "random"})
+	assert.Equal(t, "bad_request", err.Err)
+	assert.Equal(t, "pix_key_value", err.Causes[0].Field)
+
+	firstId, parseErr := pkg_entity.ParseID(first.ReceiverId)
+	assert.Nil(t, parseErr)
+	_, err = uc.AddRandomPixKey(exportCtx, firstId)
+	assert.Equal(t, "bad_request", err.Err)
+
+	other, err := uc.CreateReceiver(exportCtx, CreateReceiverInput{Name: "Felipe", Document: "12345678909", PixKeyType: "random"})
+	assert.Nil(t, err)
+	otherId, parseErr := pkg_entity.ParseID(other.ReceiverId)
+	assert.Nil(t, parseErr)
+	err = uc.UpdateReceiver(exportCtx, otherId, UpdateReceiverInput{Document: "98765432100"})
+	assert.Equal(t, "bad_request", err.Err, "moving a key to an owner at the limit")
+	assert.Nil(t, uc.UpdateReceiver(exportCtx, otherId, UpdateReceiverInput{Name: "Felipe Silva"}))
+}
+
+func TestPixKeyLimitHoldsForConcurrentRequests(t *testing.T) {
+	uc := NewReceiverUseCase(receiver_repository.NewMemoryReceiverRepository())
+	uc.PixKeyLimits = entity.PixKeyLimits{Cpf: 2, Cnpj: 20}
+	uc.Transactions = transaction.NewMemoryManager()
+
+	var wg sync.WaitGroup
+	var created atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := uc.CreateReceiver(exportCtx, CreateReceiverInput{Name: "Maria", Document: "98765432100", PixKeyType: "random"}); err == nil {
+				created.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), created.Load())
+}
+
+func TestImportReceiversReportsRegisteredPixKeys(t *testing.T) {
+	uc := NewReceiverUseCase(&receiver_repository.MemoryReceiverRepository{})
+
+	file := strings.Join([]string{
+		"name,document,email,pix_key_type,pix_key_value",
+		"Felipe,12345678909,felipe@email.com,cpf,123.456.789-09",
+		"Maria,12345678909,maria@email.com,cpf,12345678909",
+	}, "\n")
+
+	output, err := uc.ImportReceivers(exportCtx, ImportReceiversInput{Format: ExportFormatCSV}, strings.NewReader(file))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, output.Created)
+	assert.Len(t, output.Failed, 1)
+	assert.Equal(t, 3, output.Failed[0].Line)
+	assert.Equal(t, "Pix key already registered", output.Failed[0].Message)
+}
@@ -3,14 +3,46 @@ package receiver_usecase
 import (
 	"context"
 
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/entity"
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
 	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type DeleteReceiversInput struct {
 	ReceiverIds []pkg_entity.ID
 }
 
-func (uc *ReceiverUseCase) DeleteReceivers(ctx context.Context, input DeleteReceiversInput) *internal_error.InternalError {
-	return uc.receiverRepository.DeleteManyReceivers(ctx, input.ReceiverIds)
+func (uc *ReceiverUseCase) DeleteReceivers(ctx context.Context, input DeleteReceiversInput) (err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ReceiverUseCase.DeleteReceivers", trace.WithAttributes(attribute.Int("receivers", len(input.ReceiverIds))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if uc.Events == nil {
+		return uc.receiverRepository.DeleteManyReceivers(ctx, input.ReceiverIds)
+	}
+
+	// Load the receivers first so the events know what was deleted.
+	deleted := make([]*entity.Receiver, 0, len(input.ReceiverIds))
+	for _, receiverId := range input.ReceiverIds {
+		receiver, err := uc.receiverRepository.FindReceiver(ctx, receiverId)
+		if err != nil {
+			if err.Err == "not_found" {
+				continue
+			}
+			return err
+		}
+		deleted = append(deleted, receiver)
+	}
+
+	if err := uc.receiverRepository.DeleteManyReceivers(ctx, input.ReceiverIds); err != nil {
+		return err
+	}
+
+	for _, receiver := range deleted {
+		uc.Events.ReceiverDeleted(ctx, receiver)
+	}
+
+	return nil
 }
@@ -0,0 +1,239 @@
+package receiver_usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// ExportColumns lists the columns available for export, in their default order.
+var ExportColumns = []string{
+	"receiver_id",
+	"name",
+	"document",
+	"email",
+	"status",
+	"bank",
+	"office",
+	"account_number",
+	"pix_key_type",
+	"pix_key_value",
+	"created_at",
+	"updated_at",
+}
+
+type ExportReceiversInput struct {
+	Status      entity.ReceiverStatus
+	Name        string
+	PixKeyValue string
+	PixKeyType  entity.PixKeyType
+	Format      string
+	Columns     []string
+	// Mask masks the document, email, account number and pix key. Exporting them
+	// unmasked requires auth.ScopePiiRead.
+	Mask bool
+}
+
+// ExportReceiversOutput is returned with the error too, so a caller that already
+// sent part of the export can tell how many rows were written before it failed.
+type ExportReceiversOutput struct {
+	Rows int
+}
+
+type exportRowWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(columns, values []string) error
+	Flush() error
+}
+
+func (uc *ReceiverUseCase) ExportReceivers(ctx context.Context, input ExportReceiversInput, w io.Writer) (output *ExportReceiversOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ReceiverUseCase.ExportReceivers", trace.WithAttributes(attribute.String("format", input.Format)))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	output = &ExportReceiversOutput{}
+
+	if !input.Mask && !auth.CanReadPII(ctx) {
+		return output, internal_error.NewForbiddenError("Exporting unmasked personal data requires the pii:read scope")
+	}
+
+	columns := input.Columns
+	if len(columns) == 0 {
+		columns = ExportColumns
+	}
+
+	for _, column := range columns {
+		if !isExportColumn(column) {
+			return output, internal_error.NewBadRequestError("Invalid export column", internal_error.Causes{Field: "columns", Message: fmt.Sprintf("Unknown column %s", column)})
+		}
+	}
+
+	var rowWriter exportRowWriter
+	buffered := bufio.NewWriter(w)
+
+	switch input.Format {
+	case ExportFormatCSV:
+		rowWriter = &csvRowWriter{writer: csv.NewWriter(buffered)}
+	case ExportFormatNDJSON:
+		rowWriter = &ndjsonRowWriter{writer: buffered}
+	default:
+		return output, internal_error.NewBadRequestError("Invalid export format", internal_error.Causes{Field: "format", Message: "Format must be csv or ndjson"})
+	}
+
+	if err := rowWriter.WriteHeader(columns); err != nil {
+		return output, internal_error.NewInternalServerError("error writing export", err)
+	}
+
+	err = uc.receiverRepository.StreamReceivers(ctx, input.Status, input.Name, input.PixKeyValue, input.PixKeyType, func(receiver *entity.Receiver) *internal_error.InternalError {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = exportColumnValue(receiver, column, input.Mask)
+		}
+
+		if err := rowWriter.WriteRow(columns, values); err != nil {
+			return internal_error.NewInternalServerError("error writing export", err)
+		}
+		output.Rows++
+
+		return nil
+	})
+	if err != nil {
+		return output, err
+	}
+
+	if err := rowWriter.Flush(); err != nil {
+		return output, internal_error.NewInternalServerError("error writing export", err)
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return output, internal_error.NewInternalServerError("error writing export", err)
+	}
+
+	return output, nil
+}
+
+func isExportColumn(column string) bool {
+	for _, exportColumn := range ExportColumns {
+		if exportColumn == column {
+			return true
+		}
+	}
+
+	return false
+}
+
+func exportColumnValue(receiver *entity.Receiver, column string, mask bool) string {
+	switch column {
+	case "receiver_id":
+		return receiver.ReceiverId.String()
+	case "name":
+		return receiver.Name
+	case "document":
+		if receiver.Document == nil {
+			return ""
+		}
+		if mask {
+			return receiver.Document.Mask()
+		}
+		return receiver.Document.String()
+	case "email":
+		if mask {
+			return receiver.Email.Mask()
+		}
+		return receiver.Email.String()
+	case "status":
+		return strconv.Itoa(int(receiver.GetStatus()))
+	case "bank":
+		return receiver.Bank
+	case "office":
+		return receiver.Office
+	case "account_number":
+		if mask {
+			return entity.MaskAccountNumber(receiver.AccountNumber)
+		}
+		return receiver.AccountNumber
+	case "pix_key_type":
+		if receiver.PixKey == nil {
+			return ""
+		}
+		return receiver.PixKey.KeyType.GetTypeName()
+	case "pix_key_value":
+		if receiver.PixKey == nil {
+			return ""
+		}
+		if mask {
+			return receiver.PixKey.Mask()
+		}
+		return receiver.PixKey.KeyValue
+	case "created_at":
+		return receiver.CreatedAt.Format("2006-01-02T15:04:05Z07:00")
+	case "updated_at":
+		return receiver.UpdatedAt.Format("2006-01-02T15:04:05Z07:00")
+	default:
+		return ""
+	}
+}
+
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+func (cw *csvRowWriter) WriteHeader(columns []string) error {
+	return cw.writer.Write(columns)
+}
+
+func (cw *csvRowWriter) WriteRow(columns, values []string) error {
+	return cw.writer.Write(values)
+}
+
+func (cw *csvRowWriter) Flush() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+type ndjsonRowWriter struct {
+	writer io.Writer
+}
+
+func (nw *ndjsonRowWriter) WriteHeader(columns []string) error {
+	return nil
+}
+
+// WriteRow writes one JSON object per line, keeping the requested column order.
+func (nw *ndjsonRowWriter) WriteRow(columns, values []string) error {
+	line := []byte{'{'}
+	for i, column := range columns {
+		if i > 0 {
+			line = append(line, ',')
+		}
+
+		key, _ := json.Marshal(column)
+		value, _ := json.Marshal(values[i])
+		line = append(line, key...)
+		line = append(line, ':')
+		line = append(line, value...)
+	}
+	line = append(line, '}', '\n')
+
+	_, err := nw.writer.Write(line)
+	return err
+}
+
+func (nw *ndjsonRowWriter) Flush() error {
+	return nil
+}
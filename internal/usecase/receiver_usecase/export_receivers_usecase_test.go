@@ -0,0 +1,107 @@
+package receiver_usecase
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var exportCtx = auth.WithTenant(context.Background(), "acme")
+
+func newExportUseCase(t *testing.T) *ReceiverUseCase {
+	repo := &receiver_repository.MemoryReceiverRepository{}
+
+	receiver, err := entity.NewReceiver("12345678909", "govrada@gmail.com", "email", "Felipe", "felipe@email.com")
+	assert.Nil(t, err)
+	assert.Nil(t, repo.CreateReceiver(exportCtx, receiver))
+
+	return NewReceiverUseCase(repo)
+}
+
+func TestExportReceiversAsCSV(t *testing.T) {
+	uc := newExportUseCase(t)
+
+	var buffer bytes.Buffer
+	output, err := uc.ExportReceivers(exportCtx, ExportReceiversInput{
+		Status:     -1,
+		PixKeyType: -1,
+		Format:     ExportFormatCSV,
+		Columns:    []string{"name", "document", "pix_key_value"},
+		Mask:       true,
+	}, &buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, output.Rows)
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Equal(t, []string{"name,document,pix_key_value", "Felipe,***.456.789-**,g******@gmail.com"}, lines)
+}
+
+func TestExportReceiversAsNDJSON(t *testing.T) {
+	uc := newExportUseCase(t)
+
+	var buffer bytes.Buffer
+	_, err := uc.ExportReceivers(exportCtx, ExportReceiversInput{
+		Status:     -1,
+		PixKeyType: -1,
+		Format:     ExportFormatNDJSON,
+		Columns:    []string{"name", "document"},
+	}, &buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"name\":\"Felipe\",\"document\":\"12345678909\"}\n", buffer.String())
+}
+
+func TestExportReceiversRejectsInvalidInput(t *testing.T) {
+	uc := newExportUseCase(t)
+
+	var buffer bytes.Buffer
+	_, err := uc.ExportReceivers(exportCtx, ExportReceiversInput{Format: "xml"}, &buffer)
+	assert.Error(t, err)
+	assert.Equal(t, "bad_request", err.Err)
+
+	_, err = uc.ExportReceivers(exportCtx, ExportReceiversInput{Format: ExportFormatCSV, Columns: []string{"password"}}, &buffer)
+	assert.Error(t, err)
+	assert.Equal(t, "columns", err.Causes[0].Field)
+	assert.Empty(t, buffer.String())
+}
+
+func TestExportReceiversOnlyExportsTenantReceivers(t *testing.T) {
+	uc := newExportUseCase(t)
+
+	var buffer bytes.Buffer
+	_, err := uc.ExportReceivers(auth.WithTenant(context.Background(), "globex"), ExportReceiversInput{
+		Status:     -1,
+		PixKeyType: -1,
+		Format:     ExportFormatCSV,
+		Columns:    []string{"name"},
+	}, &buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, "name\n", buffer.String())
+}
+
+func TestExportUnmaskedRequiresPiiReadScope(t *testing.T) {
+	uc := newExportUseCase(t)
+	input := ExportReceiversInput{Status: -1, PixKeyType: -1, Format: ExportFormatCSV, Columns: []string{"document", "email"}}
+
+	readerCtx := auth.WithPrincipal(exportCtx, &auth.Principal{Subject: "reader", Scopes: []string{auth.ScopeReceiversRead}})
+	_, err := uc.ExportReceivers(readerCtx, input, &bytes.Buffer{})
+	assert.Equal(t, "forbidden", err.Err)
+
+	var buffer bytes.Buffer
+	input.Mask = true
+	_, err = uc.ExportReceivers(readerCtx, input, &buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, "document,email\n***.456.789-**,f*****@email.com\n", buffer.String())
+
+	buffer.Reset()
+	input.Mask = false
+	piiCtx := auth.WithPrincipal(exportCtx, &auth.Principal{Subject: "auditor", Scopes: []string{auth.ScopeReceiversRead, auth.ScopePiiRead}})
+	_, err = uc.ExportReceivers(piiCtx, input, &buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, "document,email\n12345678909,felipe@email.com\n", buffer.String())
+}
@@ -3,9 +3,13 @@ package receiver_usecase
 import (
 	"context"
 
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/auth"
 	"github.com/felipemagrassi/pix-api/internal/entity"
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
 	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type FindReceiversInput struct {
@@ -40,38 +44,22 @@ type PixKeyOutput struct {
 	KeyType  string `json:"type,omitempty"`
 }
 
-func (uc *ReceiverUseCase) FindReceivers(ctx context.Context, input FindReceiversInput) (*FindReceiversOutput, *internal_error.InternalError) {
+func (uc *ReceiverUseCase) FindReceivers(ctx context.Context, input FindReceiversInput) (output *FindReceiversOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ReceiverUseCase.FindReceivers", trace.WithAttributes(attribute.Int("page", input.Page)))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	receivers, err := uc.receiverRepository.FindReceivers(ctx, input.Status, input.Name, input.PixKeyValue, input.PixKeyType, input.Page)
 	if err != nil {
 		return nil, err
 	}
 
+	reveal := auth.CanReadPII(ctx)
 	receiversOutput := make([]FindReceiverOutput, 0)
-	for _, receiver := range receivers {
-		output := FindReceiverOutput{
-			ReceiverId:    receiver.ReceiverId.String(),
-			Name:          receiver.Name,
-			Document:      receiver.Document.String(),
-			Email:         receiver.Email.String(),
-			Status:        receiver.GetStatus(),
-			Bank:          receiver.Bank,
-			Office:        receiver.Office,
-			AccountNumber: receiver.AccountNumber,
-			CreatedAt:     receiver.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt:     receiver.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		}
-
-		if receiver.PixKey != nil {
-			output.PixKey = &PixKeyOutput{
-				KeyValue: receiver.PixKey.KeyValue,
-				KeyType:  receiver.PixKey.KeyType.GetTypeName(),
-			}
-		}
-
-		receiversOutput = append(receiversOutput, output)
+	for i := range receivers {
+		receiversOutput = append(receiversOutput, *mapReceiverToFindReceiverOutput(&receivers[i], reveal))
 	}
 
-	output := &FindReceiversOutput{
+	output = &FindReceiversOutput{
 		CurrentPage: input.Page,
 		Receivers:   receiversOutput,
 	}
@@ -79,13 +67,22 @@ func (uc *ReceiverUseCase) FindReceivers(ctx context.Context, input FindReceiver
 	return output, nil
 }
 
-func (uc *ReceiverUseCase) FindReceiverById(ctx context.Context, receiverId pkg_entity.ID) (*FindReceiverOutput, *internal_error.InternalError) {
+func (uc *ReceiverUseCase) FindReceiverById(ctx context.Context, receiverId pkg_entity.ID) (output *FindReceiverOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ReceiverUseCase.FindReceiverById", trace.WithAttributes(attribute.String("receiver_id", receiverId.String())))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	receiver, err := uc.receiverRepository.FindReceiver(ctx, receiverId)
 	if err != nil {
 		return nil, err
 	}
 
-	return &FindReceiverOutput{
+	return mapReceiverToFindReceiverOutput(receiver, auth.CanReadPII(ctx)), nil
+}
+
+// mapReceiverToFindReceiverOutput maps receiver with its document, email, account
+// number and pix key masked, unless reveal is set.
+func mapReceiverToFindReceiverOutput(receiver *entity.Receiver, reveal bool) *FindReceiverOutput {
+	output := &FindReceiverOutput{
 		ReceiverId:    receiver.ReceiverId.String(),
 		Name:          receiver.Name,
 		Document:      receiver.Document.String(),
@@ -94,11 +91,25 @@ func (uc *ReceiverUseCase) FindReceiverById(ctx context.Context, receiverId pkg_
 		Bank:          receiver.Bank,
 		Office:        receiver.Office,
 		AccountNumber: receiver.AccountNumber,
-		PixKey: &PixKeyOutput{
+		CreatedAt:     receiver.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:     receiver.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if receiver.PixKey != nil {
+		output.PixKey = &PixKeyOutput{
 			KeyValue: receiver.PixKey.KeyValue,
 			KeyType:  receiver.PixKey.KeyType.GetTypeName(),
-		},
-		CreatedAt: receiver.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: receiver.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}, nil
+		}
+	}
+
+	if !reveal {
+		output.Document = receiver.Document.Mask()
+		output.Email = receiver.Email.Mask()
+		output.AccountNumber = entity.MaskAccountNumber(receiver.AccountNumber)
+		if output.PixKey != nil {
+			output.PixKey.KeyValue = receiver.PixKey.Mask()
+		}
+	}
+
+	return output
 }
@@ -0,0 +1,43 @@
+package receiver_usecase
+
+import (
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindReceiversMasksPersonalData(t *testing.T) {
+	repo := receiver_repository.NewMemoryReceiverRepository()
+	created, createErr := entity.NewReceiver("12345678909", "govrada@gmail.com", "email", "Felipe", "felipe@email.com")
+	require.Nil(t, createErr)
+	created.AccountNumber = "123456"
+	require.Nil(t, repo.CreateReceiver(exportCtx, created))
+	uc := NewReceiverUseCase(repo)
+
+	readerCtx := auth.WithPrincipal(exportCtx, &auth.Principal{Subject: "reader", Scopes: []string{auth.ScopeReceiversRead}})
+
+	output, err := uc.FindReceivers(readerCtx, FindReceiversInput{Status: -1, PixKeyType: -1, Page: 1})
+	require.Nil(t, err)
+	require.Len(t, output.Receivers, 1)
+	receiver := output.Receivers[0]
+	assert.Equal(t, "***.456.789-**", receiver.Document)
+	assert.Equal(t, "f*****@email.com", receiver.Email)
+	assert.Equal(t, "**3456", receiver.AccountNumber)
+	assert.Equal(t, "g******@gmail.com", receiver.PixKey.KeyValue)
+
+	found, err := uc.FindReceiverById(readerCtx, created.ReceiverId)
+	require.Nil(t, err)
+	assert.Equal(t, receiver, *found)
+
+	piiCtx := auth.WithPrincipal(exportCtx, &auth.Principal{Subject: "auditor", Scopes: []string{auth.ScopeReceiversRead, auth.ScopePiiRead}})
+	found, err = uc.FindReceiverById(piiCtx, created.ReceiverId)
+	require.Nil(t, err)
+	assert.Equal(t, "12345678909", found.Document)
+	assert.Equal(t, "felipe@email.com", found.Email)
+	assert.Equal(t, "123456", found.AccountNumber)
+	assert.Equal(t, "govrada@gmail.com", found.PixKey.KeyValue)
+}
@@ -0,0 +1,173 @@
+package receiver_usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// importColumns are read from each row; any other column, such as those of an
+// export, is ignored.
+var importColumns = []string{"name", "document", "email", "pix_key_type", "pix_key_value"}
+
+type ImportReceiversInput struct {
+	Format string
+}
+
+type ImportReceiversOutput struct {
+	Created int              `json:"created"`
+	Failed  []ImportRowError `json:"failed"`
+}
+
+type ImportRowError struct {
+	Line    int                `json:"line"`
+	Message string             `json:"message"`
+	Causes  []ImportErrorCause `json:"causes,omitempty"`
+}
+
+type ImportErrorCause struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ImportReceivers creates a draft receiver for each row of a CSV file with a
+// header or of an NDJSON file, in the column names of ExportReceivers. Invalid
+// rows and rows with a pix key already registered are reported in the output and
+// do not stop the import.
+func (uc *ReceiverUseCase) ImportReceivers(ctx context.Context, input ImportReceiversInput, r io.Reader) (output *ImportReceiversOutput, err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ReceiverUseCase.ImportReceivers", trace.WithAttributes(attribute.String("format", input.Format)))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	output = &ImportReceiversOutput{Failed: make([]ImportRowError, 0)}
+
+	importRow := func(line int, row map[string]string) *internal_error.InternalError {
+		_, createErr := uc.CreateReceiver(ctx, CreateReceiverInput{
+			Name:        row["name"],
+			Document:    row["document"],
+			Email:       row["email"],
+			PixKeyValue: row["pix_key_value"],
+			PixKeyType:  row["pix_key_type"],
+		})
+		if createErr == nil {
+			output.Created++
+			return nil
+		}
+
+		if createErr.Err != "bad_request" && createErr.Err != "conflict" {
+			return createErr
+		}
+
+		output.Failed = append(output.Failed, importRowError(line, createErr))
+		return nil
+	}
+
+	switch input.Format {
+	case ExportFormatCSV:
+		err = importCSV(r, output, importRow)
+	case ExportFormatNDJSON:
+		err = importNDJSON(r, output, importRow)
+	default:
+		return nil, internal_error.NewBadRequestError("Invalid import format", internal_error.Causes{Field: "format", Message: "Format must be csv or ndjson"})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func importCSV(r io.Reader, output *ImportReceiversOutput, importRow func(line int, row map[string]string) *internal_error.InternalError) *internal_error.InternalError {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, readErr := reader.Read()
+	if errors.Is(readErr, io.EOF) {
+		return nil
+	}
+	if readErr != nil {
+		return internal_error.NewBadRequestError("Invalid import file", internal_error.Causes{Field: "file", Message: readErr.Error()})
+	}
+
+	for {
+		record, readErr := reader.Read()
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(readErr, &parseErr) {
+			output.Failed = append(output.Failed, ImportRowError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+			continue
+		}
+		if readErr != nil {
+			return internal_error.NewInternalServerError("error reading import", readErr)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := make(map[string]string, len(importColumns))
+		for i, column := range header {
+			if i < len(record) {
+				row[strings.TrimSpace(column)] = record[i]
+			}
+		}
+
+		if err := importRow(line, row); err != nil {
+			return err
+		}
+	}
+}
+
+func importNDJSON(r io.Reader, output *ImportReceiversOutput, importRow func(line int, row map[string]string) *internal_error.InternalError) *internal_error.InternalError {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var values map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &values); err != nil {
+			output.Failed = append(output.Failed, ImportRowError{Line: line, Message: "invalid JSON: " + err.Error()})
+			continue
+		}
+
+		row := make(map[string]string, len(importColumns))
+		for _, column := range importColumns {
+			if value, found := values[column]; found && value != nil {
+				row[column] = fmt.Sprint(value)
+			}
+		}
+
+		if err := importRow(line, row); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return internal_error.NewInternalServerError("error reading import", err)
+	}
+
+	return nil
+}
+
+func importRowError(line int, err *internal_error.InternalError) ImportRowError {
+	rowError := ImportRowError{Line: line, Message: err.Message}
+	for _, cause := range err.Causes {
+		rowError.Causes = append(rowError.Causes, ImportErrorCause{Field: cause.Field, Message: cause.Message})
+	}
+
+	return rowError
+}
@@ -0,0 +1,56 @@
+package receiver_usecase
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportReceiversFromCSV(t *testing.T) {
+	repo := &receiver_repository.MemoryReceiverRepository{}
+	uc := NewReceiverUseCase(repo)
+
+	file := strings.Join([]string{
+		"name,document,email,pix_key_type,pix_key_value,status",
+		"Felipe,12345678909,felipe@email.com,email,felipe@email.com,1",
+		"Maria,12345678909,maria@email.com,cpf,",
+		`"broken,quote`,
+	}, "\n")
+
+	output, err := uc.ImportReceivers(exportCtx, ImportReceiversInput{Format: ExportFormatCSV}, strings.NewReader(file))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, output.Created)
+	assert.Len(t, output.Failed, 2)
+	assert.Equal(t, 3, output.Failed[0].Line)
+	assert.Equal(t, 4, output.Failed[1].Line)
+
+	receivers, err := repo.FindReceivers(exportCtx, -1, "", "", -1, 1)
+	assert.Nil(t, err)
+	assert.Len(t, receivers, 1)
+	assert.Equal(t, "Felipe", receivers[0].Name)
+}
+
+func TestImportReceiversRoundTripsAnExport(t *testing.T) {
+	source := newExportUseCase(t)
+
+	var export bytes.Buffer
+	_, exportErr := source.ExportReceivers(exportCtx, ExportReceiversInput{Status: -1, PixKeyType: -1, Format: ExportFormatNDJSON}, &export)
+	assert.Nil(t, exportErr)
+
+	uc := NewReceiverUseCase(&receiver_repository.MemoryReceiverRepository{})
+	output, err := uc.ImportReceivers(exportCtx, ImportReceiversInput{Format: ExportFormatNDJSON}, bytes.NewReader(append(export.Bytes(), []byte("\nnot json\n")...)))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, output.Created)
+	assert.Len(t, output.Failed, 1)
+	assert.Equal(t, 3, output.Failed[0].Line)
+}
+
+func TestImportReceiversRejectsUnknownFormat(t *testing.T) {
+	uc := NewReceiverUseCase(&receiver_repository.MemoryReceiverRepository{})
+
+	_, err := uc.ImportReceivers(exportCtx, ImportReceiversInput{Format: "xml"}, strings.NewReader(""))
+	assert.Equal(t, "bad_request", err.Err)
+}
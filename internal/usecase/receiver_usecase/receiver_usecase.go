@@ -2,17 +2,25 @@ package receiver_usecase
 
 import (
 	"context"
+	"io"
 
+	"github.com/felipemagrassi/pix-api/configuration/logger"
 	"github.com/felipemagrassi/pix-api/internal/entity"
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
 	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"go.opentelemetry.io/otel"
 )
 
 type ReceiverUseCaseInterface interface {
 	CreateReceiver(
 		ctx context.Context,
 		input CreateReceiverInput,
-	) *internal_error.InternalError
+	) (*CreateReceiverOutput, *internal_error.InternalError)
+
+	AddRandomPixKey(
+		ctx context.Context,
+		receiverId pkg_entity.ID,
+	) (*CreateReceiverOutput, *internal_error.InternalError)
 
 	UpdateReceiver(
 		ctx context.Context,
@@ -33,12 +41,133 @@ type ReceiverUseCaseInterface interface {
 		ctx context.Context,
 		input DeleteReceiversInput,
 	) *internal_error.InternalError
+
+	ExportReceivers(
+		ctx context.Context,
+		input ExportReceiversInput,
+		w io.Writer,
+	) (*ExportReceiversOutput, *internal_error.InternalError)
+
+	ValidateReceiver(
+		ctx context.Context,
+		receiverId pkg_entity.ID,
+	) *internal_error.InternalError
+
+	ImportReceivers(
+		ctx context.Context,
+		input ImportReceiversInput,
+		r io.Reader,
+	) (*ImportReceiversOutput, *internal_error.InternalError)
+}
+
+// generateRandomPixKeyAttempts is the number of random keys generated before
+// giving up, when every one of them is already registered.
+const generateRandomPixKeyAttempts = 3
+
+var tracer = otel.Tracer("github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase")
+
+// ReceiverEvents is notified after a receiver is created, validated or deleted.
+type ReceiverEvents interface {
+	ReceiverCreated(ctx context.Context, receiver *entity.Receiver)
+	ReceiverValidated(ctx context.Context, receiver *entity.Receiver)
+	ReceiverDeleted(ctx context.Context, receiver *entity.Receiver)
 }
 
 type ReceiverUseCase struct {
 	receiverRepository entity.ReceiverRepositoryInterface
+	// Events is optional; when set it is notified of receiver lifecycle changes.
+	Events ReceiverEvents
+	// PixKeyLimits is the number of keys an owner can hold, see
+	// entity.DefaultPixKeyLimits.
+	PixKeyLimits entity.PixKeyLimits
+	// Transactions is optional; when set the key limit is checked and the key
+	// saved in one unit of work per owner, see saveWithinPixKeyLimit.
+	Transactions entity.TransactionManagerInterface
 }
 
 func NewReceiverUseCase(receiverRepository entity.ReceiverRepositoryInterface) *ReceiverUseCase {
-	return &ReceiverUseCase{receiverRepository: receiverRepository}
+	return &ReceiverUseCase{receiverRepository: receiverRepository, PixKeyLimits: entity.DefaultPixKeyLimits}
+}
+
+// ensurePixKeyAvailable returns a conflict error when the pix key of receiver is
+// registered to another receiver of the tenant. The repositories also enforce it
+// with a unique index, for requests racing past this check.
+func (uc *ReceiverUseCase) ensurePixKeyAvailable(ctx context.Context, receiver *entity.Receiver) *internal_error.InternalError {
+	holder, err := uc.receiverRepository.FindReceiverByPixKey(ctx, receiver.PixKey.Canonical())
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil
+		}
+		return err
+	}
+
+	if holder.ReceiverId == receiver.ReceiverId {
+		return nil
+	}
+
+	logger.FromContext(ctx).Warn("pix key already registered", "receiver_id", receiver.ReceiverId.String(), "holder_id", holder.ReceiverId.String())
+	return entity.NewPixKeyConflictError()
+}
+
+// saveWithinPixKeyLimit runs save, which registers the key of receiver, when the
+// owner of receiver holds fewer keys than its limit. With Transactions set, both
+// run in a unit of work locking the owner, so concurrent requests cannot go past
+// the limit; without it, racing requests may.
+func (uc *ReceiverUseCase) saveWithinPixKeyLimit(ctx context.Context, receiver *entity.Receiver, save func(ctx context.Context) *internal_error.InternalError) *internal_error.InternalError {
+	if uc.Transactions == nil {
+		if err := uc.ensurePixKeyLimit(ctx, receiver); err != nil {
+			return err
+		}
+
+		return save(ctx)
+	}
+
+	return uc.Transactions.RunInTransaction(ctx, func(ctx context.Context) *internal_error.InternalError {
+		if err := uc.receiverRepository.LockPixKeyOwner(ctx, receiver.Owner()); err != nil {
+			return err
+		}
+
+		if err := uc.ensurePixKeyLimit(ctx, receiver); err != nil {
+			return err
+		}
+
+		return save(ctx)
+	})
+}
+
+// ensurePixKeyLimit returns a bad request error when the owner of receiver already
+// holds as many keys as its document allows, so it cannot register another one.
+func (uc *ReceiverUseCase) ensurePixKeyLimit(ctx context.Context, receiver *entity.Receiver) *internal_error.InternalError {
+	registered, err := uc.receiverRepository.CountReceiversByOwner(ctx, receiver.Owner())
+	if err != nil {
+		return err
+	}
+
+	if err := uc.PixKeyLimits.CheckNewPixKey(receiver.Document, registered); err != nil {
+		logger.FromContext(ctx).Warn("pix key limit reached", "receiver_id", receiver.ReceiverId.String(), "registered", registered)
+		return err
+	}
+
+	return nil
+}
+
+// generateRandomPixKey generates a random (EVP) key not registered in the tenant.
+// Collisions of UUIDv4 keys are unlikely, so a few attempts are enough.
+func (uc *ReceiverUseCase) generateRandomPixKey(ctx context.Context) (*entity.PixKey, *internal_error.InternalError) {
+	for attempt := 0; attempt < generateRandomPixKeyAttempts; attempt++ {
+		pixKey := entity.NewRandomPixKey()
+
+		_, err := uc.receiverRepository.FindReceiverByPixKey(ctx, pixKey.Canonical())
+		if err == nil {
+			logger.FromContext(ctx).Warn("generated random pix key already registered", "attempt", attempt+1)
+			continue
+		}
+		if err.Err != "not_found" {
+			return nil, err
+		}
+
+		return pixKey, nil
+	}
+
+	return nil, internal_error.NewInternalServerError("error generating random pix key", nil)
 }
@@ -2,10 +2,14 @@ package receiver_usecase
 
 import (
 	"context"
-	"log/slog"
 
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/auth"
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
 	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type UpdateReceiverInput struct {
@@ -16,13 +20,18 @@ type UpdateReceiverInput struct {
 	PixKeyType  string `json:"pix_key_type"`
 }
 
-func (uc *ReceiverUseCase) UpdateReceiver(ctx context.Context, receiverId pkg_entity.ID, input UpdateReceiverInput) *internal_error.InternalError {
+func (uc *ReceiverUseCase) UpdateReceiver(ctx context.Context, receiverId pkg_entity.ID, input UpdateReceiverInput) (err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ReceiverUseCase.UpdateReceiver", trace.WithAttributes(attribute.String("receiver_id", receiverId.String())))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	receiver, err := uc.receiverRepository.FindReceiver(ctx, receiverId)
 	if err != nil {
-		slog.Error("error finding receiver")
+		logger.FromContext(ctx).Warn("error finding receiver", "receiver_id", receiverId.String(), "error", err.Error())
 		return err
 	}
 
+	previousOwner := receiver.Owner()
+
 	if err := receiver.UpdateReceiver(
 		input.Document,
 		input.PixKeyValue,
@@ -30,9 +39,26 @@ func (uc *ReceiverUseCase) UpdateReceiver(ctx context.Context, receiverId pkg_en
 		input.Name,
 		input.Email,
 	); err != nil {
-		slog.Error("error updating receiver")
+		logger.FromContext(ctx).Warn("error updating receiver", "receiver_id", receiverId.String(), "error", err.Error())
+		return err
+	}
+
+	if err := uc.ensurePixKeyAvailable(ctx, receiver); err != nil {
+		return err
+	}
+
+	// The key counts towards the limit of its new owner when the document changes.
+	if receiver.Owner() != previousOwner {
+		err = uc.saveWithinPixKeyLimit(ctx, receiver, func(ctx context.Context) *internal_error.InternalError {
+			return uc.receiverRepository.UpdateReceiver(ctx, receiver)
+		})
+	} else {
+		err = uc.receiverRepository.UpdateReceiver(ctx, receiver)
+	}
+	if err != nil {
 		return err
 	}
 
-	return uc.receiverRepository.UpdateReceiver(ctx, receiver)
+	logger.FromContext(ctx).Info("receiver updated", "receiver_id", receiver.ReceiverId.String(), "actor", auth.ActorFromContext(ctx))
+	return nil
 }
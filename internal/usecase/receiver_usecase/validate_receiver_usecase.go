@@ -0,0 +1,49 @@
+package receiver_usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/felipemagrassi/pix-api/configuration/logger"
+	"github.com/felipemagrassi/pix-api/configuration/tracing"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ValidateReceiver marks a draft receiver as valid, after which only its email can
+// be changed.
+func (uc *ReceiverUseCase) ValidateReceiver(ctx context.Context, receiverId pkg_entity.ID) (err *internal_error.InternalError) {
+	ctx, span := tracer.Start(ctx, "ReceiverUseCase.ValidateReceiver", trace.WithAttributes(attribute.String("receiver_id", receiverId.String())))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	receiver, err := uc.receiverRepository.FindReceiver(ctx, receiverId)
+	if err != nil {
+		return err
+	}
+
+	if receiver.GetStatus() == entity.Valid {
+		return internal_error.NewBadRequestError("Receiver is already valid", internal_error.Causes{Field: "status", Message: "Receiver is already valid"})
+	}
+
+	if err := receiver.Validate(); err != nil {
+		return err
+	}
+
+	receiver.ValidateReceiverStatus()
+	receiver.UpdatedAt = time.Now()
+
+	if err := uc.receiverRepository.UpdateReceiver(ctx, receiver); err != nil {
+		return err
+	}
+
+	if uc.Events != nil {
+		uc.Events.ReceiverValidated(ctx, receiver)
+	}
+
+	logger.FromContext(ctx).Info("receiver validated", "receiver_id", receiver.ReceiverId.String(), "actor", auth.ActorFromContext(ctx))
+	return nil
+}
@@ -0,0 +1,27 @@
+package receiver_usecase
+
+import (
+	"testing"
+
+	"github.com/felipemagrassi/pix-api/internal/entity"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReceiver(t *testing.T) {
+	uc := newExportUseCase(t)
+
+	output, err := uc.FindReceivers(exportCtx, FindReceiversInput{Status: -1, PixKeyType: -1, Page: 1})
+	assert.Nil(t, err)
+	receiverId, parseErr := pkg_entity.ParseID(output.Receivers[0].ReceiverId)
+	assert.Nil(t, parseErr)
+
+	assert.Nil(t, uc.ValidateReceiver(exportCtx, receiverId))
+
+	validated, err := uc.FindReceiverById(exportCtx, receiverId)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.Valid, validated.Status)
+
+	err = uc.ValidateReceiver(exportCtx, receiverId)
+	assert.Equal(t, "bad_request", err.Err)
+}
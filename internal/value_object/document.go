@@ -2,7 +2,9 @@ package value_object
 
 import (
 	"fmt"
+	"log/slog"
 	"regexp"
+	"strings"
 
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
 )
@@ -15,6 +17,8 @@ type (
 type Document interface {
 	Validate() *internal_error.InternalError
 	String() string
+	Digits() string
+	Mask() string
 }
 
 func (cpf CPF) String() string {
@@ -107,6 +111,59 @@ func (cnpj CNPJ) Validate() *internal_error.InternalError {
 	return nil
 }
 
+// Digits returns the CPF without punctuation.
+func (cpf CPF) Digits() string {
+	return onlyDigits(cpf.String())
+}
+
+// Digits returns the CNPJ without punctuation.
+func (cnpj CNPJ) Digits() string {
+	return onlyDigits(cnpj.String())
+}
+
+// Mask hides the first three and the last two digits of the CPF,
+// e.g. ***.456.789-**.
+func (cpf CPF) Mask() string {
+	digits := onlyDigits(cpf.String())
+	if len(digits) != 11 {
+		return strings.Repeat("*", len(cpf))
+	}
+
+	return fmt.Sprintf("***.%s.%s-**", digits[3:6], digits[6:9])
+}
+
+// Mask hides the first two and the last two digits of the CNPJ,
+// e.g. **.345.678/0001-**.
+func (cnpj CNPJ) Mask() string {
+	digits := onlyDigits(cnpj.String())
+	if len(digits) != 14 {
+		return strings.Repeat("*", len(cnpj))
+	}
+
+	return fmt.Sprintf("**.%s.%s/%s-**", digits[2:5], digits[5:8], digits[8:12])
+}
+
+// LogValue logs the CPF masked.
+func (cpf CPF) LogValue() slog.Value {
+	return slog.StringValue(cpf.Mask())
+}
+
+// LogValue logs the CNPJ masked.
+func (cnpj CNPJ) LogValue() slog.Value {
+	return slog.StringValue(cnpj.Mask())
+}
+
+func onlyDigits(value string) string {
+	var builder strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
+
 func isValidCpf(cpf string) bool {
 	_, err := NewCPF(cpf)
 	if err != nil {
@@ -27,3 +27,14 @@ func TestCannotCreateCNPJ(t *testing.T) {
 	_, err := NewCNPJ("12.345.678/0001-00")
 	assert.Error(t, err)
 }
+
+func TestMaskCPF(t *testing.T) {
+	assert.Equal(t, "***.456.789-**", CPF("123.456.789-09").Mask())
+	assert.Equal(t, "***.456.789-**", CPF("12345678909").Mask())
+	assert.Equal(t, "****", CPF("1234").Mask())
+}
+
+func TestMaskCNPJ(t *testing.T) {
+	assert.Equal(t, "**.299.131/0001-**", CNPJ("41.299.131/0001-07").Mask())
+	assert.Equal(t, "**.299.131/0001-**", CNPJ("41299131000107").Mask())
+}
@@ -2,17 +2,29 @@ package value_object
 
 import (
 	"fmt"
+	"log/slog"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/felipemagrassi/pix-api/internal/internal_error"
+	"golang.org/x/net/idna"
 )
 
 type Email string
 
 const (
+	// EmailKeyPattern is the pattern of receiver contact emails.
 	EmailKeyPattern = `^[a-z0-9+_.-]+@[a-z0-9.-]+$`
+	// EmailPixKeyPattern is the pattern DICT accepts for email keys: a lowercase
+	// local part and an ASCII domain with a top-level domain.
+	EmailPixKeyPattern = `^[a-z0-9.!#$&'*+/=?^_` + "`" + `{|}~-]+@(?:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?\.)+(?:[a-z]{2,63}|xn--[a-z0-9-]{1,59})$`
+	// EmailPixKeyMaxLength is the maximum length of an email key in DICT.
+	EmailPixKeyMaxLength = 77
 )
 
+var emailPixKeyRegexp = regexp.MustCompile(EmailPixKeyPattern)
+
 func NewEmail(email string) (Email, *internal_error.InternalError) {
 	newEmail := Email(email)
 
@@ -27,6 +39,39 @@ func (e Email) String() string {
 	return string(e)
 }
 
+// Normalize returns the email as pix keys are stored: lowercase, with an
+// internationalized domain converted to punycode, e.g. joao@ação.br becomes
+// joao@xn--ao-siap.br.
+func (e Email) Normalize() Email {
+	local, domain, found := strings.Cut(strings.ToLower(e.String()), "@")
+	if !found {
+		return Email(strings.ToLower(e.String()))
+	}
+
+	if asciiDomain, err := idna.Lookup.ToASCII(domain); err == nil {
+		domain = asciiDomain
+	}
+
+	return Email(local + "@" + domain)
+}
+
+// Mask keeps the first character of the local part and the domain,
+// e.g. f*****@email.com.
+func (e Email) Mask() string {
+	local, domain, found := strings.Cut(e.String(), "@")
+	if !found || local == "" {
+		return strings.Repeat("*", utf8.RuneCountInString(e.String()))
+	}
+
+	first, size := utf8.DecodeRuneInString(local)
+	return string(first) + strings.Repeat("*", utf8.RuneCountInString(local[size:])) + "@" + domain
+}
+
+// LogValue logs the email masked.
+func (e Email) LogValue() slog.Value {
+	return slog.StringValue(e.Mask())
+}
+
 func (e Email) Validate() *internal_error.InternalError {
 	re, err := regexp.Compile(EmailKeyPattern)
 	if err != nil {
@@ -44,3 +89,27 @@ func (e Email) Validate() *internal_error.InternalError {
 
 	return nil
 }
+
+// ValidatePixKey checks the DICT rules for email keys, stricter than the ones of
+// contact emails checked by Validate. The email must be normalized.
+func (e Email) ValidatePixKey() *internal_error.InternalError {
+	invalid := func(message string) *internal_error.InternalError {
+		return internal_error.NewBadRequestError("Invalid Email", internal_error.Causes{Field: "email", Message: message})
+	}
+
+	if len(e.String()) > EmailPixKeyMaxLength {
+		return invalid(fmt.Sprintf("Email must have at most %d characters", EmailPixKeyMaxLength))
+	}
+
+	local, domain, found := strings.Cut(e.String(), "@")
+	if found && !strings.Contains(domain, ".") {
+		return invalid("Email domain must have a top-level domain")
+	}
+
+	if !found || !emailPixKeyRegexp.MatchString(e.String()) || len(local) > 64 ||
+		strings.HasPrefix(local, ".") || strings.HasSuffix(local, ".") || strings.Contains(local, "..") {
+		return invalid("Invalid Email")
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package value_object
 
 import (
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -17,3 +18,21 @@ func TestEmptyEmail(t *testing.T) {
 	assert.Error(t, err)
 	assert.Empty(t, email)
 }
+
+func TestMaskEmail(t *testing.T) {
+	assert.Equal(t, "f*****@email.com", Email("felipe@email.com").Mask())
+	assert.Equal(t, "***", Email("abc").Mask())
+	assert.Equal(t, "é****@email.com", Email("élise@email.com").Mask())
+	assert.True(t, utf8.ValidString(Email("ñ@email.com").Mask()))
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, Email("joao@email.com"), Email("Joao@Email.COM").Normalize())
+	assert.Equal(t, Email("joao@xn--ao-siap.br"), Email("joao@ação.br").Normalize())
+}
+
+func TestContactEmailRulesAreLooserThanPixKeyRules(t *testing.T) {
+	email := Email("felipe@email")
+	assert.Nil(t, email.Validate())
+	assert.NotNil(t, email.ValidatePixKey())
+}
@@ -0,0 +1,1262 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: pix/receiver/v1/receiver_service.proto
+
+package receiverv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReceiverStatus int32
+
+const (
+	ReceiverStatus_RECEIVER_STATUS_UNSPECIFIED ReceiverStatus = 0
+	ReceiverStatus_RECEIVER_STATUS_VALID       ReceiverStatus = 1
+	ReceiverStatus_RECEIVER_STATUS_DRAFT       ReceiverStatus = 2
+)
+
+// Enum value maps for ReceiverStatus.
+var (
+	ReceiverStatus_name = map[int32]string{
+		0: "RECEIVER_STATUS_UNSPECIFIED",
+		1: "RECEIVER_STATUS_VALID",
+		2: "RECEIVER_STATUS_DRAFT",
+	}
+	ReceiverStatus_value = map[string]int32{
+		"RECEIVER_STATUS_UNSPECIFIED": 0,
+		"RECEIVER_STATUS_VALID":       1,
+		"RECEIVER_STATUS_DRAFT":       2,
+	}
+)
+
+func (x ReceiverStatus) Enum() *ReceiverStatus {
+	p := new(ReceiverStatus)
+	*p = x
+	return p
+}
+
+func (x ReceiverStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReceiverStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_pix_receiver_v1_receiver_service_proto_enumTypes[0].Descriptor()
+}
+
+func (ReceiverStatus) Type() protoreflect.EnumType {
+	return &file_pix_receiver_v1_receiver_service_proto_enumTypes[0]
+}
+
+func (x ReceiverStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReceiverStatus.Descriptor instead.
+func (ReceiverStatus) EnumDescriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{0}
+}
+
+type PixKeyType int32
+
+const (
+	PixKeyType_PIX_KEY_TYPE_UNSPECIFIED PixKeyType = 0
+	PixKeyType_PIX_KEY_TYPE_CNPJ        PixKeyType = 1
+	PixKeyType_PIX_KEY_TYPE_CPF         PixKeyType = 2
+	PixKeyType_PIX_KEY_TYPE_EMAIL       PixKeyType = 3
+	PixKeyType_PIX_KEY_TYPE_PHONE       PixKeyType = 4
+	PixKeyType_PIX_KEY_TYPE_RANDOM      PixKeyType = 5
+)
+
+// Enum value maps for PixKeyType.
+var (
+	PixKeyType_name = map[int32]string{
+		0: "PIX_KEY_TYPE_UNSPECIFIED",
+		1: "PIX_KEY_TYPE_CNPJ",
+		2: "PIX_KEY_TYPE_CPF",
+		3: "PIX_KEY_TYPE_EMAIL",
+		4: "PIX_KEY_TYPE_PHONE",
+		5: "PIX_KEY_TYPE_RANDOM",
+	}
+	PixKeyType_value = map[string]int32{
+		"PIX_KEY_TYPE_UNSPECIFIED": 0,
+		"PIX_KEY_TYPE_CNPJ":        1,
+		"PIX_KEY_TYPE_CPF":         2,
+		"PIX_KEY_TYPE_EMAIL":       3,
+		"PIX_KEY_TYPE_PHONE":       4,
+		"PIX_KEY_TYPE_RANDOM":      5,
+	}
+)
+
+func (x PixKeyType) Enum() *PixKeyType {
+	p := new(PixKeyType)
+	*p = x
+	return p
+}
+
+func (x PixKeyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PixKeyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pix_receiver_v1_receiver_service_proto_enumTypes[1].Descriptor()
+}
+
+func (PixKeyType) Type() protoreflect.EnumType {
+	return &file_pix_receiver_v1_receiver_service_proto_enumTypes[1]
+}
+
+func (x PixKeyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PixKeyType.Descriptor instead.
+func (PixKeyType) EnumDescriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{1}
+}
+
+type PixKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type  PixKeyType `protobuf:"varint,1,opt,name=type,proto3,enum=pix.receiver.v1.PixKeyType" json:"type,omitempty"`
+	Value string     `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *PixKey) Reset() {
+	*x = PixKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PixKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PixKey) ProtoMessage() {}
+
+func (x *PixKey) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PixKey.ProtoReflect.Descriptor instead.
+func (*PixKey) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PixKey) GetType() PixKeyType {
+	if x != nil {
+		return x.Type
+	}
+	return PixKeyType_PIX_KEY_TYPE_UNSPECIFIED
+}
+
+func (x *PixKey) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type Receiver struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReceiverId    string                 `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Document      string                 `protobuf:"bytes,3,opt,name=document,proto3" json:"document,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Status        ReceiverStatus         `protobuf:"varint,5,opt,name=status,proto3,enum=pix.receiver.v1.ReceiverStatus" json:"status,omitempty"`
+	Bank          string                 `protobuf:"bytes,6,opt,name=bank,proto3" json:"bank,omitempty"`
+	Office        string                 `protobuf:"bytes,7,opt,name=office,proto3" json:"office,omitempty"`
+	AccountNumber string                 `protobuf:"bytes,8,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	PixKey        *PixKey                `protobuf:"bytes,9,opt,name=pix_key,json=pixKey,proto3" json:"pix_key,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *Receiver) Reset() {
+	*x = Receiver{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Receiver) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receiver) ProtoMessage() {}
+
+func (x *Receiver) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receiver.ProtoReflect.Descriptor instead.
+func (*Receiver) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Receiver) GetReceiverId() string {
+	if x != nil {
+		return x.ReceiverId
+	}
+	return ""
+}
+
+func (x *Receiver) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Receiver) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *Receiver) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Receiver) GetStatus() ReceiverStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReceiverStatus_RECEIVER_STATUS_UNSPECIFIED
+}
+
+func (x *Receiver) GetBank() string {
+	if x != nil {
+		return x.Bank
+	}
+	return ""
+}
+
+func (x *Receiver) GetOffice() string {
+	if x != nil {
+		return x.Office
+	}
+	return ""
+}
+
+func (x *Receiver) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *Receiver) GetPixKey() *PixKey {
+	if x != nil {
+		return x.PixKey
+	}
+	return nil
+}
+
+func (x *Receiver) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Receiver) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateReceiverRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Document string  `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	Email    string  `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	PixKey   *PixKey `protobuf:"bytes,4,opt,name=pix_key,json=pixKey,proto3" json:"pix_key,omitempty"`
+}
+
+func (x *CreateReceiverRequest) Reset() {
+	*x = CreateReceiverRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateReceiverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReceiverRequest) ProtoMessage() {}
+
+func (x *CreateReceiverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReceiverRequest.ProtoReflect.Descriptor instead.
+func (*CreateReceiverRequest) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateReceiverRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateReceiverRequest) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *CreateReceiverRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateReceiverRequest) GetPixKey() *PixKey {
+	if x != nil {
+		return x.PixKey
+	}
+	return nil
+}
+
+type CreateReceiverResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReceiverId string  `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	PixKey     *PixKey `protobuf:"bytes,2,opt,name=pix_key,json=pixKey,proto3" json:"pix_key,omitempty"`
+}
+
+func (x *CreateReceiverResponse) Reset() {
+	*x = CreateReceiverResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateReceiverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReceiverResponse) ProtoMessage() {}
+
+func (x *CreateReceiverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReceiverResponse.ProtoReflect.Descriptor instead.
+func (*CreateReceiverResponse) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateReceiverResponse) GetReceiverId() string {
+	if x != nil {
+		return x.ReceiverId
+	}
+	return ""
+}
+
+func (x *CreateReceiverResponse) GetPixKey() *PixKey {
+	if x != nil {
+		return x.PixKey
+	}
+	return nil
+}
+
+type UpdateReceiverRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReceiverId string  `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	Name       string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Document   string  `protobuf:"bytes,3,opt,name=document,proto3" json:"document,omitempty"`
+	Email      string  `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	PixKey     *PixKey `protobuf:"bytes,5,opt,name=pix_key,json=pixKey,proto3" json:"pix_key,omitempty"`
+}
+
+func (x *UpdateReceiverRequest) Reset() {
+	*x = UpdateReceiverRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReceiverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReceiverRequest) ProtoMessage() {}
+
+func (x *UpdateReceiverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReceiverRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReceiverRequest) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateReceiverRequest) GetReceiverId() string {
+	if x != nil {
+		return x.ReceiverId
+	}
+	return ""
+}
+
+func (x *UpdateReceiverRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateReceiverRequest) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *UpdateReceiverRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UpdateReceiverRequest) GetPixKey() *PixKey {
+	if x != nil {
+		return x.PixKey
+	}
+	return nil
+}
+
+type UpdateReceiverResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateReceiverResponse) Reset() {
+	*x = UpdateReceiverResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReceiverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReceiverResponse) ProtoMessage() {}
+
+func (x *UpdateReceiverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReceiverResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReceiverResponse) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{5}
+}
+
+type GetReceiverRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReceiverId string `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+}
+
+func (x *GetReceiverRequest) Reset() {
+	*x = GetReceiverRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReceiverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiverRequest) ProtoMessage() {}
+
+func (x *GetReceiverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiverRequest.ProtoReflect.Descriptor instead.
+func (*GetReceiverRequest) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetReceiverRequest) GetReceiverId() string {
+	if x != nil {
+		return x.ReceiverId
+	}
+	return ""
+}
+
+type GetReceiverResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Receiver *Receiver `protobuf:"bytes,1,opt,name=receiver,proto3" json:"receiver,omitempty"`
+}
+
+func (x *GetReceiverResponse) Reset() {
+	*x = GetReceiverResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReceiverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiverResponse) ProtoMessage() {}
+
+func (x *GetReceiverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiverResponse.ProtoReflect.Descriptor instead.
+func (*GetReceiverResponse) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetReceiverResponse) GetReceiver() *Receiver {
+	if x != nil {
+		return x.Receiver
+	}
+	return nil
+}
+
+// ListReceiversRequest filters are ignored when left unset. Name accepts the
+// SQL LIKE wildcards % and _.
+type ListReceiversRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status      ReceiverStatus `protobuf:"varint,1,opt,name=status,proto3,enum=pix.receiver.v1.ReceiverStatus" json:"status,omitempty"`
+	Name        string         `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PixKeyValue string         `protobuf:"bytes,3,opt,name=pix_key_value,json=pixKeyValue,proto3" json:"pix_key_value,omitempty"`
+	PixKeyType  PixKeyType     `protobuf:"varint,4,opt,name=pix_key_type,json=pixKeyType,proto3,enum=pix.receiver.v1.PixKeyType" json:"pix_key_type,omitempty"`
+	// Page starts at 1; zero is the first page.
+	Page int32 `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *ListReceiversRequest) Reset() {
+	*x = ListReceiversRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListReceiversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReceiversRequest) ProtoMessage() {}
+
+func (x *ListReceiversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReceiversRequest.ProtoReflect.Descriptor instead.
+func (*ListReceiversRequest) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListReceiversRequest) GetStatus() ReceiverStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReceiverStatus_RECEIVER_STATUS_UNSPECIFIED
+}
+
+func (x *ListReceiversRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListReceiversRequest) GetPixKeyValue() string {
+	if x != nil {
+		return x.PixKeyValue
+	}
+	return ""
+}
+
+func (x *ListReceiversRequest) GetPixKeyType() PixKeyType {
+	if x != nil {
+		return x.PixKeyType
+	}
+	return PixKeyType_PIX_KEY_TYPE_UNSPECIFIED
+}
+
+func (x *ListReceiversRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type ListReceiversResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CurrentPage int32       `protobuf:"varint,1,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	Receivers   []*Receiver `protobuf:"bytes,2,rep,name=receivers,proto3" json:"receivers,omitempty"`
+}
+
+func (x *ListReceiversResponse) Reset() {
+	*x = ListReceiversResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListReceiversResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReceiversResponse) ProtoMessage() {}
+
+func (x *ListReceiversResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReceiversResponse.ProtoReflect.Descriptor instead.
+func (*ListReceiversResponse) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListReceiversResponse) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *ListReceiversResponse) GetReceivers() []*Receiver {
+	if x != nil {
+		return x.Receivers
+	}
+	return nil
+}
+
+type DeleteReceiversRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReceiverIds []string `protobuf:"bytes,1,rep,name=receiver_ids,json=receiverIds,proto3" json:"receiver_ids,omitempty"`
+}
+
+func (x *DeleteReceiversRequest) Reset() {
+	*x = DeleteReceiversRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteReceiversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReceiversRequest) ProtoMessage() {}
+
+func (x *DeleteReceiversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReceiversRequest.ProtoReflect.Descriptor instead.
+func (*DeleteReceiversRequest) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteReceiversRequest) GetReceiverIds() []string {
+	if x != nil {
+		return x.ReceiverIds
+	}
+	return nil
+}
+
+type DeleteReceiversResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteReceiversResponse) Reset() {
+	*x = DeleteReceiversResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteReceiversResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReceiversResponse) ProtoMessage() {}
+
+func (x *DeleteReceiversResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pix_receiver_v1_receiver_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReceiversResponse.ProtoReflect.Descriptor instead.
+func (*DeleteReceiversResponse) Descriptor() ([]byte, []int) {
+	return file_pix_receiver_v1_receiver_service_proto_rawDescGZIP(), []int{11}
+}
+
+var File_pix_receiver_v1_receiver_service_proto protoreflect.FileDescriptor
+
+var file_pix_receiver_v1_receiver_service_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x70, 0x69, 0x78, 0x2f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2f, 0x76,
+	0x31, 0x2f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4f, 0x0a, 0x06, 0x50, 0x69,
+	0x78, 0x4b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x78, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xa5, 0x03, 0x0a, 0x08,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12,
+	0x37, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1f, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x6e, 0x6b,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x66,
+	0x66, 0x69, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x07, 0x70,
+	0x69, 0x78, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70,
+	0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x69, 0x78, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x70, 0x69, 0x78, 0x4b, 0x65, 0x79, 0x12, 0x39, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x22, 0x8f, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x12, 0x30, 0x0a, 0x07, 0x70, 0x69, 0x78, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x78, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x70,
+	0x69, 0x78, 0x4b, 0x65, 0x79, 0x22, 0x6b, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x30, 0x0a, 0x07, 0x70, 0x69, 0x78, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x78, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x70, 0x69, 0x78, 0x4b,
+	0x65, 0x79, 0x22, 0xb0, 0x01, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x12, 0x30, 0x0a, 0x07, 0x70, 0x69, 0x78, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x78, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x70,
+	0x69, 0x78, 0x4b, 0x65, 0x79, 0x22, 0x18, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x35, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x22, 0x4c, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a,
+	0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x72, 0x22, 0xda, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e,
+	0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x69,
+	0x78, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x70, 0x69, 0x78, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3d,
+	0x0a, 0x0c, 0x70, 0x69, 0x78, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x78, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x0a, 0x70, 0x69, 0x78, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67,
+	0x65, 0x22, 0x73, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12, 0x37, 0x0a,
+	0x09, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x09, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x22, 0x3b, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x73, 0x22, 0x19, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x67,
+	0x0a, 0x0e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1f, 0x0a, 0x1b, 0x52, 0x45, 0x43, 0x45, 0x49, 0x56, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x45, 0x43, 0x45, 0x49, 0x56, 0x45, 0x52, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15,
+	0x52, 0x45, 0x43, 0x45, 0x49, 0x56, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x44, 0x52, 0x41, 0x46, 0x54, 0x10, 0x02, 0x2a, 0xa0, 0x01, 0x0a, 0x0a, 0x50, 0x69, 0x78, 0x4b,
+	0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x49, 0x58, 0x5f, 0x4b, 0x45,
+	0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x49, 0x58, 0x5f, 0x4b, 0x45, 0x59, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4e, 0x50, 0x4a, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x50,
+	0x49, 0x58, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x50, 0x46, 0x10,
+	0x02, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x49, 0x58, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x49, 0x58,
+	0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x48, 0x4f, 0x4e, 0x45, 0x10,
+	0x04, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x49, 0x58, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x52, 0x41, 0x4e, 0x44, 0x4f, 0x4d, 0x10, 0x05, 0x32, 0xf7, 0x03, 0x0a, 0x0f, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x61,
+	0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x12, 0x26, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x61, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x12, 0x26, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x69,
+	0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x12, 0x23, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e,
+	0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x12,
+	0x25, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64,
+	0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x73, 0x12, 0x27, 0x2e, 0x70, 0x69, 0x78, 0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x70, 0x69, 0x78,
+	0x2e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x66, 0x65, 0x6c, 0x69, 0x70, 0x65, 0x6d, 0x61, 0x67, 0x72, 0x61, 0x73, 0x73,
+	0x69, 0x2f, 0x70, 0x69, 0x78, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62,
+	0x2f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pix_receiver_v1_receiver_service_proto_rawDescOnce sync.Once
+	file_pix_receiver_v1_receiver_service_proto_rawDescData = file_pix_receiver_v1_receiver_service_proto_rawDesc
+)
+
+func file_pix_receiver_v1_receiver_service_proto_rawDescGZIP() []byte {
+	file_pix_receiver_v1_receiver_service_proto_rawDescOnce.Do(func() {
+		file_pix_receiver_v1_receiver_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_pix_receiver_v1_receiver_service_proto_rawDescData)
+	})
+	return file_pix_receiver_v1_receiver_service_proto_rawDescData
+}
+
+var file_pix_receiver_v1_receiver_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_pix_receiver_v1_receiver_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_pix_receiver_v1_receiver_service_proto_goTypes = []any{
+	(ReceiverStatus)(0),             // 0: pix.receiver.v1.ReceiverStatus
+	(PixKeyType)(0),                 // 1: pix.receiver.v1.PixKeyType
+	(*PixKey)(nil),                  // 2: pix.receiver.v1.PixKey
+	(*Receiver)(nil),                // 3: pix.receiver.v1.Receiver
+	(*CreateReceiverRequest)(nil),   // 4: pix.receiver.v1.CreateReceiverRequest
+	(*CreateReceiverResponse)(nil),  // 5: pix.receiver.v1.CreateReceiverResponse
+	(*UpdateReceiverRequest)(nil),   // 6: pix.receiver.v1.UpdateReceiverRequest
+	(*UpdateReceiverResponse)(nil),  // 7: pix.receiver.v1.UpdateReceiverResponse
+	(*GetReceiverRequest)(nil),      // 8: pix.receiver.v1.GetReceiverRequest
+	(*GetReceiverResponse)(nil),     // 9: pix.receiver.v1.GetReceiverResponse
+	(*ListReceiversRequest)(nil),    // 10: pix.receiver.v1.ListReceiversRequest
+	(*ListReceiversResponse)(nil),   // 11: pix.receiver.v1.ListReceiversResponse
+	(*DeleteReceiversRequest)(nil),  // 12: pix.receiver.v1.DeleteReceiversRequest
+	(*DeleteReceiversResponse)(nil), // 13: pix.receiver.v1.DeleteReceiversResponse
+	(*timestamppb.Timestamp)(nil),   // 14: google.protobuf.Timestamp
+}
+var file_pix_receiver_v1_receiver_service_proto_depIdxs = []int32{
+	1,  // 0: pix.receiver.v1.PixKey.type:type_name -> pix.receiver.v1.PixKeyType
+	0,  // 1: pix.receiver.v1.Receiver.status:type_name -> pix.receiver.v1.ReceiverStatus
+	2,  // 2: pix.receiver.v1.Receiver.pix_key:type_name -> pix.receiver.v1.PixKey
+	14, // 3: pix.receiver.v1.Receiver.created_at:type_name -> google.protobuf.Timestamp
+	14, // 4: pix.receiver.v1.Receiver.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 5: pix.receiver.v1.CreateReceiverRequest.pix_key:type_name -> pix.receiver.v1.PixKey
+	2,  // 6: pix.receiver.v1.CreateReceiverResponse.pix_key:type_name -> pix.receiver.v1.PixKey
+	2,  // 7: pix.receiver.v1.UpdateReceiverRequest.pix_key:type_name -> pix.receiver.v1.PixKey
+	3,  // 8: pix.receiver.v1.GetReceiverResponse.receiver:type_name -> pix.receiver.v1.Receiver
+	0,  // 9: pix.receiver.v1.ListReceiversRequest.status:type_name -> pix.receiver.v1.ReceiverStatus
+	1,  // 10: pix.receiver.v1.ListReceiversRequest.pix_key_type:type_name -> pix.receiver.v1.PixKeyType
+	3,  // 11: pix.receiver.v1.ListReceiversResponse.receivers:type_name -> pix.receiver.v1.Receiver
+	4,  // 12: pix.receiver.v1.ReceiverService.CreateReceiver:input_type -> pix.receiver.v1.CreateReceiverRequest
+	6,  // 13: pix.receiver.v1.ReceiverService.UpdateReceiver:input_type -> pix.receiver.v1.UpdateReceiverRequest
+	8,  // 14: pix.receiver.v1.ReceiverService.GetReceiver:input_type -> pix.receiver.v1.GetReceiverRequest
+	10, // 15: pix.receiver.v1.ReceiverService.ListReceivers:input_type -> pix.receiver.v1.ListReceiversRequest
+	12, // 16: pix.receiver.v1.ReceiverService.DeleteReceivers:input_type -> pix.receiver.v1.DeleteReceiversRequest
+	5,  // 17: pix.receiver.v1.ReceiverService.CreateReceiver:output_type -> pix.receiver.v1.CreateReceiverResponse
+	7,  // 18: pix.receiver.v1.ReceiverService.UpdateReceiver:output_type -> pix.receiver.v1.UpdateReceiverResponse
+	9,  // 19: pix.receiver.v1.ReceiverService.GetReceiver:output_type -> pix.receiver.v1.GetReceiverResponse
+	11, // 20: pix.receiver.v1.ReceiverService.ListReceivers:output_type -> pix.receiver.v1.ListReceiversResponse
+	13, // 21: pix.receiver.v1.ReceiverService.DeleteReceivers:output_type -> pix.receiver.v1.DeleteReceiversResponse
+	17, // [17:22] is the sub-list for method output_type
+	12, // [12:17] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_pix_receiver_v1_receiver_service_proto_init() }
+func file_pix_receiver_v1_receiver_service_proto_init() {
+	if File_pix_receiver_v1_receiver_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*PixKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Receiver); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateReceiverRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateReceiverResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateReceiverRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateReceiverResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*GetReceiverRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GetReceiverResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ListReceiversRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ListReceiversResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteReceiversRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pix_receiver_v1_receiver_service_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteReceiversResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pix_receiver_v1_receiver_service_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pix_receiver_v1_receiver_service_proto_goTypes,
+		DependencyIndexes: file_pix_receiver_v1_receiver_service_proto_depIdxs,
+		EnumInfos:         file_pix_receiver_v1_receiver_service_proto_enumTypes,
+		MessageInfos:      file_pix_receiver_v1_receiver_service_proto_msgTypes,
+	}.Build()
+	File_pix_receiver_v1_receiver_service_proto = out.File
+	file_pix_receiver_v1_receiver_service_proto_rawDesc = nil
+	file_pix_receiver_v1_receiver_service_proto_goTypes = nil
+	file_pix_receiver_v1_receiver_service_proto_depIdxs = nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"log/slog"
 	"math/rand"
@@ -9,8 +10,10 @@ import (
 
 	"github.com/felipemagrassi/pix-api/configuration/database/postgres"
 	"github.com/felipemagrassi/pix-api/configuration/env"
+	"github.com/felipemagrassi/pix-api/internal/auth"
 	"github.com/felipemagrassi/pix-api/internal/entity"
 	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	pkg_entity "github.com/felipemagrassi/pix-api/pkg/entity"
 )
 
 var (
@@ -24,15 +27,15 @@ var (
 )
 
 func main() {
-	ctx := context.Background()
+	ctx := auth.WithTenant(context.Background(), auth.DefaultTenant)
 
-	config, err := env.LoadConfig("cmd/api/.env")
+	config, _, err := env.Load(os.Args[1:])
 	if err != nil {
 		log.Fatal(err)
 		os.Exit(1)
 	}
 
-	db, err := postgres.InitializeDatabase(ctx, config.DBUrl, "../../../..")
+	db, err := postgres.InitializeDatabase(ctx, config.DBUrl, postgres.MigrateConfig{AutoMigrate: true, LockTimeout: config.DBMigrateLockTimeout})
 	if err != nil {
 		log.Fatal(err)
 		os.Exit(1)
@@ -47,11 +50,11 @@ func main() {
 	}
 
 	for i := 0; i < 15; i++ {
-		seedReceiver(receiverRepo, PixKey["cpf"], PixKey["cpf"], "cpf", randomArrayElement(RandomNames), randomArrayElement(RandomEmails), Status[rand.Intn(len(Status))])
-		seedReceiver(receiverRepo, PixKey["cpf"], PixKey["email"], "email", randomArrayElement(RandomNames), randomArrayElement(RandomEmails), Status[rand.Intn(len(Status))])
-		seedReceiver(receiverRepo, PixKey["cnpj"], PixKey["phone"], "phone", randomArrayElement(RandomNames), randomArrayElement(RandomEmails), Status[rand.Intn(len(Status))])
-		seedReceiver(receiverRepo, PixKey["cnpj"], PixKey["random"], "random", randomArrayElement(RandomNames), randomArrayElement(RandomEmails), Status[rand.Intn(len(Status))])
-		seedReceiver(receiverRepo, PixKey["cnpj"], PixKey["cnpj"], "cnpj", randomArrayElement(RandomNames), randomArrayElement(RandomEmails), Status[rand.Intn(len(Status))])
+		seedReceiver(ctx, receiverRepo, PixKey["cpf"], seedPixKey("cpf", i), "cpf", randomArrayElement(RandomNames), randomArrayElement(RandomEmails), Status[rand.Intn(len(Status))])
+		seedReceiver(ctx, receiverRepo, PixKey["cpf"], seedPixKey("email", i), "email", randomArrayElement(RandomNames), randomArrayElement(RandomEmails), Status[rand.Intn(len(Status))])
+		seedReceiver(ctx, receiverRepo, PixKey["cnpj"], seedPixKey("phone", i), "phone", randomArrayElement(RandomNames), randomArrayElement(RandomEmails), Status[rand.Intn(len(Status))])
+		seedReceiver(ctx, receiverRepo, PixKey["cnpj"], seedPixKey("random", i), "random", randomArrayElement(RandomNames), randomArrayElement(RandomEmails), Status[rand.Intn(len(Status))])
+		seedReceiver(ctx, receiverRepo, PixKey["cnpj"], seedPixKey("cnpj", i), "cnpj", randomArrayElement(RandomNames), randomArrayElement(RandomEmails), Status[rand.Intn(len(Status))])
 	}
 
 	res, err := db.Query("SELECT COUNT(*) FROM receivers")
@@ -70,7 +73,7 @@ func main() {
 	log.Printf("Total of receivers: %d\n", count)
 }
 
-func seedReceiver(repo entity.ReceiverRepositoryInterface, document, pixKeyValue, pixKeyType, name, email string, status entity.ReceiverStatus) {
+func seedReceiver(ctx context.Context, repo entity.ReceiverRepositoryInterface, document, pixKeyValue, pixKeyType, name, email string, status entity.ReceiverStatus) {
 	receiver, err := entity.NewReceiver(
 		document,
 		pixKeyValue,
@@ -89,12 +92,28 @@ func seedReceiver(repo entity.ReceiverRepositoryInterface, document, pixKeyValue
 		log.Fatal(err)
 	}
 
-	err = repo.CreateReceiver(context.Background(), receiver)
+	err = repo.CreateReceiver(ctx, receiver)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	slog.Info("Receiver created: ", receiver.ReceiverId.String(), receiver.Name, receiver.Email, receiver.Bank, receiver.Office, receiver.AccountNumber, receiver.PixKey.KeyValue, receiver.PixKey.KeyType, receiver.Status)
+	slog.Info("receiver created", "receiver_id", receiver.ReceiverId.String(), "name", receiver.Name, "pix_key_type", receiver.PixKey.KeyType.GetTypeName(), "status", receiver.GetStatus())
+}
+
+// seedPixKey returns the i-th sample key of the type, as pix keys are unique.
+func seedPixKey(keyType string, i int) string {
+	switch keyType {
+	case "email":
+		return fmt.Sprintf("test%d@email.com", i)
+	case "cpf":
+		return fmt.Sprintf("%011d", 12345678901+i)
+	case "phone":
+		return fmt.Sprintf("+55119%08d", 99999999-i)
+	case "cnpj":
+		return fmt.Sprintf("%014d", 41299131000107+i)
+	default:
+		return pkg_entity.NewID().String()
+	}
 }
 
 func seedBank() (string, string, string) {
@@ -14,8 +14,14 @@ import (
 
 	pg "github.com/felipemagrassi/pix-api/configuration/database/postgres"
 	"github.com/felipemagrassi/pix-api/configuration/rest_err"
+	"github.com/felipemagrassi/pix-api/db/migrations"
+	"github.com/felipemagrassi/pix-api/internal/auth"
+	"github.com/felipemagrassi/pix-api/internal/entity"
 	"github.com/felipemagrassi/pix-api/internal/infra/api/web/controller/receiver_controller"
 	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository"
+	"github.com/felipemagrassi/pix-api/internal/infra/database/receiver_repository_contract"
+	"github.com/felipemagrassi/pix-api/internal/infra/encryption"
+	"github.com/felipemagrassi/pix-api/internal/infra/health"
 	"github.com/felipemagrassi/pix-api/internal/usecase/receiver_usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
@@ -154,12 +160,76 @@ func (suite *ReceiverTestSuite) TestCantCreateWithInvalidKeys() {
 	assert.Equal(suite.T(), 1, findReceiversOutput.CurrentPage)
 }
 
+func (suite *ReceiverTestSuite) TestDatabaseHealthCheck() {
+	expectedVersion, err := migrations.LatestVersion(migrations.FS, ".")
+	assert.NoError(suite.T(), err)
+
+	details, err := health.NewDatabaseChecker(suite.Db, expectedVersion).Check(context.Background())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), false, details["migration_dirty"])
+	assert.EqualValues(suite.T(), expectedVersion, details["migration_version"])
+	assert.Equal(suite.T(), false, details["migration_ahead"])
+
+	_, err = health.NewDatabaseChecker(suite.Db, expectedVersion+1).Check(context.Background())
+	assert.Error(suite.T(), err)
+
+	details, err = health.NewDatabaseChecker(suite.Db, expectedVersion-1).Check(context.Background())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), true, details["migration_ahead"])
+
+	_, err = suite.Db.Exec("UPDATE schema_migrations SET dirty = true")
+	assert.NoError(suite.T(), err)
+
+	_, err = health.NewDatabaseChecker(suite.Db, expectedVersion).Check(context.Background())
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReceiverTestSuite) TestMigrateDownAndUp() {
+	ctx := context.Background()
+	migrator := pg.NewMigrator(suite.Db.DB, time.Minute)
+
+	latest, dirty, err := migrator.Version(ctx)
+	suite.NoError(err)
+	suite.False(dirty)
+
+	suite.NoError(migrator.Down(ctx, 1))
+	version, _, err := migrator.Version(ctx)
+	suite.NoError(err)
+	suite.Equal(latest-1, version)
+
+	suite.NoError(migrator.Up(ctx))
+	version, _, err = migrator.Version(ctx)
+	suite.NoError(err)
+	suite.Equal(latest, version)
+}
+
+func (suite *ReceiverTestSuite) TestReceiverRepositoryContract() {
+	receiver_repository_contract.Run(suite.T(), func(t *testing.T) entity.ReceiverRepositoryInterface {
+		return receiver_repository.NewReceiverRepository(suite.Db)
+	})
+}
+
+func (suite *ReceiverTestSuite) TestEncryptedReceiverRepositoryContract() {
+	keyring, err := encryption.NewKeyring([][]byte{bytes.Repeat([]byte{1}, encryption.KeySize)}, bytes.Repeat([]byte{2}, encryption.KeySize))
+	suite.Require().NoError(err)
+
+	receiver_repository_contract.Run(suite.T(), func(t *testing.T) entity.ReceiverRepositoryInterface {
+		repo := receiver_repository.NewReceiverRepository(suite.Db)
+		repo.Keyring = keyring
+		return repo
+	})
+}
+
 func initServer(db *sqlx.DB) *httptest.Server {
 	controller := initDependencies(db)
 
 	g := gin.New()
+	g.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(auth.WithTenant(c.Request.Context(), auth.DefaultTenant))
+	})
 
 	g.GET("/receiver", controller.FindReceivers)
+	g.GET("/receiver/export", controller.ExportReceivers)
 	g.GET("/receiver/:receiverId", controller.FindReceiverById)
 	g.POST("/receiver", controller.CreateReceiver)
 	g.PUT("/receiver/:receiverId", controller.UpdateReceiver)
@@ -206,7 +276,7 @@ func (suite *ReceiverTestSuite) SetupTest() {
 	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
 	assert.NoError(suite.T(), err)
 
-	db, err := pg.InitializeDatabase(ctx, connStr, "../../../..")
+	db, err := pg.InitializeDatabase(ctx, connStr, pg.MigrateConfig{AutoMigrate: true})
 	suite.NoError(err)
 	suite.Db = db
 	suite.Container = postgresContainer